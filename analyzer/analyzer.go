@@ -4,22 +4,75 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/ChmielewskiKamil/solbot/analyzer/constantvars"
+	"github.com/ChmielewskiKamil/solbot/analyzer/functionorder"
+	"github.com/ChmielewskiKamil/solbot/analyzer/immutablevars"
+	"github.com/ChmielewskiKamil/solbot/analyzer/privatefuncunderscore"
+	"github.com/ChmielewskiKamil/solbot/analyzer/privatevarunderscore"
+	"github.com/ChmielewskiKamil/solbot/analyzer/publicexternalfunc"
 	"github.com/ChmielewskiKamil/solbot/analyzer/screamingsnakeconst"
+	"github.com/ChmielewskiKamil/solbot/analyzer/unuseddecl"
+	"github.com/ChmielewskiKamil/solbot/analyzer/unusedevent"
+	"github.com/ChmielewskiKamil/solbot/analyzer/unusedfunction"
+	"github.com/ChmielewskiKamil/solbot/analyzer/unusedimport"
+	"github.com/ChmielewskiKamil/solbot/analyzer/unusedlocalvar"
+	"github.com/ChmielewskiKamil/solbot/analyzer/unusedparams"
+	"github.com/ChmielewskiKamil/solbot/analyzer/unusedreturn"
+	"github.com/ChmielewskiKamil/solbot/analyzer/unusedstatevar"
+	"github.com/ChmielewskiKamil/solbot/analyzer/zeroaddresseth"
 	"github.com/ChmielewskiKamil/solbot/ast"
 	"github.com/ChmielewskiKamil/solbot/parser"
 	"github.com/ChmielewskiKamil/solbot/reporter"
 	"github.com/ChmielewskiKamil/solbot/symbols"
 	"github.com/ChmielewskiKamil/solbot/token"
+	"github.com/ChmielewskiKamil/solbot/types"
 )
 
+// Detector finds the issues in the file. The env is the env of the file
+// after the reference resolution, so the detectors can check how the symbols
+// are used.
 type Detector interface {
-	Detect(node ast.Node) *reporter.Finding
+	Detect(node ast.Node, env *symbols.Environment) *reporter.Finding
 }
 
+// GetAllDetectors returns the detectors with the default settings.
 func GetAllDetectors() *[]Detector {
-	return &[]Detector{
+	return (&Analyzer{}).detectors()
+}
+
+// detectors returns the detectors configured with the options of the
+// analyzer.
+func (a *Analyzer) detectors() *[]Detector {
+	detectors := []Detector{
 		&screamingsnakeconst.Detector{},
+		&unusedevent.Detector{},
+		&unusedfunction.Detector{},
+		&publicexternalfunc.Detector{},
+		&unusedstatevar.Detector{},
+		&constantvars.Detector{},
+		&immutablevars.Detector{},
+		&unusedimport.Detector{},
+		&unusedlocalvar.Detector{},
+		&unusedparams.Detector{},
+		&unusedreturn.Detector{},
+	}
+
+	kinds := unuseddecl.Kinds
+	if a.unusedDeclKinds != nil {
+		kinds = a.unusedDeclKinds
+	}
+	for _, kind := range kinds {
+		detectors = append(detectors, &unuseddecl.Detector{Kind: kind})
 	}
+
+	detectors = append(detectors,
+		&privatefuncunderscore.Detector{CheckPublic: !a.ignorePublicUnderscore},
+		&privatevarunderscore.Detector{CheckPublic: !a.ignorePublicUnderscore},
+		&functionorder.Detector{},
+		&zeroaddresseth.Detector{},
+	)
+
+	return &detectors
 }
 
 type Analyzer struct {
@@ -27,19 +80,73 @@ type Analyzer struct {
 	findings []reporter.Finding
 
 	analysisErrors ErrorList
+	warnings       ErrorList // Diagnostics that don't stop the analysis e.g. shadowing.
+
+	fset        *token.FileSet // All the files parsed by the analyzer.
+	projectRoot string         // The paths of the files are reported relative to it.
+
+	// Whether the underscore detectors skip the public and external names.
+	ignorePublicUnderscore bool
+	// The kinds reported by the unuseddecl detectors; all of them if nil.
+	unusedDeclKinds []unuseddecl.Kind
 
 	currentFile    *ast.File            // The currently analysed file; returned from parser.ParseFile.
 	currentFileEnv *symbols.Environment // The environment of the currently analyred file.
+	typesInfo      *types.Info          // The types of the currently analysed file.
+	results        *ast.ParamList       // The results of the currently resolved function or nil.
+
+	// The envs of the nested blocks of the currently analysed file. The
+	// top-level statements of the function bodies are in the function envs.
+	blockEnvs map[ast.Node]*symbols.Environment
+}
+
+type Option func(*Analyzer)
+
+// WithProjectRoot makes the analyzer report the paths of the files relative
+// to the root. See config.ProjectRoot for how the root is chosen.
+func WithProjectRoot(root string) Option {
+	return func(a *Analyzer) {
+		a.projectRoot = root
+	}
+}
+
+// WithCheckPublicUnderscore sets whether the privatefuncunderscore and
+// privatevarunderscore detectors report the public and external names
+// prefixed with an underscore. They do by default.
+func WithCheckPublicUnderscore(check bool) Option {
+	return func(a *Analyzer) {
+		a.ignorePublicUnderscore = !check
+	}
 }
 
-func (a *Analyzer) Init(filePathToAnalyze string) error {
+// WithUnusedDeclarationKinds sets the kinds of the declarations reported by
+// the unuseddecl detectors. All of them are reported by default; an empty
+// list turns the detectors off.
+func WithUnusedDeclarationKinds(kinds []unuseddecl.Kind) Option {
+	return func(a *Analyzer) {
+		a.unusedDeclKinds = append([]unuseddecl.Kind{}, kinds...)
+	}
+}
+
+func (a *Analyzer) Init(filePathToAnalyze string, opts ...Option) error {
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	a.fset = token.NewFileSet()
+	if a.projectRoot != "" {
+		if err := a.fset.SetRoot(a.projectRoot); err != nil {
+			return fmt.Errorf("Could not set the project root %s: %w", a.projectRoot, err)
+		}
+	}
+
 	f, err := os.Open(filePathToAnalyze)
 	if err != nil {
 		return fmt.Errorf("Could not open the path to analyze %s: %w", filePathToAnalyze, err)
 	}
 	defer f.Close()
 
-	file, err := parser.ParseFile(filePathToAnalyze, f)
+	file, err := parser.ParseFile(filePathToAnalyze, f, parser.WithFileSet(a.fset))
 	if err != nil {
 		return fmt.Errorf("Error while parsing the file %s: %w", filePathToAnalyze, err)
 	}
@@ -54,23 +161,47 @@ func (a *Analyzer) AnalyzeCurrentFile() {
 	a.AnalyzeFile(a.currentFile)
 }
 
+// AnalyzeFile analyzes the file, which becomes the currently analysed file.
+// The file doesn't have to come from Init e.g. the detectors' tests parse
+// their sources themselves.
 func (a *Analyzer) AnalyzeFile(file *ast.File) {
+	a.currentFile = file
 	fileEnv := symbols.NewEnvironment(file.Name, symbols.FILE)
 	a.currentFileEnv = fileEnv
+	a.blockEnvs = make(map[ast.Node]*symbols.Environment)
+
+	// Phase 0: Type check the file. The checking does not stop on errors, so
+	// the types of the correct parts of the file are known anyway.
+	a.typesInfo = types.NewInfo()
+	if err := types.Check(file, a.typesInfo); err != nil {
+		if errs, ok := err.(types.ErrorList); ok {
+			for _, e := range errs {
+				a.analysisErrors.Add(a.GetNodeLocation(file, e.Pos), "Type error: "+e.Msg)
+			}
+		}
+	}
 
 	// Phase 1: Get all declarations first to avoid unknown symbol errors if
 	// the symbols are defined later in a file or somewhere else (inheritance).
 	a.discoverSymbols(file, fileEnv)
+	a.linearizeContracts(fileEnv)
 
 	// Phase 2: Populate all references. Since all declarations in all scopes
 	// should be known at this time, connect them to the place they are used.
 	a.resolveReferences(file, fileEnv)
+	a.resolveImportedSymbols(file, fileEnv)
 
 	// Phase 3: The environment is populated with context at this point.
 	// Diagnose issues with the code. Run detectors.
 	findings := a.detectIssues(file, fileEnv)
 
+	var resolver reporter.PositionResolver = file.SourceFile
+	if a.fset != nil {
+		resolver = a.fset
+	}
+
 	for _, finding := range findings {
+		finding.CalculatePositions(resolver)
 		a.findings = append(a.findings, finding)
 	}
 }
@@ -83,6 +214,12 @@ func (a *Analyzer) GetCurrentFileEnv() *symbols.Environment {
 	return a.currentFileEnv
 }
 
+// TypesInfo returns the types of the expressions of the currently analysed
+// file.
+func (a *Analyzer) TypesInfo() *types.Info {
+	return a.typesInfo
+}
+
 ////////////////////////////////////////////////////////////////////
 //                            PHASE 1			                  //
 ////////////////////////////////////////////////////////////////////
@@ -114,14 +251,119 @@ func (a *Analyzer) discoverSymbols(node ast.Node, outer *symbols.Environment) {
 
 		functionSymbol.SetInnerEnv(functionEnv)
 
-		// Statements in the function's body can be analyzed in the context of
-		// the function's inner env.
-		a.discoverSymbols(n.Body, functionEnv)
+		// The params and the named returns are in the same scope as the
+		// top-level statements of the body, so they share the function's
+		// inner env.
+		for _, param := range functionSymbol.Parameters {
+			a.declareLocal(param.Name, param.Offset, param, functionEnv)
+		}
+		for _, result := range functionSymbol.Results {
+			a.declareLocal(result.Name, result.Offset, result, functionEnv)
+		}
+
+		if n.Body != nil {
+			a.discoverStatements(n.Body.Statements, functionEnv)
+		}
+	case *ast.ConstructorDeclaration:
+		constructorSymbol := a.discoverConstructorDeclaration(n, outer)
+		constructorEnv := symbols.NewEnclosedEnvironment(outer, constructorSymbol.Name, symbols.CONSTRUCTOR)
+
+		constructorSymbol.SetInnerEnv(constructorEnv)
+
+		for _, param := range constructorSymbol.Parameters {
+			a.declareLocal(param.Name, param.Offset, param, constructorEnv)
+		}
+
+		if n.Body != nil {
+			a.discoverStatements(n.Body.Statements, constructorEnv)
+		}
+	case *ast.SpecialFunctionDeclaration:
+		functionSymbol := a.discoverSpecialFunctionDeclaration(n, outer)
+		functionEnv := symbols.NewEnclosedEnvironment(outer, functionSymbol.Name, symbols.FUNCTION)
+
+		functionSymbol.SetInnerEnv(functionEnv)
+
+		for _, param := range functionSymbol.Parameters {
+			a.declareLocal(param.Name, param.Offset, param, functionEnv)
+		}
+		for _, result := range functionSymbol.Results {
+			a.declareLocal(result.Name, result.Offset, result, functionEnv)
+		}
+
+		if n.Body != nil {
+			a.discoverStatements(n.Body.Statements, functionEnv)
+		}
+	case *ast.ModifierDeclaration:
+		modifierSymbol := a.discoverModifierDeclaration(n, outer)
+		modifierEnv := symbols.NewEnclosedEnvironment(outer, n.Name.Value, symbols.MODIFIER)
+
+		modifierSymbol.SetInnerEnv(modifierEnv)
+
+		for _, param := range modifierSymbol.Parameters {
+			a.declareLocal(param.Name, param.Offset, param, modifierEnv)
+		}
+
+		if n.Body != nil {
+			a.discoverStatements(n.Body.Statements, modifierEnv)
+		}
 	case *ast.StateVariableDeclaration:
 		a.discoverStateVariableDeclaration(n, outer)
+	case *ast.ConstantVariableDeclaration:
+		a.discoverConstantVariableDeclaration(n, outer)
 	case *ast.EventDeclaration:
 		// Event declaration can be present in the Contract as well as outside
 		a.discoverEventDeclaration(n, outer)
+	case *ast.StructDeclaration, *ast.EnumDeclaration, *ast.ErrorDeclaration:
+		a.discoverTypeDeclaration(n, outer)
+	case *ast.ImportDirective:
+		a.discoverImportDirective(n, outer)
+	}
+}
+
+// linearizeContracts links the envs of the contracts to the envs of their
+// bases in the order of the C3 linearization, so the inherited symbols can
+// be resolved. It must run after all the contracts in the env are discovered.
+func (a *Analyzer) linearizeContracts(env *symbols.Environment) {
+	parents := func(contract *symbols.Contract) []*symbols.Contract {
+		node, ok := contract.AstNode.(*ast.ContractDeclaration)
+		if !ok {
+			return nil
+		}
+
+		var parents []*symbols.Contract
+		for _, parent := range node.Parents {
+			matchingSymbols, _ := env.Get(parent.Value)
+			for _, symbol := range matchingSymbols {
+				if parentSymbol, ok := symbol.(*symbols.Contract); ok {
+					parents = append(parents, parentSymbol)
+					break
+				}
+			}
+		}
+		return parents
+	}
+
+	for _, contract := range symbols.GetAllSymbolsByType[*symbols.Contract](env) {
+		linearized, err := types.Linearize(contract, parents)
+		if err != nil {
+			// The type checker reports the contracts that can't be
+			// linearized.
+			continue
+		}
+		contract.LinearizedBases = linearized
+
+		contractEnv := contract.GetInnerEnv()
+		if contractEnv == nil {
+			continue
+		}
+
+		var bases []*symbols.Environment
+		for _, base := range linearized[1:] {
+			if baseEnv := base.GetInnerEnv(); baseEnv != nil {
+				bases = append(bases, baseEnv)
+			}
+		}
+		contractEnv.SetBases(bases)
 	}
 }
 
@@ -157,15 +399,107 @@ func (a *Analyzer) discoverFunctionDeclaration(
 		Visibility: node.Visibility,
 		Mutability: node.Mutability,
 		Virtual:    node.Virtual,
+		Body:       node.Body,
 	}
 
-	// TODO: Finish populating function symbol with: Parameters, Results
+	fnSymbol.Parameters = a.discoverParams(node.Params)
+	fnSymbol.Results = a.discoverParams(node.Results)
 
 	env.Set(node.Name.Value, fnSymbol)
 
 	return fnSymbol
 }
 
+// discoverSpecialFunctionDeclaration declares the receive or the fallback
+// function in the contract env as "receive" or "fallback". The names are
+// keywords, so they can't clash with the other symbols.
+func (a *Analyzer) discoverSpecialFunctionDeclaration(
+	node *ast.SpecialFunctionDeclaration, env *symbols.Environment) *symbols.Function {
+	fnSymbol := &symbols.Function{
+		BaseSymbol: symbols.BaseSymbol{
+			Name:       node.Kind.Literal,
+			SourceFile: a.currentFile.SourceFile,
+			Offset:     node.Kind.Pos,
+			AstNode:    node,
+		},
+		Parameters: a.discoverParams(node.Params),
+		Results:    a.discoverParams(node.Results),
+		Visibility: node.Visibility,
+		Mutability: node.Mutability,
+		Virtual:    node.Virtual,
+		Body:       node.Body,
+	}
+
+	env.Set(fnSymbol.Name, fnSymbol)
+
+	return fnSymbol
+}
+
+func (a *Analyzer) discoverConstructorDeclaration(
+	node *ast.ConstructorDeclaration, env *symbols.Environment) *symbols.Constructor {
+	constructorSymbol := &symbols.Constructor{
+		BaseSymbol: symbols.BaseSymbol{
+			Name:       "constructor",
+			SourceFile: a.currentFile.SourceFile,
+			Offset:     node.Pos,
+			AstNode:    node,
+		},
+		Parameters: a.discoverParams(node.Params),
+		Body:       node.Body,
+	}
+
+	env.Set(constructorSymbol.Name, constructorSymbol)
+
+	return constructorSymbol
+}
+
+func (a *Analyzer) discoverModifierDeclaration(
+	node *ast.ModifierDeclaration, env *symbols.Environment) *symbols.Modifier {
+	baseSymbol := symbols.BaseSymbol{
+		Name:       node.Name.Value,
+		SourceFile: a.currentFile.SourceFile,
+		Offset:     node.Name.Pos,
+		AstNode:    node,
+	}
+
+	modifierSymbol := &symbols.Modifier{
+		BaseSymbol: baseSymbol,
+		Parameters: a.discoverParams(node.Params),
+		Virtual:    node.Virtual,
+		Body:       node.Body,
+	}
+
+	env.Set(node.Name.Value, modifierSymbol)
+
+	return modifierSymbol
+}
+
+func (a *Analyzer) discoverParams(params *ast.ParamList) []*symbols.Param {
+	if params == nil {
+		return nil
+	}
+
+	var paramSymbols []*symbols.Param
+	for _, param := range params.List {
+		// Unnamed params can't be referred to, so they have no symbols.
+		if param == nil || param.Name == nil {
+			continue
+		}
+		paramSymbols = append(paramSymbols, &symbols.Param{
+			BaseSymbol: symbols.BaseSymbol{
+				Name:       param.Name.Value,
+				SourceFile: a.currentFile.SourceFile,
+				Offset:     param.Name.Pos,
+				AstNode:    param,
+			},
+			Type:         a.typeOf(param.Type),
+			DataLocation: param.DataLocation,
+		})
+	}
+
+	return paramSymbols
+}
+
 func (a *Analyzer) discoverStateVariableDeclaration(
 	node *ast.StateVariableDeclaration, env *symbols.Environment) {
 	baseSymbol := symbols.BaseSymbol{
@@ -177,11 +511,28 @@ func (a *Analyzer) discoverStateVariableDeclaration(
 
 	stateVarSymbol := &symbols.StateVariable{
 		BaseSymbol: baseSymbol,
+		Type:       a.typeOf(node.Type),
+		Visibility: node.Visibility,
+		Mutability: node.Mutability,
 	}
 
 	env.Set(node.Name.Value, stateVarSymbol)
 }
 
+func (a *Analyzer) discoverConstantVariableDeclaration(
+	node *ast.ConstantVariableDeclaration, env *symbols.Environment) {
+	constVarSymbol := &symbols.ConstantVariable{
+		BaseSymbol: symbols.BaseSymbol{
+			Name:       node.Name.Value,
+			SourceFile: a.currentFile.SourceFile,
+			Offset:     node.Name.Pos,
+			AstNode:    node,
+		},
+	}
+
+	env.Set(node.Name.Value, constVarSymbol)
+}
+
 func (a *Analyzer) discoverEventDeclaration(
 	node *ast.EventDeclaration, env *symbols.Environment) {
 	baseSymbol := symbols.BaseSymbol{
@@ -197,7 +548,8 @@ func (a *Analyzer) discoverEventDeclaration(
 	}
 
 	for _, param := range node.Params.List {
-		if param != nil {
+		// The names of event params are optional.
+		if param != nil && param.Name != nil {
 			eventParamSymbol := &symbols.EventParam{
 				BaseSymbol: symbols.BaseSymbol{
 					Name:       param.Name.Value,
@@ -207,6 +559,7 @@ func (a *Analyzer) discoverEventDeclaration(
 					Offset:  param.Name.Pos,
 					AstNode: param,
 				},
+				Type:      a.typeOf(param.Type),
 				IsIndexed: param.IsIndexed,
 			}
 			eventSymbol.Parameters = append(eventSymbol.Parameters, eventParamSymbol)
@@ -215,6 +568,127 @@ func (a *Analyzer) discoverEventDeclaration(
 	env.Set(node.Name.Value, eventSymbol)
 }
 
+// discoverTypeDeclaration declares the struct, the enum or the custom error.
+// Like the events, they can be declared in the contracts and at the file
+// level.
+func (a *Analyzer) discoverTypeDeclaration(node ast.Node, env *symbols.Environment) {
+	var name *ast.Identifier
+	var symbol symbols.Symbol
+	switch n := node.(type) {
+	case *ast.StructDeclaration:
+		name = n.Name
+		symbol = &symbols.Struct{BaseSymbol: a.declarationBase(n, name)}
+	case *ast.EnumDeclaration:
+		name = n.Name
+		enumSymbol := &symbols.Enum{BaseSymbol: a.declarationBase(n, name)}
+		for _, member := range n.Members {
+			enumSymbol.Members = append(enumSymbol.Members, member.Value)
+		}
+		symbol = enumSymbol
+	case *ast.ErrorDeclaration:
+		name = n.Name
+		symbol = &symbols.CustomError{BaseSymbol: a.declarationBase(n, name)}
+	}
+
+	if name != nil {
+		env.Set(name.Value, symbol)
+	}
+}
+
+// declarationBase returns the base of the symbol declared by the node under
+// the name.
+func (a *Analyzer) declarationBase(node ast.Node, name *ast.Identifier) symbols.BaseSymbol {
+	base := symbols.BaseSymbol{
+		SourceFile: a.currentFile.SourceFile,
+		AstNode:    node,
+	}
+	if name != nil {
+		base.Name = name.Value
+		base.Offset = name.Pos
+	}
+	return base
+}
+
+func (a *Analyzer) discoverStatements(statements []ast.Statement, env *symbols.Environment) {
+	for _, statement := range statements {
+		a.discoverStatement(statement, env)
+	}
+}
+
+// discoverStatement declares the local variables of the statement. The
+// nested blocks get their own envs, so the variables declared in them are
+// not visible outside.
+// TODO: Declare the loop and catch variables once the parser supports the
+// loops and try/catch.
+func (a *Analyzer) discoverStatement(statement ast.Statement, env *symbols.Environment) {
+	switch stmt := statement.(type) {
+	case *ast.BlockStatement:
+		a.discoverStatements(stmt.Statements, a.newBlockEnv(stmt, env))
+	case *ast.UncheckedBlockStatement:
+		a.discoverStatements(stmt.Statements, a.newBlockEnv(stmt, env))
+	case *ast.IfStatement:
+		if stmt.Consequence != nil {
+			a.discoverStatement(stmt.Consequence, env)
+		}
+		if stmt.Alternative != nil {
+			a.discoverStatement(stmt.Alternative, env)
+		}
+	case *ast.VariableDeclarationStatement:
+		a.discoverVariableDeclaration(stmt, env)
+	case *ast.VariableDeclarationTupleStatement:
+		for _, decl := range stmt.Declarations {
+			// The omitted components are nil.
+			if decl != nil {
+				a.discoverVariableDeclaration(decl, env)
+			}
+		}
+	}
+}
+
+// newBlockEnv creates the env of the nested block. References in the block
+// are attributed to the enclosing function, so the env inherits its scope.
+func (a *Analyzer) newBlockEnv(block ast.Node, outer *symbols.Environment) *symbols.Environment {
+	env := symbols.NewEnclosedEnvironment(outer, outer.GetCurrentScopeName(), outer.GetCurrentScopeType())
+	a.blockEnvs[block] = env
+	return env
+}
+
+func (a *Analyzer) discoverVariableDeclaration(
+	node *ast.VariableDeclarationStatement, env *symbols.Environment) {
+	if node.Name == nil {
+		return
+	}
+
+	localVarSymbol := &symbols.LocalVariable{
+		BaseSymbol: symbols.BaseSymbol{
+			Name:       node.Name.Value,
+			SourceFile: a.currentFile.SourceFile,
+			Offset:     node.Name.Pos,
+			AstNode:    node,
+		},
+		Type:         a.typeOf(node.Type),
+		DataLocation: node.DataLocation,
+	}
+
+	a.declareLocal(node.Name.Value, node.Name.Pos, localVarSymbol, env)
+}
+
+// declareLocal adds the local variable or the param to the env. It warns if
+// the declaration shadows a declaration of the outer scopes e.g. a state
+// variable or a local variable of the enclosing block. The redeclarations
+// in the same scope are reported by the type checker.
+func (a *Analyzer) declareLocal(name string, offset token.Pos, symbol symbols.Symbol, env *symbols.Environment) {
+	if outer := env.Outer(); outer != nil {
+		if shadowed, found := outer.Get(name); found {
+			a.warnings.Add(a.GetNodeLocation(a.currentFile, offset),
+				"Shadowing warning: declaration of '"+name+
+					"' shadows an existing declaration at "+shadowed[0].Location()+".")
+		}
+	}
+
+	env.Set(name, symbol)
+}
+
 ////////////////////////////////////////////////////////////////////
 //                            PHASE 2			                  //
 ////////////////////////////////////////////////////////////////////
@@ -229,11 +703,52 @@ func (a *Analyzer) resolveReferences(node ast.Node, env *symbols.Environment) {
 		a.resolveContractDeclaration(n, env)
 	case *ast.FunctionDeclaration:
 		a.resolveFunctionDeclaration(n, env)
+	case *ast.ConstructorDeclaration:
+		a.resolveConstructorDeclaration(n, env)
+	case *ast.SpecialFunctionDeclaration:
+		a.resolveSpecialFunctionDeclaration(n, env)
+	case *ast.ModifierDeclaration:
+		a.resolveModifierDeclaration(n, env)
+	case *ast.StateVariableDeclaration:
+		a.resolveTypeName(n.Type, env)
+		a.resolveExpression(n.Value, symbols.READ, env)
+	case *ast.ConstantVariableDeclaration:
+		a.resolveTypeName(n.Type, env)
+		a.resolveExpression(n.Value, symbols.READ, env)
+	case *ast.EventDeclaration:
+		for _, param := range n.Params.List {
+			a.resolveTypeName(param.Type, env)
+		}
+	case *ast.StructDeclaration:
+		for _, member := range n.Members {
+			a.resolveTypeName(member.Type, env)
+		}
+	case *ast.ErrorDeclaration:
+		a.resolveParamTypes(n.Params, env)
+	case *ast.UsingForDirective:
+		a.resolveTypeName(n.ForType, env)
 	case *ast.BlockStatement:
 		a.resolveBlockStatement(n, env)
 	}
 }
 
+// resolveTypeName attaches the READ reference to the user-defined type the
+// type name refers to e.g. a struct, an enum or a contract.
+func (a *Analyzer) resolveTypeName(typ ast.Type, env *symbols.Environment) {
+	if t, ok := typ.(*ast.UserDefinedType); ok {
+		a.resolveIdentifier(t.Name, symbols.READ, env)
+	}
+}
+
+func (a *Analyzer) resolveParamTypes(params *ast.ParamList, env *symbols.Environment) {
+	if params == nil {
+		return
+	}
+	for _, param := range params.List {
+		a.resolveTypeName(param.Type, env)
+	}
+}
+
 func (a *Analyzer) resolveContractDeclaration(contractNode *ast.ContractDeclaration, env *symbols.Environment) {
 	// Find ENV and resolve in its context.
 	contractSymbol, found := env.Get(contractNode.Name.Value)
@@ -266,22 +781,147 @@ func (a *Analyzer) resolveContractDeclaration(contractNode *ast.ContractDeclarat
 }
 
 func (a *Analyzer) resolveFunctionDeclaration(fnNode *ast.FunctionDeclaration, env *symbols.Environment) {
-	functionSymbol, found := env.Get(fnNode.Name.Value)
-	if !found {
+	functionSymbol := a.declarationSymbol(fnNode, fnNode.Name, env)
+	if functionSymbol == nil {
 		a.analysisErrors.Add(a.GetNodeLocation(fnNode, fnNode.Name.Pos),
 			"Reference resolution error: No symbol with this name found for function '"+
 				fnNode.Name.Value+"'.")
+		return
 	}
 
-	functionEnv, err := symbols.GetInnerEnv(functionSymbol[0])
+	functionEnv, err := symbols.GetInnerEnv(functionSymbol)
 	if err != nil {
 		a.analysisErrors.Add(
 			a.GetNodeLocation(fnNode, fnNode.Name.Pos),
 			"Reference resolution error: "+err.Error(),
 		)
+		return
 	}
 
-	a.resolveReferences(fnNode.Body, functionEnv)
+	a.resolveParamTypes(fnNode.Params, functionEnv)
+	a.resolveParamTypes(fnNode.Results, functionEnv)
+	a.resolveModifierInvocations(fnNode.Modifiers, functionEnv)
+
+	if fnNode.Body != nil {
+		a.results = fnNode.Results
+		a.resolveBlockStatement(fnNode.Body, functionEnv)
+		a.results = nil
+	}
+}
+
+func (a *Analyzer) resolveConstructorDeclaration(constructorNode *ast.ConstructorDeclaration, env *symbols.Environment) {
+	var constructorSymbol symbols.Symbol
+	for _, symbol := range symbols.GetAllSymbolsByType[*symbols.Constructor](env) {
+		if symbol.AstNode == constructorNode {
+			constructorSymbol = symbol
+		}
+	}
+	if constructorSymbol == nil {
+		a.analysisErrors.Add(a.GetNodeLocation(constructorNode, constructorNode.Pos),
+			"Reference resolution error: No symbol found for the constructor.")
+		return
+	}
+
+	constructorEnv, err := symbols.GetInnerEnv(constructorSymbol)
+	if err != nil {
+		a.analysisErrors.Add(
+			a.GetNodeLocation(constructorNode, constructorNode.Pos),
+			"Reference resolution error: "+err.Error(),
+		)
+		return
+	}
+
+	// The calls to the base constructors are resolved like the modifiers;
+	// they refer to the base contracts.
+	a.resolveParamTypes(constructorNode.Params, constructorEnv)
+	a.resolveModifierInvocations(constructorNode.Modifiers, constructorEnv)
+
+	if constructorNode.Body != nil {
+		a.resolveBlockStatement(constructorNode.Body, constructorEnv)
+	}
+}
+
+func (a *Analyzer) resolveSpecialFunctionDeclaration(fnNode *ast.SpecialFunctionDeclaration, env *symbols.Environment) {
+	var functionSymbol symbols.Symbol
+	matchingSymbols, _ := env.Get(fnNode.Kind.Literal)
+	for _, symbol := range matchingSymbols {
+		if symbol.GetAstNode() == fnNode {
+			functionSymbol = symbol
+		}
+	}
+	if functionSymbol == nil {
+		a.analysisErrors.Add(a.GetNodeLocation(fnNode, fnNode.Kind.Pos),
+			"Reference resolution error: No symbol found for the "+fnNode.Kind.Literal+" function.")
+		return
+	}
+
+	functionEnv, err := symbols.GetInnerEnv(functionSymbol)
+	if err != nil {
+		a.analysisErrors.Add(
+			a.GetNodeLocation(fnNode, fnNode.Kind.Pos),
+			"Reference resolution error: "+err.Error(),
+		)
+		return
+	}
+
+	a.resolveParamTypes(fnNode.Params, functionEnv)
+	a.resolveParamTypes(fnNode.Results, functionEnv)
+	a.resolveModifierInvocations(fnNode.Modifiers, functionEnv)
+
+	if fnNode.Body != nil {
+		a.resolveBlockStatement(fnNode.Body, functionEnv)
+	}
+}
+
+// resolveModifierInvocations resolves the modifiers invoked in the header
+// of the function. The modifiers are invoked in the context of the function,
+// so their arguments can refer to the params.
+func (a *Analyzer) resolveModifierInvocations(modifiers []*ast.ModifierInvocation, functionEnv *symbols.Environment) {
+	for _, modifier := range modifiers {
+		a.resolveIdentifier(modifier.Name, symbols.CALL, functionEnv)
+		for _, arg := range modifier.Args {
+			a.resolveExpression(arg, symbols.READ, functionEnv)
+		}
+	}
+}
+
+func (a *Analyzer) resolveModifierDeclaration(modifierNode *ast.ModifierDeclaration, env *symbols.Environment) {
+	modifierSymbol := a.declarationSymbol(modifierNode, modifierNode.Name, env)
+	if modifierSymbol == nil {
+		a.analysisErrors.Add(a.GetNodeLocation(modifierNode, modifierNode.Name.Pos),
+			"Reference resolution error: No symbol with this name found for modifier '"+
+				modifierNode.Name.Value+"'.")
+		return
+	}
+
+	modifierEnv, err := symbols.GetInnerEnv(modifierSymbol)
+	if err != nil {
+		a.analysisErrors.Add(
+			a.GetNodeLocation(modifierNode, modifierNode.Name.Pos),
+			"Reference resolution error: "+err.Error(),
+		)
+		return
+	}
+
+	a.resolveParamTypes(modifierNode.Params, modifierEnv)
+
+	if modifierNode.Body != nil {
+		a.resolveBlockStatement(modifierNode.Body, modifierEnv)
+	}
+}
+
+// declarationSymbol returns the symbol of the declaration from the env it
+// was declared in. The functions can be overloaded, so the symbol is matched
+// by the declaration rather than by the name. It returns nil if there is no
+// such symbol.
+func (a *Analyzer) declarationSymbol(decl ast.Node, name *ast.Identifier, env *symbols.Environment) symbols.Symbol {
+	matchingSymbols, _ := env.Get(name.Value)
+	for _, symbol := range matchingSymbols {
+		if symbol.GetAstNode() == decl {
+			return symbol
+		}
+	}
+	return nil
 }
 
 func (a *Analyzer) resolveBlockStatement(blockNode *ast.BlockStatement, env *symbols.Environment) {
@@ -292,22 +932,354 @@ func (a *Analyzer) resolveBlockStatement(blockNode *ast.BlockStatement, env *sym
 
 func (a *Analyzer) resolveStatement(statement ast.Statement, env *symbols.Environment) {
 	switch stmt := statement.(type) {
+	case *ast.BlockStatement:
+		a.resolveBlockStatement(stmt, a.GetBlockEnv(stmt, env))
+	case *ast.UncheckedBlockStatement:
+		blockEnv := a.GetBlockEnv(stmt, env)
+		for _, statement := range stmt.Statements {
+			a.resolveStatement(statement, blockEnv)
+		}
+	case *ast.IfStatement:
+		a.resolveExpression(stmt.Condition, symbols.READ, env)
+		if stmt.Consequence != nil {
+			a.resolveStatement(stmt.Consequence, env)
+		}
+		if stmt.Alternative != nil {
+			a.resolveStatement(stmt.Alternative, env)
+		}
+	case *ast.VariableDeclarationStatement:
+		a.resolveTypeName(stmt.Type, env)
+		a.resolveExpression(stmt.Value, symbols.READ, env)
+		if stmt.DataLocation == ast.Storage {
+			a.resolveStoragePointer(stmt.Value, env)
+		}
+	case *ast.VariableDeclarationTupleStatement:
+		for _, decl := range stmt.Declarations {
+			if decl != nil {
+				a.resolveTypeName(decl.Type, env)
+			}
+		}
+		a.resolveExpression(stmt.Value, symbols.READ, env)
+	case *ast.ReturnStatement:
+		a.resolveExpression(stmt.Result, symbols.READ, env)
+		a.resolveReturnedStoragePointers(stmt.Result, env)
+	case *ast.ExpressionStatement:
+		a.resolveExpression(stmt.Expression, symbols.READ, env)
 	case *ast.EmitStatement:
 		a.resolveEmitStatement(stmt, env)
+	case *ast.RevertStatement:
+		// The error is called with the arguments, see the CallExpression.
+		a.resolveExpression(stmt.Expression, symbols.READ, env)
+	case *ast.AssemblyStatement:
+		a.resolveAssemblyStatement(stmt, env)
+	}
+}
+
+// resolveAssemblyStatement attaches the references to the symbols used in
+// the inline assembly. The Yul code is not analyzed, so it is not known how
+// the identifiers are used e.g. `sstore(x.slot, 1)` writes to a state
+// variable. Each of them is assumed to be both read and written. The
+// references point to the assembly statement, like the references of the
+// events point to the emit statements.
+func (a *Analyzer) resolveAssemblyStatement(stmt *ast.AssemblyStatement, env *symbols.Environment) {
+	for _, ident := range stmt.Identifiers {
+		symbol := a.referencedSymbol(ident, env.GetAll(ident.Value))
+		if symbol == nil {
+			continue
+		}
+
+		for _, usage := range []symbols.ReferenceUsageType{symbols.READ, symbols.WRITE} {
+			symbol.AddReference(&symbols.Reference{
+				SourceFile: a.currentFile.SourceFile,
+				Offset:     ident.Pos,
+				Context: symbols.ReferenceContext{
+					ScopeName: env.GetCurrentScopeName(),
+					ScopeType: env.GetCurrentScopeType(),
+					Usage:     usage,
+				},
+				AstNode: stmt,
+			})
+		}
+	}
+}
+
+// resolveExpression attaches the references to the symbols used in the
+// expression. The usage is how the expression itself is used; it is passed
+// down to the operands that are used in the same way e.g. the components of
+// the tuple on the left-hand side of an assignment are all written to.
+func (a *Analyzer) resolveExpression(expr ast.Expression, usage symbols.ReferenceUsageType, env *symbols.Environment) {
+	switch e := expr.(type) {
+	case *ast.Identifier:
+		a.resolveIdentifier(e, usage, env)
+	case *ast.PrefixExpression:
+		// `++x`, `--x` and `delete x` write to the operand.
+		switch e.Operator.Type {
+		case token.INC, token.DEC, token.DELETE:
+			a.resolveExpression(e.Right, symbols.WRITE, env)
+		default:
+			a.resolveExpression(e.Right, symbols.READ, env)
+		}
+	case *ast.PostfixExpression:
+		a.resolveExpression(e.Left, symbols.WRITE, env)
+	case *ast.InfixExpression:
+		// The compound assignments e.g. `x += 1` both read and write the
+		// left-hand side, they are considered writes.
+		if token.IsAssignment(e.Operator.Type) {
+			a.resolveExpression(e.Left, symbols.WRITE, env)
+		} else {
+			a.resolveExpression(e.Left, symbols.READ, env)
+		}
+		a.resolveExpression(e.Right, symbols.READ, env)
+		if e.Operator.Type == token.ASSIGN && a.isStoragePointer(e.Left, env) {
+			a.resolveStoragePointer(e.Right, env)
+		}
+	case *ast.TupleExpression:
+		for _, component := range e.Components {
+			a.resolveExpression(component, usage, env)
+		}
+	case *ast.CallExpression:
+		a.resolveExpression(e.Ident, symbols.CALL, env)
+		params := a.calledParams(e.Ident)
+		for i, arg := range e.Args {
+			a.resolveExpression(arg, symbols.READ, env)
+			if i < len(params) && params[i].DataLocation == ast.Storage {
+				a.resolveStoragePointer(arg, env)
+			}
+		}
+	case *ast.CallOptionsExpression:
+		a.resolveExpression(e.Expression, usage, env)
+		for _, value := range e.Values {
+			a.resolveExpression(value, symbols.READ, env)
+		}
+	case *ast.MemberAccessExpression:
+		a.resolveMemberAccess(e, usage, env)
+	case *ast.ElementaryTypeExpression:
+		a.resolveExpression(e.Value, symbols.READ, env)
+	}
+}
+
+// resolveMemberAccess attaches the references to the accessed member and to
+// the symbols of the accessed expression. Writing to a member e.g. a field
+// of a struct, writes to the expression, so the usage is passed down.
+// Calling a member only reads the expression, unless the member modifies it
+// e.g. `data.push(b)`.
+func (a *Analyzer) resolveMemberAccess(
+	expr *ast.MemberAccessExpression, usage symbols.ReferenceUsageType, env *symbols.Environment) {
+	if usage == symbols.WRITE {
+		a.resolveExpression(expr.Expression, symbols.WRITE, env)
+	} else {
+		a.resolveExpression(expr.Expression, symbols.READ, env)
+	}
+	if usage == symbols.CALL && a.isModifyingCall(expr) {
+		a.resolveStoragePointer(expr.Expression, env)
+	}
+
+	if expr.Member == nil || a.typesInfo == nil {
+		return
+	}
+
+	// Only the members of the contracts have symbols e.g. `this.f()`,
+	// `super.f()`, `Base.f()` and `token.transfer()`.
+	var contract *types.Contract
+	switch t := a.typesInfo.Types[expr.Expression].(type) {
+	case *types.Contract:
+		contract = t
+	case *types.Super:
+		contract = t.Contract
+	case *types.TypeType:
+		contract, _ = t.Type.(*types.Contract)
+	}
+	if contract == nil {
+		return
+	}
+
+	for _, symbol := range env.GetAll(contract.Name) {
+		if contractSymbol, ok := symbol.(*symbols.Contract); ok && contractSymbol.GetInnerEnv() != nil {
+			a.addReference(expr.Member, usage, contractSymbol.GetInnerEnv().GetAll(expr.Member.Value), env)
+			return
+		}
+	}
+}
+
+// isModifyingCall reports whether calling the member modifies the accessed
+// expression i.e. it is `push` or `pop` of an array, bytes or string.
+func (a *Analyzer) isModifyingCall(expr *ast.MemberAccessExpression) bool {
+	if expr.Member == nil || a.typesInfo == nil ||
+		(expr.Member.Value != "push" && expr.Member.Value != "pop") {
+		return false
+	}
+
+	switch t := a.typesInfo.Types[expr.Expression].(type) {
+	case *types.Array:
+		return true
+	case *types.Basic:
+		return t.Kind() == types.Bytes || t.Kind() == types.String
+	}
+	return false
+}
+
+// calledParams returns the params of the called function declaration, or
+// nil if the declaration is not known e.g. for the builtins.
+func (a *Analyzer) calledParams(callee ast.Expression) []*ast.Param {
+	if a.typesInfo == nil {
+		return nil
+	}
+
+	var ident *ast.Identifier
+	switch c := callee.(type) {
+	case *ast.Identifier:
+		ident = c
+	case *ast.MemberAccessExpression:
+		ident = c.Member
+	case *ast.CallOptionsExpression:
+		return a.calledParams(c.Expression)
+	}
+	if ident == nil {
+		return nil
+	}
+
+	fn, ok := a.typesInfo.Uses[ident].(*ast.FunctionDeclaration)
+	if !ok || fn.Params == nil {
+		return nil
+	}
+	return fn.Params.List
+}
+
+// resolveStoragePointer attaches the write reference to the variable bound
+// to a storage pointer e.g. `data` in `bytes storage p = data;`. The read
+// references are attached by resolving the expression as usual. The
+// variable can be modified through the pointer, so it is also written.
+func (a *Analyzer) resolveStoragePointer(expr ast.Expression, env *symbols.Environment) {
+	switch e := expr.(type) {
+	case *ast.Identifier:
+		a.resolveIdentifier(e, symbols.WRITE, env)
+	case *ast.MemberAccessExpression:
+		// e.g. `position.data`, a field of a struct.
+		a.resolveStoragePointer(e.Expression, env)
 	}
 }
 
+// resolveReturnedStoragePointers attaches the write references to the
+// variables returned as storage pointers, since they can be modified by the
+// callers.
+func (a *Analyzer) resolveReturnedStoragePointers(result ast.Expression, env *symbols.Environment) {
+	if result == nil || a.results == nil {
+		return
+	}
+
+	values := []ast.Expression{result}
+	if tuple, ok := result.(*ast.TupleExpression); ok && len(a.results.List) > 1 {
+		values = tuple.Components
+	}
+	for i, value := range values {
+		if i < len(a.results.List) && a.results.List[i].DataLocation == ast.Storage {
+			a.resolveStoragePointer(value, env)
+		}
+	}
+}
+
+// isStoragePointer reports whether the expression is a local variable or a
+// param declared as a storage pointer.
+func (a *Analyzer) isStoragePointer(expr ast.Expression, env *symbols.Environment) bool {
+	ident, ok := expr.(*ast.Identifier)
+	if !ok {
+		return false
+	}
+
+	switch s := a.referencedSymbol(ident, env.GetAll(ident.Value)).(type) {
+	case *symbols.LocalVariable:
+		return s.DataLocation == ast.Storage
+	case *symbols.Param:
+		return s.DataLocation == ast.Storage
+	}
+	return false
+}
+
+// resolveIdentifier attaches the reference to the symbol the identifier
+// refers to. The identifiers without symbols e.g. `msg` or `require`, are
+// skipped; the undeclared ones are reported by the type checker.
+func (a *Analyzer) resolveIdentifier(ident *ast.Identifier, usage symbols.ReferenceUsageType, env *symbols.Environment) {
+	if ident == nil {
+		return
+	}
+	a.addReference(ident, usage, env.GetAll(ident.Value), env)
+}
+
+// addReference adds the reference to the symbol the identifier refers to
+// among the candidates. The type checker knows the declaration the
+// identifier refers to, taking the overloads and the order of the
+// declarations into account, so its symbol is chosen.
+func (a *Analyzer) addReference(ident *ast.Identifier, usage symbols.ReferenceUsageType,
+	candidates []symbols.Symbol, env *symbols.Environment) {
+	symbol := a.referencedSymbol(ident, candidates)
+	if symbol == nil {
+		return
+	}
+
+	symbol.AddReference(&symbols.Reference{
+		SourceFile: a.currentFile.SourceFile,
+		Offset:     ident.Pos,
+		Context: symbols.ReferenceContext{
+			ScopeName: env.GetCurrentScopeName(),
+			ScopeType: env.GetCurrentScopeType(),
+			Usage:     usage,
+		},
+		AstNode: ident,
+	})
+}
+
+// referencedSymbol returns the symbol the identifier refers to among the
+// candidates or nil if there is no such symbol.
+func (a *Analyzer) referencedSymbol(ident *ast.Identifier, candidates []symbols.Symbol) symbols.Symbol {
+	// The imported symbols are resolved separately, see
+	// resolveImportedSymbols.
+	var filtered []symbols.Symbol
+	for _, candidate := range candidates {
+		if _, ok := candidate.(*symbols.ImportedSymbol); !ok {
+			filtered = append(filtered, candidate)
+		}
+	}
+	candidates = filtered
+
+	if a.typesInfo != nil {
+		if decl, ok := a.typesInfo.Uses[ident]; ok {
+			for _, candidate := range candidates {
+				if decl != nil && candidate.GetAstNode() == decl {
+					return candidate
+				}
+			}
+			return nil
+		}
+	}
+
+	// The type checker doesn't know the declaration, so the identifier can
+	// only be resolved by its name. Dropping the reference would make the
+	// symbol look unused.
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+
+	// The overloads can't be told apart by their name.
+	return nil
+}
+
 func (a *Analyzer) resolveEmitStatement(stmt *ast.EmitStatement, env *symbols.Environment) {
 	call, ok := stmt.Expression.(*ast.CallExpression)
 	if !ok {
 		a.analysisErrors.Add(a.GetNodeLocation(stmt, stmt.Pos),
 			"Reference resolution error: expected call expression in an emit statement.")
+		return
+	}
+
+	for _, arg := range call.Args {
+		a.resolveExpression(arg, symbols.READ, env)
 	}
 
 	ident, ok := call.Ident.(*ast.Identifier)
 	if !ok {
 		a.analysisErrors.Add(a.GetNodeLocation(call, call.Pos),
 			"Reference resolution error: emit statement must refer to an event identifier.")
+		return
 	}
 
 	matchingSymbols, found := env.Get(ident.Value)
@@ -315,16 +1287,12 @@ func (a *Analyzer) resolveEmitStatement(stmt *ast.EmitStatement, env *symbols.En
 		a.analysisErrors.Add(a.GetNodeLocation(ident, ident.Start()),
 			"Reference resolution error: No symbol found for event '"+
 				ident.Value+"'.")
+		return
 	}
 
-	// TODO: Validate arguments match parameters.
-	// TODO: Handle the situations when many symbols match
-
-	eventSymbol, ok := matchingSymbols[0].(*symbols.Event)
-	if !ok {
-		a.analysisErrors.Add(a.GetNodeLocation(ident, ident.Start()),
-			"Reference resolution error: symbols found with name '"+
-				ident.Value+"' does not match the Event type.")
+	eventSymbol := a.resolveEvent(ident, matchingSymbols)
+	if eventSymbol == nil {
+		return
 	}
 
 	ref := &symbols.Reference{
@@ -341,6 +1309,44 @@ func (a *Analyzer) resolveEmitStatement(stmt *ast.EmitStatement, env *symbols.En
 	eventSymbol.References = append(eventSymbol.References, ref)
 }
 
+// resolveEvent returns the event the identifier in the emit statement refers
+// to. Events can be overloaded, so the overload is the one the type checker
+// selected based on the emitted arguments. The type checker also validates
+// the arguments against the params of the event and reports the emits
+// matching no overload or more than one. It returns nil if the event can't
+// be resolved.
+func (a *Analyzer) resolveEvent(ident *ast.Identifier, matchingSymbols []symbols.Symbol) *symbols.Event {
+	var events []*symbols.Event
+	for _, symbol := range matchingSymbols {
+		if event, ok := symbol.(*symbols.Event); ok {
+			events = append(events, event)
+		}
+	}
+
+	if len(events) == 0 {
+		a.analysisErrors.Add(a.GetNodeLocation(ident, ident.Start()),
+			"Reference resolution error: symbols found with name '"+
+				ident.Value+"' does not match the Event type.")
+		return nil
+	}
+
+	if len(events) == 1 {
+		return events[0]
+	}
+
+	if a.typesInfo != nil {
+		decl := a.typesInfo.Uses[ident]
+		for _, event := range events {
+			if event.AstNode == decl {
+				return event
+			}
+		}
+	}
+
+	// The type checker has already reported why the overload is unknown.
+	return nil
+}
+
 ////////////////////////////////////////////////////////////////////
 //                            PHASE 3			                  //
 ////////////////////////////////////////////////////////////////////
@@ -348,14 +1354,14 @@ func (a *Analyzer) resolveEmitStatement(stmt *ast.EmitStatement, env *symbols.En
 func (a *Analyzer) detectIssues(node ast.Node, env *symbols.Environment) []reporter.Finding {
 	var findings []reporter.Finding
 
-	// detectors := *GetAllDetectors()
-	//
-	// for _, detector := range detectors {
-	// 	finding := detector.Detect(file)
-	// 	if finding != nil {
-	// 		findings = append(findings, *finding)
-	// 	}
-	// }
+	detectors := *a.detectors()
+
+	for _, detector := range detectors {
+		finding := detector.Detect(node, env)
+		if finding != nil {
+			findings = append(findings, *finding)
+		}
+	}
 
 	return findings
 }
@@ -364,12 +1370,24 @@ func (a *Analyzer) detectIssues(node ast.Node, env *symbols.Environment) []repor
 //                            Helpers			                  //
 ////////////////////////////////////////////////////////////////////
 
+// typeOf returns the type of the type name or nil if the file was not type
+// checked.
+func (a *Analyzer) typeOf(typ ast.Type) types.Type {
+	if a.typesInfo == nil || typ == nil {
+		return nil
+	}
+	return a.typesInfo.TypeOf(typ)
+}
+
 func (a *Analyzer) GetNodeLocation(node ast.Node, pos token.Pos) string {
 	if node == nil {
 		return "Unknown location: nil node"
 	}
 
 	sourceFile := a.currentFile.SourceFile
+	if a.fset != nil {
+		sourceFile = a.fset.File(pos)
+	}
 	if sourceFile == nil {
 		return fmt.Sprintf("Unknown location for node at position %d", pos)
 	}
@@ -379,6 +1397,22 @@ func (a *Analyzer) GetNodeLocation(node ast.Node, pos token.Pos) string {
 	return fmt.Sprintf("%s:%d:%d", sourceFile.RelativePathFromProjectRoot(), line, column)
 }
 
+// GetBlockEnv returns the env of the nested block e.g. the body of an if
+// statement. It returns the outer env if the block has no env of its own,
+// which is the case for the function bodies.
+func (a *Analyzer) GetBlockEnv(block ast.Node, outer *symbols.Environment) *symbols.Environment {
+	if env, ok := a.blockEnvs[block]; ok {
+		return env
+	}
+	return outer
+}
+
+// Warnings returns the diagnostics that don't stop the analysis e.g. the
+// local variables shadowing the state variables.
+func (a *Analyzer) Warnings() ErrorList {
+	return a.warnings
+}
+
 // Errors returns the combined list of errors encountered during the analysis.
 // It includes errors from all phases.
 func (a *Analyzer) Errors() ErrorList {
@@ -1,8 +1,16 @@
 package analyzer
 
 import (
+	"github.com/ChmielewskiKamil/solbot/analyzer/unuseddecl"
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/parser"
+	"github.com/ChmielewskiKamil/solbot/reporter"
 	"github.com/ChmielewskiKamil/solbot/symbols"
+	"github.com/ChmielewskiKamil/solbot/types"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -202,6 +210,136 @@ func Test_ResolveReferences(t *testing.T) {
 	}
 }
 
+func Test_Init_WithCheckPublicUnderscore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Vault.sol")
+	src := `contract Vault {
+    uint256 public _total;
+
+    function f() public returns (uint256) {
+        return _total;
+    }
+}
+`
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+
+	const title = "Private and internal state variables should be prefixed with an underscore"
+
+	for _, check := range []bool{true, false} {
+		analyzer := Analyzer{}
+		if err := analyzer.Init(path, WithCheckPublicUnderscore(check)); err != nil {
+			t.Fatalf("Init failed: %v", err)
+		}
+		analyzer.AnalyzeCurrentFile()
+		checkAnalyzerErrors(t, &analyzer)
+
+		reported := false
+		for _, finding := range analyzer.GetFindings() {
+			reported = reported || finding.Title == title
+		}
+		if reported != check {
+			t.Errorf("Expected the public _total to be reported: %v, got: %v", check, reported)
+		}
+	}
+}
+
+func Test_Init_WithProjectRoot_ReportsRelativePaths(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "src", "Vault.sol")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+	}
+	src := `contract Vault {
+    struct Deposit { uint256 amount; }
+}
+`
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+
+	analyzer := Analyzer{}
+	if err := analyzer.Init(path, WithProjectRoot(root)); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	analyzer.AnalyzeCurrentFile()
+	checkAnalyzerErrors(t, &analyzer)
+
+	findings := analyzer.GetFindings()
+	if len(findings) == 0 {
+		t.Fatalf("Expected the unused struct to be reported, got no findings")
+	}
+
+	// The report template is read relative to the root of the repository.
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get the working directory: %v", err)
+	}
+	if err := os.Chdir(".."); err != nil {
+		t.Fatalf("Failed to change the working directory: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(cwd) })
+
+	reportPath := filepath.Join(t.TempDir(), "solbot.md")
+	if err := reporter.GenerateReport(findings, reportPath); err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+	report, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("Failed to read the report: %v", err)
+	}
+
+	if !strings.Contains(string(report), "[src/Vault.sol](link)") {
+		t.Errorf("Expected the report to contain the path relative to the root, got:\n%s", report)
+	}
+	if strings.Contains(string(report), root) {
+		t.Errorf("Expected the report not to contain the absolute path %s, got:\n%s", root, report)
+	}
+}
+
+func Test_Init_WithUnusedDeclarationKinds(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Vault.sol")
+	src := `contract Vault {
+    struct Deposit { uint256 amount; }
+    error Unauthorized();
+}
+`
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected []string
+	}{
+		{"default", nil, []string{"Unused structs should be removed", "Unused custom errors should be removed"}},
+		{"errors", []Option{WithUnusedDeclarationKinds([]unuseddecl.Kind{unuseddecl.Error})}, []string{"Unused custom errors should be removed"}},
+		{"none", []Option{WithUnusedDeclarationKinds([]unuseddecl.Kind{})}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analyzer := Analyzer{}
+			if err := analyzer.Init(path, tt.opts...); err != nil {
+				t.Fatalf("Init failed: %v", err)
+			}
+			analyzer.AnalyzeCurrentFile()
+			checkAnalyzerErrors(t, &analyzer)
+
+			var titles []string
+			for _, finding := range analyzer.GetFindings() {
+				if strings.HasPrefix(finding.Title, "Unused structs") || strings.HasPrefix(finding.Title, "Unused custom errors") {
+					titles = append(titles, finding.Title)
+				}
+			}
+			if !reflect.DeepEqual(titles, tt.expected) {
+				t.Errorf("Expected the findings %q, got %q", tt.expected, titles)
+			}
+		})
+	}
+}
+
 func checkAnalyzerErrors(t *testing.T, a *Analyzer) {
 	errors := a.Errors()
 	if len(errors) == 0 {
@@ -214,3 +352,483 @@ func checkAnalyzerErrors(t *testing.T, a *Analyzer) {
 	}
 	t.FailNow()
 }
+
+func Test_ResolveReferences_OverloadedEvents(t *testing.T) {
+	testContractPath := "testdata/foundry/src/004_OverloadedEvents.sol"
+	analyzer := Analyzer{}
+	analyzer.Init(testContractPath)
+
+	analyzer.AnalyzeCurrentFile()
+
+	checkAnalyzerErrors(t, &analyzer)
+
+	contracts := symbols.GetAllSymbolsByType[*symbols.Contract](analyzer.GetCurrentFileEnv())
+	if len(contracts) != 1 {
+		t.Fatalf("Expected 1 contract, but found %d.", len(contracts))
+	}
+
+	contractEnv, err := symbols.GetInnerEnv(contracts[0])
+	if err != nil {
+		t.Fatalf("Error getting inner env of symbol: %s", err)
+	}
+
+	events := symbols.GetAllSymbolsByType[*symbols.Event](contractEnv)
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got: %d", len(events))
+	}
+
+	// Each overload is emitted once, each from a different function.
+	expectedScopeNames := []string{"register", "deposit", "depositFor"}
+
+	for idx, event := range events {
+		if len(event.References) != 1 {
+			t.Fatalf("Event '%s' overload %d: expected 1 reference, got %d.",
+				event.Name, idx, len(event.References))
+		}
+
+		gotScopeName := event.References[0].Context.ScopeName
+		if gotScopeName != expectedScopeNames[idx] {
+			t.Errorf("Event '%s' overload %d got incorrect reference scope NAME. Got: %s, expected: %s.",
+				event.Name, idx, gotScopeName, expectedScopeNames[idx])
+		}
+	}
+}
+
+func Test_AnalyzeFile_OverloadErrors(t *testing.T) {
+	src := `contract C {
+    event E(uint8 a);
+    event E(uint16 a);
+    event E(address a);
+
+    function ambiguous() public {
+        emit E(1);
+    }
+
+    function unmatched() public {
+        emit E(true);
+    }
+}`
+
+	analyzer := Analyzer{analysisErrors: ErrorList{}}
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parser errors: %s", err)
+	}
+	analyzer.currentFile = file
+	analyzer.AnalyzeFile(file)
+
+	expected := []string{
+		"Type error: ambiguous call to overloaded E with the arguments (int_const 1)",
+		"Type error: no overload of E matches the arguments (bool)",
+	}
+
+	errors := analyzer.Errors()
+	if len(errors) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d: %v", len(expected), len(errors), errors)
+	}
+	for i, msg := range expected {
+		if errors[i].Msg != msg {
+			t.Errorf("Error %d: expected %q, got %q", i, msg, errors[i].Msg)
+		}
+	}
+}
+
+func Test_LinearizeContracts(t *testing.T) {
+	testContractPath := "testdata/foundry/src/005_Inheritance.sol"
+	analyzer := Analyzer{}
+	analyzer.Init(testContractPath)
+
+	analyzer.AnalyzeCurrentFile()
+
+	checkAnalyzerErrors(t, &analyzer)
+
+	fileEnv := analyzer.GetCurrentFileEnv()
+	contracts := symbols.GetAllSymbolsByType[*symbols.Contract](fileEnv)
+	if len(contracts) != 3 {
+		t.Fatalf("Expected 3 contracts, but found %d.", len(contracts))
+	}
+
+	vault := contracts[2]
+	var linearized []string
+	for _, base := range vault.LinearizedBases {
+		linearized = append(linearized, base.Name)
+	}
+	if strings.Join(linearized, " ") != "Vault Pausable Ownable" {
+		t.Fatalf("Expected the linearization Vault Pausable Ownable, got %v", linearized)
+	}
+
+	vaultEnv, err := symbols.GetInnerEnv(vault)
+	if err != nil {
+		t.Fatalf("Error getting inner env of symbol: %s", err)
+	}
+
+	// The inherited symbols are resolved through the bases.
+	for _, name := range []string{"owner", "paused", "Paused", "OwnershipTransferred"} {
+		if _, found := vaultEnv.Get(name); !found {
+			t.Errorf("Inherited symbol '%s' not found in the env of Vault.", name)
+		}
+		if _, found := vaultEnv.GetSuper(name); !found {
+			t.Errorf("Inherited symbol '%s' not found through super in the env of Vault.", name)
+		}
+	}
+
+	// The emits in Vault are references to the events of the bases.
+	for _, contract := range contracts[:2] {
+		contractEnv, err := symbols.GetInnerEnv(contract)
+		if err != nil {
+			t.Fatalf("Error getting inner env of symbol: %s", err)
+		}
+		for _, event := range symbols.GetAllSymbolsByType[*symbols.Event](contractEnv) {
+			if len(event.References) != 1 {
+				t.Errorf("Event '%s': expected 1 reference, got %d.", event.Name, len(event.References))
+			}
+		}
+	}
+}
+
+func Test_DiscoverSymbols_LocalVariables(t *testing.T) {
+	testContractPath := "testdata/foundry/src/006_LocalVariables.sol"
+	analyzer := Analyzer{}
+	analyzer.Init(testContractPath)
+
+	analyzer.AnalyzeCurrentFile()
+
+	checkAnalyzerErrors(t, &analyzer)
+
+	contracts := symbols.GetAllSymbolsByType[*symbols.Contract](analyzer.GetCurrentFileEnv())
+	if len(contracts) != 1 {
+		t.Fatalf("Expected 1 contract, but found %d.", len(contracts))
+	}
+	contractEnv, err := symbols.GetInnerEnv(contracts[0])
+	if err != nil {
+		t.Fatalf("Error getting inner env of symbol: %s", err)
+	}
+
+	functions := symbols.GetAllSymbolsByType[*symbols.Function](contractEnv)
+	if len(functions) != 2 {
+		t.Fatalf("Expected 2 functions, but found %d.", len(functions))
+	}
+	fnEnv, err := symbols.GetInnerEnv(functions[0])
+	if err != nil {
+		t.Fatalf("Error getting inner env of symbol: %s", err)
+	}
+
+	// The params and the top-level locals are in the function env.
+	var fnLocals []string
+	for _, param := range symbols.GetAllSymbolsByType[*symbols.Param](fnEnv) {
+		fnLocals = append(fnLocals, param.Name)
+	}
+	for _, local := range symbols.GetAllSymbolsByType[*symbols.LocalVariable](fnEnv) {
+		fnLocals = append(fnLocals, local.Name)
+	}
+	if strings.Join(fnLocals, " ") != "amount before first last" {
+		t.Errorf("Unexpected symbols in the function env: %v", fnLocals)
+	}
+
+	// The locals of the nested blocks are in the block envs.
+	body := functions[0].Body
+	ifStmt := body.Statements[1].(*ast.IfStatement)
+	consequenceEnv := analyzer.GetBlockEnv(ifStmt.Consequence, nil)
+	if consequenceEnv == nil || consequenceEnv.Outer() != fnEnv {
+		t.Fatalf("Expected the env of the consequence to be enclosed by the function env.")
+	}
+	for _, name := range []string{"total", "done"} {
+		locals, found := consequenceEnv.Get(name)
+		if !found {
+			t.Fatalf("Local '%s' not found in the env of the consequence.", name)
+		}
+		if _, ok := locals[0].(*symbols.LocalVariable); !ok {
+			t.Errorf("Expected '%s' to resolve to a local variable, got %T.", name, locals[0])
+		}
+	}
+	if _, found := fnEnv.Get("done"); found {
+		t.Errorf("The local declared in the nested block is visible in the function env.")
+	}
+
+	alternative := ifStmt.Alternative.(*ast.BlockStatement)
+	uncheckedEnv := analyzer.GetBlockEnv(alternative.Statements[0], nil)
+	if uncheckedEnv == nil || uncheckedEnv.Outer() != analyzer.GetBlockEnv(alternative, nil) {
+		t.Fatalf("Expected the env of the unchecked block to be enclosed by the env of the alternative.")
+	}
+	if _, found := uncheckedEnv.Get("diff"); !found {
+		t.Errorf("Local 'diff' not found in the env of the unchecked block.")
+	}
+
+	// The local `total` shadows the state variable.
+	warnings := analyzer.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %d: %v", len(warnings), warnings)
+	}
+	expected := "Shadowing warning: declaration of 'total' shadows an existing declaration at " +
+		"testdata/foundry/src/006_LocalVariables.sol:5:13."
+	if warnings[0].Msg != expected {
+		t.Errorf("Unexpected warning. Expected: %s, got: %s", expected, warnings[0].Msg)
+	}
+}
+
+func Test_ResolveReferences_Usage(t *testing.T) {
+	testContractPath := "testdata/foundry/src/007_ReferenceUsage.sol"
+	analyzer := Analyzer{}
+	analyzer.Init(testContractPath)
+
+	analyzer.AnalyzeCurrentFile()
+
+	checkAnalyzerErrors(t, &analyzer)
+
+	contracts := symbols.GetAllSymbolsByType[*symbols.Contract](analyzer.GetCurrentFileEnv())
+	if len(contracts) != 2 {
+		t.Fatalf("Expected 2 contracts, but found %d.", len(contracts))
+	}
+	usageEnv, err := symbols.GetInnerEnv(contracts[1])
+	if err != nil {
+		t.Fatalf("Error getting inner env of symbol: %s", err)
+	}
+
+	tests := []struct {
+		name     string
+		expected []string
+	}{
+		{"owner", []string{"READ onlyOwner"}},
+		{"total", []string{"WRITE deposit", "READ deposit", "WRITE deposit"}},
+		{"count", []string{"WRITE deposit"}},
+		{"paused", []string{"WRITE deposit", "WRITE deposit", "READ values"}},
+		{"fees", []string{"WRITE collect"}},
+		{"collect", []string{"CALL deposit", "CALL deposit"}},
+		{"values", []string{"CALL deposit"}},
+		{"onlyOwner", []string{"CALL deposit"}},
+	}
+
+	for _, tt := range tests {
+		matchingSymbols, found := usageEnv.Get(tt.name)
+		if !found {
+			t.Fatalf("Symbol '%s' not found.", tt.name)
+		}
+		got := test_helper_referenceUsages(matchingSymbols[0])
+		if strings.Join(got, ", ") != strings.Join(tt.expected, ", ") {
+			t.Errorf("Wrong references of '%s'. Expected: %v, got: %v", tt.name, tt.expected, got)
+		}
+	}
+
+	// The param is referred to in the function, not in the modifier.
+	deposit := symbols.GetAllSymbolsByType[*symbols.Function](usageEnv)[0]
+	got := test_helper_referenceUsages(deposit.Parameters[0])
+	expected := []string{"READ deposit", "READ deposit", "READ deposit"}
+	if strings.Join(got, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("Wrong references of 'amount'. Expected: %v, got: %v", expected, got)
+	}
+
+	modifier := symbols.GetAllSymbolsByType[*symbols.Modifier](usageEnv)[0]
+	if scopeType := modifier.GetInnerEnv().GetCurrentScopeType(); scopeType != symbols.MODIFIER {
+		t.Errorf("Expected the MODIFIER scope of the modifier env, got %s", scopeType)
+	}
+}
+
+func Test_ReferencedSymbol_WithoutTypesInfo(t *testing.T) {
+	// The type checker recorded no declaration for the identifiers.
+	analyzer := Analyzer{typesInfo: types.NewInfo()}
+
+	ident := &ast.Identifier{Value: "total"}
+	total := &symbols.StateVariable{BaseSymbol: symbols.BaseSymbol{Name: "total"}}
+	if got := analyzer.referencedSymbol(ident, []symbols.Symbol{total}); got != total {
+		t.Errorf("Expected the only candidate to be referenced, got: %v", got)
+	}
+
+	ident = &ast.Identifier{Value: "collect"}
+	candidates := []symbols.Symbol{
+		&symbols.Function{BaseSymbol: symbols.BaseSymbol{Name: "collect"}},
+		&symbols.Function{BaseSymbol: symbols.BaseSymbol{Name: "collect"}},
+	}
+	if got := analyzer.referencedSymbol(ident, candidates); got != nil {
+		t.Errorf("Expected no symbol for the overloads, got: %v", got)
+	}
+}
+
+func Test_ResolveReferences_UserDefinedTypes(t *testing.T) {
+	src := `struct Position { uint256 amount; Status status; }
+enum Status { Open, Closed }
+
+contract C {
+    error Unauthorized(address caller);
+
+    Position position;
+
+    function close(Position memory p) public returns (Status) {
+        if (msg.sender != address(0)) revert Unauthorized(msg.sender);
+        Position storage current = position;
+        current.status = Status.Closed;
+        return p.status;
+    }
+}`
+
+	analyzer := Analyzer{}
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parser errors: %s", err)
+	}
+	analyzer.AnalyzeFile(file)
+
+	checkAnalyzerErrors(t, &analyzer)
+
+	fileEnv := analyzer.GetCurrentFileEnv()
+	tests := []struct {
+		name     string
+		expected []string
+	}{
+		{"Position", []string{"READ C", "READ close", "READ close"}},
+		{"Status", []string{"READ test.sol", "READ close", "READ close"}},
+		{"Unauthorized", []string{"CALL close"}},
+	}
+
+	for _, tt := range tests {
+		matchingSymbols := fileEnv.GetAll(tt.name)
+		if len(matchingSymbols) == 0 {
+			matchingSymbols = symbols.GetAllSymbolsByType[*symbols.Contract](fileEnv)[0].GetInnerEnv().GetAll(tt.name)
+		}
+		if len(matchingSymbols) == 0 {
+			t.Fatalf("Symbol '%s' not found.", tt.name)
+		}
+		got := test_helper_referenceUsages(matchingSymbols[0])
+		if strings.Join(got, ", ") != strings.Join(tt.expected, ", ") {
+			t.Errorf("Wrong references of '%s'. Expected: %v, got: %v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func Test_ResolveReferences_SpecialFunctions(t *testing.T) {
+	src := `contract C {
+    uint256 total;
+    bytes last;
+
+    modifier onlyPositive() {
+        require(msg.value > 0);
+        _;
+    }
+
+    receive() external payable onlyPositive {
+        total += msg.value;
+    }
+
+    fallback(bytes calldata input) external returns (bytes memory) {
+        last = input;
+        return input;
+    }
+}`
+
+	analyzer := Analyzer{}
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parser errors: %s", err)
+	}
+	analyzer.AnalyzeFile(file)
+
+	checkAnalyzerErrors(t, &analyzer)
+
+	contractEnv := symbols.GetAllSymbolsByType[*symbols.Contract](analyzer.GetCurrentFileEnv())[0].GetInnerEnv()
+	tests := []struct {
+		name     string
+		expected []string
+	}{
+		{"total", []string{"WRITE receive"}},
+		{"last", []string{"WRITE fallback"}},
+		{"onlyPositive", []string{"CALL receive"}},
+	}
+
+	for _, tt := range tests {
+		matchingSymbols := contractEnv.GetAll(tt.name)
+		if len(matchingSymbols) == 0 {
+			t.Fatalf("Symbol '%s' not found.", tt.name)
+		}
+		got := test_helper_referenceUsages(matchingSymbols[0])
+		if strings.Join(got, ", ") != strings.Join(tt.expected, ", ") {
+			t.Errorf("Wrong references of '%s'. Expected: %v, got: %v", tt.name, tt.expected, got)
+		}
+	}
+
+	fallback := contractEnv.GetAll("fallback")
+	if len(fallback) != 1 {
+		t.Fatalf("Expected the fallback function symbol, got: %v", fallback)
+	}
+	got := test_helper_referenceUsages(fallback[0].(*symbols.Function).Parameters[0])
+	expected := []string{"READ fallback", "READ fallback"}
+	if strings.Join(got, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("Wrong references of 'input'. Expected: %v, got: %v", expected, got)
+	}
+}
+
+// test_helper_referenceUsages returns the usage and the scope name of each
+// reference of the symbol e.g. "READ deposit".
+func test_helper_referenceUsages(symbol symbols.Symbol) []string {
+	var references []*symbols.Reference
+	switch s := symbol.(type) {
+	case *symbols.StateVariable:
+		references = s.References
+	case *symbols.Function:
+		references = s.References
+	case *symbols.Modifier:
+		references = s.References
+	case *symbols.Param:
+		references = s.References
+	case *symbols.Struct:
+		references = s.References
+	case *symbols.Enum:
+		references = s.References
+	case *symbols.CustomError:
+		references = s.References
+	}
+
+	var usages []string
+	for _, ref := range references {
+		usages = append(usages, ref.Context.Usage.String()+" "+ref.Context.ScopeName)
+	}
+	return usages
+}
+
+func Test_DumpSymbols(t *testing.T) {
+	testContractPath := "testdata/foundry/src/006_LocalVariables.sol"
+	analyzer := Analyzer{}
+	analyzer.Init(testContractPath)
+
+	analyzer.AnalyzeCurrentFile()
+
+	checkAnalyzerErrors(t, &analyzer)
+
+	dump := symbols.Dump(analyzer.GetCurrentFileEnv())
+	if dump.Kind != "FILE" || len(dump.Symbols) != 1 {
+		t.Fatalf("Expected the FILE scope with 1 contract, got %s with %d symbols.", dump.Kind, len(dump.Symbols))
+	}
+
+	contract := dump.Symbols[0]
+	if contract.Kind != "contract" || contract.Scope == nil || contract.Scope.Kind != "CONTRACT" {
+		t.Fatalf("Expected the contract with the CONTRACT scope, got %+v", contract)
+	}
+
+	add := contract.Scope.Symbols[1]
+	if add.Kind != "function" || add.Visibility != "public" || add.Scope == nil {
+		t.Fatalf("Expected the public function add with a scope, got %+v", add)
+	}
+
+	// The sibling blocks of the if statement are both kept.
+	if len(add.Scope.Blocks) != 2 {
+		t.Fatalf("Expected 2 blocks in the function scope, got %d.", len(add.Scope.Blocks))
+	}
+
+	var out strings.Builder
+	if err := symbols.Fprint(&out, dump); err != nil {
+		t.Fatalf("Could not print the symbols: %s", err)
+	}
+
+	expectedLines := []string{
+		"    state variable total testdata/foundry/src/006_LocalVariables.sol:5:13 internal\n" +
+			"      ref READ FUNCTION add testdata/foundry/src/006_LocalVariables.sol:8:26\n",
+		"      block\n" +
+			"        local variable total testdata/foundry/src/006_LocalVariables.sol:10:21\n",
+		"      block\n" +
+			"        block\n" +
+			"          local variable diff testdata/foundry/src/006_LocalVariables.sol:14:25\n",
+	}
+	for _, expected := range expectedLines {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("Expected the dump to contain:\n%s\ngot:\n%s", expected, out.String())
+		}
+	}
+}
@@ -0,0 +1,32 @@
+// analyzertest provides the helpers shared by the tests of the detectors.
+package analyzertest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/analyzer"
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/parser"
+	"github.com/ChmielewskiKamil/solbot/symbols"
+)
+
+// Analyze parses and analyzes the source code and returns the file and its
+// env, so the detectors can be run on them. The test fails on any parser or
+// analyzer error.
+func Analyze(t *testing.T, src string) (*ast.File, *symbols.Environment) {
+	t.Helper()
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	return file, a.GetCurrentFileEnv()
+}
@@ -0,0 +1,85 @@
+// constantvars detects state variables that are initialized with a compile
+// time constant and are never written afterwards, so they can be declared
+// as constant. The writes in the inline assembly e.g. `sstore(x.slot, 1)`,
+// and in the contracts inheriting the variable are taken into account. Only
+// the variables of the value types, strings and bytes are checked, since the
+// others can't be constant. The strings and bytes can also be modified with
+// `push` and `pop`, and through the storage pointers e.g.
+// `bytes storage p = data;`; these are writes too.
+package constantvars
+
+import (
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/symbols"
+	"github.com/ChmielewskiKamil/solbot/token"
+	"github.com/ChmielewskiKamil/solbot/types"
+)
+
+const (
+	title          = "Variables that never change should be declared as `constant`"
+	severity       = "Best Practices"
+	descTempl      = "The following state variables are initialized with a constant value and are never changed: {{ range .Locations }}\n- `{{ .Context }}`{{ end }}"
+	recommendation = "Consider declaring the variables as `constant`. The constants are not stored in the storage, so reading them is much cheaper."
+)
+
+type Detector struct{}
+
+func (*Detector) Detect(node ast.Node, env *symbols.Environment) *reporter.Finding {
+	if _, ok := node.(*ast.File); !ok || env == nil {
+		return nil
+	}
+
+	finding := reporter.Finding{}
+
+	for _, stateVar := range symbols.GetAllSymbolsByTypeInFile[*symbols.StateVariable](env) {
+		if !IsCandidate(stateVar) {
+			continue
+		}
+
+		finding.Locations = append(finding.Locations, reporter.Location{
+			Position: token.Position{
+				Offset: stateVar.Offset,
+			},
+			Context: stateVar.Name,
+		})
+	}
+
+	if len(finding.Locations) == 0 {
+		return nil
+	}
+
+	finding.Title = title
+	finding.Severity = severity
+	finding.Description = reporter.GenerateCustomDescription(descTempl, finding.Locations)
+	finding.Recommendation = recommendation
+	return &finding
+}
+
+// IsCandidate reports whether the state variable can be declared as
+// constant. The immutablevars detector uses it to skip the variables that
+// are reported as constants.
+func IsCandidate(stateVar *symbols.StateVariable) bool {
+	if stateVar.Mutability != 0 || !canBeConstant(stateVar.Type) {
+		return false
+	}
+
+	decl, ok := stateVar.AstNode.(*ast.StateVariableDeclaration)
+	if !ok || decl.Value == nil || !symbols.IsCompileTimeConstant(decl.Value, stateVar.GetOuterEnv()) {
+		return false
+	}
+
+	for _, ref := range stateVar.References {
+		if ref.Context.Usage == symbols.WRITE {
+			return false
+		}
+	}
+	return true
+}
+
+func canBeConstant(t types.Type) bool {
+	if basic, ok := t.(*types.Basic); ok && (basic.Kind() == types.String || basic.Kind() == types.Bytes) {
+		return true
+	}
+	return symbols.IsValueType(t)
+}
@@ -0,0 +1,132 @@
+package constantvars_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/analyzer"
+	"github.com/ChmielewskiKamil/solbot/analyzer/constantvars"
+	"github.com/ChmielewskiKamil/solbot/parser"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+func Test_DetectConstantVariables(t *testing.T) {
+	src := `uint256 constant DECIMALS = 18;
+
+contract Base {
+    uint256 public writtenByChild = 1;
+}
+
+contract Vault is Base {
+    uint256 public fee = 100;
+    uint256 public scale = 10 ** DECIMALS;
+    address public zero = address(0);
+    bool public enabled = true;
+    string public name = "Vault";
+    address public owner = msg.sender;
+    uint256 public counter = 0;
+    uint256 public inAssembly = 0;
+    uint256 public uninitialized;
+    uint256 public constant MAX = 1;
+
+    function f() public {
+        counter++;
+        writtenByChild = 2;
+        assembly {
+            sstore(inAssembly.slot, 1)
+        }
+    }
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := constantvars.Detector{}
+
+	finding := d.Detect(file, a.GetCurrentFileEnv())
+	if finding == nil {
+		t.Fatalf("Expected a finding, got nil")
+	}
+
+	finding.CalculatePositions(file.SourceFile)
+
+	expectedLocations := []reporter.Location{
+		{Position: token.Position{Line: 8, Column: 20}, Context: "fee"},
+		{Position: token.Position{Line: 9, Column: 20}, Context: "scale"},
+		{Position: token.Position{Line: 10, Column: 20}, Context: "zero"},
+		{Position: token.Position{Line: 11, Column: 17}, Context: "enabled"},
+		{Position: token.Position{Line: 12, Column: 19}, Context: "name"},
+	}
+
+	if len(finding.Locations) != len(expectedLocations) {
+		t.Fatalf("Expected %d findings, got %d: %v",
+			len(expectedLocations), len(finding.Locations), finding.Locations)
+	}
+
+	for i, loc := range finding.Locations {
+		if loc.Position.Line != expectedLocations[i].Position.Line ||
+			loc.Position.Column != expectedLocations[i].Position.Column {
+			t.Errorf("Expected %d:%d, got %d:%d",
+				expectedLocations[i].Position.Line, expectedLocations[i].Position.Column,
+				loc.Position.Line, loc.Position.Column)
+		}
+
+		if loc.Context != expectedLocations[i].Context {
+			t.Errorf("Expected context %s, got %s", expectedLocations[i].Context, loc.Context)
+		}
+	}
+}
+
+func Test_DetectConstantVariables_ModifiedThroughMembersAndPointers(t *testing.T) {
+	src := `contract Vault {
+    bytes pushed = "abc";
+    bytes popped = "abc";
+    bytes aliased = "abc";
+    bytes passed = "abc";
+    bytes returned = "abc";
+
+    function f(bytes1 b) public {
+        pushed.push(b);
+        popped.pop();
+        bytes storage p = aliased;
+        p.push(b);
+        _append(passed, b);
+        _get().push(b);
+    }
+
+    function _append(bytes storage data, bytes1 b) internal {
+        data.push(b);
+    }
+
+    function _get() internal view returns (bytes storage) {
+        return returned;
+    }
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := constantvars.Detector{}
+	if finding := d.Detect(file, a.GetCurrentFileEnv()); finding != nil {
+		t.Errorf("Expected no finding, got: %v", finding.Locations)
+	}
+}
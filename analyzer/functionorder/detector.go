@@ -0,0 +1,199 @@
+// functionorder detects contracts whose declarations don't follow the order
+// of the Solidity style guide. The layout of a contract is: the type
+// declarations, the state variables, the events, the errors, the modifiers
+// and the functions. The using directives are grouped with the type
+// declarations, since they are usually placed at the top of the contract.
+//
+// The functions are grouped in the following order: the constructor, the
+// receive function, the fallback function and the external, public, internal
+// and private functions. Within a group, the view and pure functions go last.
+//
+// Only the first out-of-order declaration of each contract is reported,
+// together with the outline of the contract in the expected order, since
+// moving one declaration often fixes the ones that follow it. The
+// declarations of other kinds are not part of the style guide layout, so they
+// are neither checked nor listed in the outline.
+package functionorder
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/symbols"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+const (
+	title          = "Order of functions should follow the Solidity style guide"
+	severity       = "Best Practices"
+	descTempl      = "The declarations inside a contract should be ordered as follows: type declarations, state variables, events, errors, modifiers and functions. The functions should be ordered as follows: constructor, receive, fallback, external, public, internal and private, with the `view` and `pure` functions last within each group. The following declarations are out of order: {{ range .Locations }}\n- `{{ .Context }}`, consider the following order:\n\n```solidity\n{{ .Suggestion }}\n```\n{{ end }}"
+	recommendation = "Consider reordering the declarations to make the contracts easier to navigate."
+)
+
+type Detector struct{}
+
+func (*Detector) Detect(node ast.Node, _ *symbols.Environment) *reporter.Finding {
+	file, ok := node.(*ast.File)
+	if !ok {
+		return nil
+	}
+
+	finding := reporter.Finding{}
+
+	for _, decl := range file.Declarations {
+		contract, ok := decl.(*ast.ContractDeclaration)
+		if !ok || contract.Name == nil || contract.Body == nil {
+			continue
+		}
+		check(&finding, contract)
+	}
+
+	if len(finding.Locations) == 0 {
+		return nil
+	}
+
+	finding.Title = title
+	finding.Severity = severity
+	finding.Description = reporter.GenerateCustomDescription(descTempl, finding.Locations)
+	finding.Recommendation = recommendation
+	return &finding
+}
+
+// check adds the location of the first declaration of the contract that
+// should be placed before one of the declarations preceding it.
+func check(finding *reporter.Finding, contract *ast.ContractDeclaration) {
+	decls := contract.Body.Declarations
+
+	highest := -1
+	for _, decl := range decls {
+		r, ok := rank(decl)
+		if !ok {
+			continue
+		}
+		if r >= highest {
+			highest = r
+			continue
+		}
+
+		finding.Locations = append(finding.Locations, reporter.Location{
+			Position: token.Position{
+				Offset: decl.Start(),
+			},
+			Context:    header(decl),
+			Suggestion: outline(contract),
+		})
+		return
+	}
+}
+
+// rank returns the position of the declaration in the expected order; the
+// declarations with the lower ranks go first. The hundreds are the layout
+// groups, the tens are the function groups and the ones put the view and
+// pure functions last. The returned ok is false for the declarations that are
+// not part of the layout.
+func rank(decl ast.Declaration) (r int, ok bool) {
+	switch d := decl.(type) {
+	case *ast.UsingForDirective, *ast.StructDeclaration, *ast.EnumDeclaration:
+		return 0, true
+	case *ast.StateVariableDeclaration:
+		return 100, true
+	case *ast.EventDeclaration:
+		return 200, true
+	case *ast.ErrorDeclaration:
+		return 300, true
+	case *ast.ModifierDeclaration:
+		return 400, true
+	case *ast.ConstructorDeclaration:
+		return 500, true
+	case *ast.SpecialFunctionDeclaration:
+		if d.Kind.Type == token.RECEIVE {
+			return 510, true
+		}
+		return 520, true
+	case *ast.FunctionDeclaration:
+		r = 500
+		switch d.Visibility {
+		case ast.External:
+			r += 30
+		case ast.Public:
+			r += 40
+		case ast.Internal:
+			r += 50
+		case ast.Private:
+			r += 60
+		}
+		if d.Mutability == ast.View || d.Mutability == ast.Pure {
+			r++
+		}
+		return r, true
+	}
+	return 0, false
+}
+
+// outline returns the headers of the declarations of the contract in the
+// expected order. The declarations of the same rank keep their order.
+func outline(contract *ast.ContractDeclaration) string {
+	var ordered []ast.Declaration
+	ranks := make(map[ast.Declaration]int)
+	for _, decl := range contract.Body.Declarations {
+		if r, ok := rank(decl); ok {
+			ordered = append(ordered, decl)
+			ranks[decl] = r
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ranks[ordered[i]] < ranks[ordered[j]]
+	})
+
+	var out strings.Builder
+	if contract.Abstract {
+		out.WriteString("abstract ")
+	}
+	out.WriteString("contract " + contract.Name.Value + " {\n")
+	for _, decl := range ordered {
+		out.WriteString("    " + header(decl) + "\n")
+	}
+	out.WriteString("}")
+	return out.String()
+}
+
+// header returns the declaration without its body e.g.
+// `function withdraw(uint256 amount) external`.
+func header(decl ast.Declaration) string {
+	switch d := decl.(type) {
+	case *ast.UsingForDirective:
+		return strings.TrimSuffix(d.String(), ";")
+	case *ast.StructDeclaration:
+		return "struct " + d.Name.Value
+	case *ast.EnumDeclaration:
+		return "enum " + d.Name.Value
+	case *ast.StateVariableDeclaration:
+		return d.Type.String() + " " + d.Name.Value
+	case *ast.EventDeclaration:
+		return "event " + d.Name.Value
+	case *ast.ErrorDeclaration:
+		return "error " + d.Name.Value
+	case *ast.ModifierDeclaration:
+		return "modifier " + d.Name.Value
+	case *ast.ConstructorDeclaration:
+		return "constructor" + d.Params.String()
+	case *ast.SpecialFunctionDeclaration:
+		return d.Kind.Literal + d.Params.String() + attributes(d.Visibility, d.Mutability)
+	case *ast.FunctionDeclaration:
+		return "function " + d.Name.Value + d.Params.String() + attributes(d.Visibility, d.Mutability)
+	}
+	return decl.String()
+}
+
+func attributes(visibility ast.Visibility, mutability ast.Mutability) string {
+	var out string
+	if visibility != 0 {
+		out += " " + visibility.String()
+	}
+	if mutability != 0 {
+		out += " " + mutability.String()
+	}
+	return out
+}
@@ -0,0 +1,200 @@
+package functionorder_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/analyzer"
+	"github.com/ChmielewskiKamil/solbot/analyzer/functionorder"
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/parser"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+func Test_DetectFunctionOrder(t *testing.T) {
+	src := `contract Layout {
+    uint256 total;
+
+    function deposit() external payable {
+        total += msg.value;
+    }
+
+    event Deposited(uint256 amount);
+}
+
+contract Functions {
+    receive() external payable {}
+
+    function balance() external view returns (uint256) {
+        return address(this).balance;
+    }
+
+    function withdraw(uint256 amount) external {}
+
+    constructor() {}
+}
+
+abstract contract Base {
+    function _hook() internal virtual;
+
+    modifier onlyOwner() virtual;
+}
+
+contract Ordered {
+    struct Position { uint256 amount; }
+
+    uint256 total;
+
+    event Deposited(uint256 amount);
+
+    error Unauthorized();
+
+    modifier onlyOwner() { _; }
+
+    constructor() {}
+
+    receive() external payable {}
+
+    fallback() external {}
+
+    function deposit() external payable onlyOwner {}
+
+    function balance() external view returns (uint256) {
+        return total;
+    }
+
+    function _update() internal {}
+
+    function _check() private pure {}
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := functionorder.Detector{}
+
+	finding := d.Detect(file, a.GetCurrentFileEnv())
+	if finding == nil {
+		t.Fatalf("Expected a finding, got nil")
+	}
+
+	finding.CalculatePositions(file.SourceFile)
+
+	// Only the first out-of-order declaration of each contract is reported.
+	expectedLocations := []reporter.Location{
+		{
+			Position: token.Position{Line: 8, Column: 5},
+			Context:  "event Deposited",
+			Suggestion: `contract Layout {
+    uint256 total
+    event Deposited
+    function deposit() external payable
+}`,
+		},
+		{
+			Position: token.Position{Line: 18, Column: 5},
+			Context:  "function withdraw(uint256 amount) external",
+			Suggestion: `contract Functions {
+    constructor()
+    receive() external payable
+    function withdraw(uint256 amount) external
+    function balance() external view
+}`,
+		},
+		{
+			Position: token.Position{Line: 26, Column: 5},
+			Context:  "modifier onlyOwner",
+			Suggestion: `abstract contract Base {
+    modifier onlyOwner
+    function _hook() internal
+}`,
+		},
+	}
+
+	if len(finding.Locations) != len(expectedLocations) {
+		t.Fatalf("Expected %d locations, got %d: %v", len(expectedLocations), len(finding.Locations), finding.Locations)
+	}
+
+	for i, expected := range expectedLocations {
+		got := finding.Locations[i]
+		if got.Position.Line != expected.Position.Line || got.Position.Column != expected.Position.Column {
+			t.Errorf("Location %d: expected %d:%d, got %d:%d", i,
+				expected.Position.Line, expected.Position.Column, got.Position.Line, got.Position.Column)
+		}
+		if got.Context != expected.Context {
+			t.Errorf("Location %d: expected context %q, got %q", i, expected.Context, got.Context)
+		}
+		if got.Suggestion != expected.Suggestion {
+			t.Errorf("Location %d: expected outline:\n%s\ngot:\n%s", i, expected.Suggestion, got.Suggestion)
+		}
+	}
+}
+
+func Test_DetectFunctionOrder_ShouldReturnNil(t *testing.T) {
+	src := `contract Vault {
+    using SafeTransfer for address;
+
+    address owner;
+
+    constructor() {
+        owner = msg.sender;
+    }
+
+    function withdraw() external {}
+
+    function owned() public view returns (bool) {
+        return msg.sender == owner;
+    }
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	d := functionorder.Detector{}
+
+	if finding := d.Detect(file, nil); finding != nil {
+		t.Errorf("Expected no finding, got: %v", finding.Locations)
+	}
+}
+
+func Test_DetectFunctionOrder_SkipsUnknownDeclarations(t *testing.T) {
+	src := `contract Vault {
+    uint256 total;
+
+    function deposit() external {}
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	// The parser doesn't produce constants inside the contracts, so the
+	// declaration outside of the layout is added to the body by hand.
+	contract := file.Declarations[0].(*ast.ContractDeclaration)
+	contract.Body.Declarations = append(contract.Body.Declarations, &ast.ConstantVariableDeclaration{
+		Name:  &ast.Identifier{Value: "LIMIT"},
+		Type:  &ast.ElementaryType{Kind: token.Token{Type: token.UINT_256, Literal: "uint256"}},
+		Value: &ast.NumberLiteral{},
+	})
+
+	d := functionorder.Detector{}
+
+	if finding := d.Detect(file, nil); finding != nil {
+		t.Errorf("Expected no finding, got: %v", finding.Locations)
+	}
+}
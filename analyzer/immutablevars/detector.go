@@ -0,0 +1,93 @@
+// immutablevars detects state variables that are written only in the
+// constructor of their contract, so they can be declared as immutable. The
+// variables initialized in the declaration with a value that is not a
+// compile time constant e.g. `msg.sender`, and never written afterwards are
+// reported too. The writes in the inline assembly e.g. `sstore(x.slot, 1)`,
+// in the functions called from the constructor and in the contracts
+// inheriting the variable, can't be done to immutables, so such variables
+// are skipped. Only the variables of the value types can be immutable; they
+// can't be written through the storage pointers.
+package immutablevars
+
+import (
+	"github.com/ChmielewskiKamil/solbot/analyzer/constantvars"
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/symbols"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+const (
+	title          = "Variables that are assigned once during construction should be declared as `immutable`"
+	severity       = "Best Practices"
+	descTempl      = "The following state variables are never changed after the construction of the contract: {{ range .Locations }}\n- `{{ .Context }}`{{ end }}"
+	recommendation = "Consider declaring the variables as `immutable`. The immutables are not stored in the storage, so reading them is much cheaper."
+)
+
+type Detector struct{}
+
+func (*Detector) Detect(node ast.Node, env *symbols.Environment) *reporter.Finding {
+	if _, ok := node.(*ast.File); !ok || env == nil {
+		return nil
+	}
+
+	finding := reporter.Finding{}
+
+	for _, contract := range symbols.GetAllSymbolsByType[*symbols.Contract](env) {
+		contractEnv := contract.GetInnerEnv()
+		if contractEnv == nil {
+			continue
+		}
+
+		for _, stateVar := range symbols.GetAllSymbolsByType[*symbols.StateVariable](contractEnv) {
+			if !isCandidate(stateVar, contract) {
+				continue
+			}
+
+			finding.Locations = append(finding.Locations, reporter.Location{
+				Position: token.Position{
+					Offset: stateVar.Offset,
+				},
+				Context: stateVar.Name,
+			})
+		}
+	}
+
+	if len(finding.Locations) == 0 {
+		return nil
+	}
+
+	finding.Title = title
+	finding.Severity = severity
+	finding.Description = reporter.GenerateCustomDescription(descTempl, finding.Locations)
+	finding.Recommendation = recommendation
+	return &finding
+}
+
+func isCandidate(stateVar *symbols.StateVariable, contract *symbols.Contract) bool {
+	if stateVar.Mutability != 0 || !symbols.IsValueType(stateVar.Type) || constantvars.IsCandidate(stateVar) {
+		return false
+	}
+
+	contractDecl := contract.GetAstNode()
+
+	writes := 0
+	for _, ref := range stateVar.References {
+		if ref.Context.Usage != symbols.WRITE {
+			continue
+		}
+		// Only the constructor of the contract declaring the variable can
+		// write to an immutable, and not in the inline assembly.
+		if _, ok := ref.AstNode.(*ast.AssemblyStatement); ok {
+			return false
+		}
+		if ref.Context.ScopeType != symbols.CONSTRUCTOR ||
+			ref.Offset < contractDecl.Start() || ref.Offset >= contractDecl.End() {
+			return false
+		}
+		writes++
+	}
+
+	decl, ok := stateVar.AstNode.(*ast.StateVariableDeclaration)
+	return ok && (writes > 0 || decl.Value != nil)
+}
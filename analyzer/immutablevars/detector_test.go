@@ -0,0 +1,95 @@
+package immutablevars_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/analyzer"
+	"github.com/ChmielewskiKamil/solbot/analyzer/immutablevars"
+	"github.com/ChmielewskiKamil/solbot/parser"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+func Test_DetectImmutableVariables(t *testing.T) {
+	src := `contract Base {
+    address public writtenInChildConstructor;
+}
+
+contract Vault is Base {
+    address public owner;
+    address public deployer = msg.sender;
+    uint256 public fee = 100;
+    uint256 public writtenInFunction;
+    uint256 public writtenInAssembly;
+    uint256 public setInInternalFunction;
+    string public name;
+    uint256 public immutable CAP;
+
+    constructor(address _owner, uint256 cap, string memory _name) {
+        owner = _owner;
+        CAP = cap;
+        name = _name;
+        writtenInFunction = 1;
+        writtenInChildConstructor = _owner;
+        assembly {
+            sstore(writtenInAssembly.slot, 1)
+        }
+        _init();
+    }
+
+    function _init() internal {
+        setInInternalFunction = 1;
+    }
+
+    function f() public {
+        writtenInFunction = 2;
+    }
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := immutablevars.Detector{}
+
+	finding := d.Detect(file, a.GetCurrentFileEnv())
+	if finding == nil {
+		t.Fatalf("Expected a finding, got nil")
+	}
+
+	finding.CalculatePositions(file.SourceFile)
+
+	// The fee is reported by the constantvars detector, and the string
+	// can't be immutable.
+	expectedLocations := []reporter.Location{
+		{Position: token.Position{Line: 6, Column: 20}, Context: "owner"},
+		{Position: token.Position{Line: 7, Column: 20}, Context: "deployer"},
+	}
+
+	if len(finding.Locations) != len(expectedLocations) {
+		t.Fatalf("Expected %d findings, got %d: %v",
+			len(expectedLocations), len(finding.Locations), finding.Locations)
+	}
+
+	for i, loc := range finding.Locations {
+		if loc.Position.Line != expectedLocations[i].Position.Line ||
+			loc.Position.Column != expectedLocations[i].Position.Column {
+			t.Errorf("Expected %d:%d, got %d:%d",
+				expectedLocations[i].Position.Line, expectedLocations[i].Position.Column,
+				loc.Position.Line, loc.Position.Column)
+		}
+
+		if loc.Context != expectedLocations[i].Context {
+			t.Errorf("Expected context %s, got %s", expectedLocations[i].Context, loc.Context)
+		}
+	}
+}
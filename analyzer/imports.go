@@ -0,0 +1,249 @@
+package analyzer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/ast/astutil"
+	"github.com/ChmielewskiKamil/solbot/parser"
+	"github.com/ChmielewskiKamil/solbot/symbols"
+)
+
+// discoverImportDirective declares the names imported by the directive in
+// the file env. The names of a plain import e.g. `import "./A.sol";` are the
+// names exported by the imported file, so the file is parsed. If it can't be
+// read or parsed, a warning is added and nothing is declared for it.
+func (a *Analyzer) discoverImportDirective(node *ast.ImportDirective, env *symbols.Environment) {
+	declare := func(name *ast.Identifier, decl ast.Node) {
+		env.Set(name.Value, &symbols.ImportedSymbol{
+			BaseSymbol: symbols.BaseSymbol{
+				Name:       name.Value,
+				SourceFile: a.currentFile.SourceFile,
+				Offset:     name.Pos,
+				AstNode:    decl,
+			},
+			Directive: node,
+		})
+	}
+
+	switch {
+	case node.UnitAlias != nil:
+		declare(node.UnitAlias, node)
+	case len(node.Symbols) > 0:
+		for _, sym := range node.Symbols {
+			if sym != nil && sym.Name != nil {
+				declare(sym.LocalName(), sym)
+			}
+		}
+	case node.Path != nil:
+		names, err := a.exportedNames(a.currentFile.Name, node.Path.Value, make(map[string]bool))
+		if err != nil {
+			a.warnings.Add(a.GetNodeLocation(node, node.Pos), "Import warning: "+err.Error())
+			return
+		}
+
+		// The symbols of a plain import are located at its path.
+		seen := make(map[string]bool)
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				declare(&ast.Identifier{Pos: node.Path.Pos, Value: name}, node)
+			}
+		}
+	}
+}
+
+// exportedNames returns the names the file at the path, imported by the
+// importer, makes visible to the files importing it: its top-level
+// declarations and the names it imports itself. The visited files are
+// skipped, so the import cycles are harmless.
+func (a *Analyzer) exportedNames(importer, path string, visited map[string]bool) ([]string, error) {
+	resolved := a.resolveImportPath(importer, path)
+	if visited[resolved] {
+		return nil, nil
+	}
+	visited[resolved] = true
+
+	f, err := os.Open(resolved)
+	if err != nil {
+		return nil, fmt.Errorf("could not open the imported file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	// The parser skips the unsupported declarations e.g. interfaces, so the
+	// names of a file with errors are not known.
+	file, err := parser.ParseFile(resolved, f)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the imported file %s", path)
+	}
+
+	var names []string
+	for _, decl := range file.Declarations {
+		switch d := decl.(type) {
+		case *ast.ContractDeclaration:
+			names = append(names, d.Name.Value)
+		case *ast.FunctionDeclaration:
+			names = append(names, d.Name.Value)
+		case *ast.ConstantVariableDeclaration:
+			names = append(names, d.Name.Value)
+		case *ast.EventDeclaration:
+			names = append(names, d.Name.Value)
+		case *ast.StructDeclaration:
+			names = append(names, d.Name.Value)
+		case *ast.EnumDeclaration:
+			names = append(names, d.Name.Value)
+		case *ast.ErrorDeclaration:
+			names = append(names, d.Name.Value)
+		case *ast.ImportDirective:
+			switch {
+			case d.UnitAlias != nil:
+				names = append(names, d.UnitAlias.Value)
+			case len(d.Symbols) > 0:
+				for _, sym := range d.Symbols {
+					names = append(names, sym.LocalName().Value)
+				}
+			default:
+				imported, err := a.exportedNames(resolved, d.Path.Value, visited)
+				if err != nil {
+					return nil, err
+				}
+				names = append(names, imported...)
+			}
+		}
+	}
+	return names, nil
+}
+
+// resolveImportPath returns the path of the imported file. The paths
+// starting with "./" or "../" are relative to the importing file, the other
+// ones to the project root.
+// TODO: Support the remappings e.g. `@openzeppelin/=lib/openzeppelin/`.
+func (a *Analyzer) resolveImportPath(importer, path string) string {
+	if strings.HasPrefix(path, "./") || strings.HasPrefix(path, "../") {
+		return filepath.Join(filepath.Dir(importer), path)
+	}
+	return filepath.Join(a.projectRoot, path)
+}
+
+// resolveImportedSymbols attaches the references to the imported symbols.
+// The imported names can be used anywhere, including the places the other
+// references are not resolved in e.g. the bases of the contracts and the
+// type names, so the whole file is searched for them. The identifiers the
+// type checker resolved refer to the import they were resolved to. The
+// other ones can come from the plain imports, which the type checker does
+// not know, so they refer to the imported symbols of the same name.
+func (a *Analyzer) resolveImportedSymbols(file *ast.File, env *symbols.Environment) {
+	imported := symbols.GetAllSymbolsByType[*symbols.ImportedSymbol](env)
+	if len(imported) == 0 {
+		return
+	}
+
+	for _, decl := range file.Declarations {
+		switch d := decl.(type) {
+		case *ast.ImportDirective:
+			continue
+		case *ast.ContractDeclaration:
+			scope := symbols.ReferenceContext{ScopeName: d.Name.Value, ScopeType: symbols.CONTRACT}
+			for _, parent := range d.Parents {
+				a.resolveImportedIdentifiers(parent, scope, imported)
+			}
+			if d.Body == nil {
+				continue
+			}
+			for _, member := range d.Body.Declarations {
+				a.resolveImportedIdentifiers(member, memberScope(member, scope), imported)
+			}
+		case *ast.FunctionDeclaration:
+			scope := symbols.ReferenceContext{ScopeName: d.Name.Value, ScopeType: symbols.FUNCTION}
+			a.resolveImportedIdentifiers(d, scope, imported)
+		default:
+			scope := symbols.ReferenceContext{ScopeName: file.Name, ScopeType: symbols.FILE}
+			a.resolveImportedIdentifiers(d, scope, imported)
+		}
+	}
+}
+
+// memberScope returns the scope of the references in the contract member.
+func memberScope(member ast.Declaration, contractScope symbols.ReferenceContext) symbols.ReferenceContext {
+	switch m := member.(type) {
+	case *ast.FunctionDeclaration:
+		return symbols.ReferenceContext{ScopeName: m.Name.Value, ScopeType: symbols.FUNCTION}
+	case *ast.ModifierDeclaration:
+		return symbols.ReferenceContext{ScopeName: m.Name.Value, ScopeType: symbols.MODIFIER}
+	case *ast.ConstructorDeclaration:
+		return symbols.ReferenceContext{ScopeName: "constructor", ScopeType: symbols.CONSTRUCTOR}
+	case *ast.SpecialFunctionDeclaration:
+		return symbols.ReferenceContext{ScopeName: m.Kind.Literal, ScopeType: symbols.FUNCTION}
+	}
+	return contractScope
+}
+
+func (a *Analyzer) resolveImportedIdentifiers(
+	node ast.Node, scope symbols.ReferenceContext, imported []*symbols.ImportedSymbol) {
+	astutil.Apply(node, func(c *astutil.Cursor) bool {
+		ident, ok := c.Node().(*ast.Identifier)
+		if !ok {
+			return true
+		}
+		// The members e.g. `x` in `M.x`, are not looked up in the file.
+		if _, ok := c.Parent().(*ast.MemberAccessExpression); ok && c.Name() == "Member" {
+			return true
+		}
+		// Neither are the names of the call options e.g. `value`.
+		if _, ok := c.Parent().(*ast.CallOptionsExpression); ok && c.Name() == "Names" {
+			return true
+		}
+
+		for _, symbol := range a.importedSymbolsOf(ident, imported) {
+			symbol.AddReference(&symbols.Reference{
+				SourceFile: a.currentFile.SourceFile,
+				Offset:     ident.Pos,
+				Context: symbols.ReferenceContext{
+					ScopeName: scope.ScopeName,
+					ScopeType: scope.ScopeType,
+					Usage:     symbols.READ,
+				},
+				AstNode: ident,
+			})
+		}
+		return true
+	}, nil)
+}
+
+// importedSymbolsOf returns the imported symbols the identifier refers to.
+// An identifier can refer to the symbols of many plain imports, if they
+// export the same name e.g. two files importing the same interface.
+func (a *Analyzer) importedSymbolsOf(ident *ast.Identifier, imported []*symbols.ImportedSymbol) []*symbols.ImportedSymbol {
+	var decl ast.Node
+	if a.typesInfo != nil {
+		if _, ok := a.typesInfo.Defs[ident]; ok {
+			// The name of a declaration.
+			return nil
+		}
+		decl = a.typesInfo.Uses[ident]
+	}
+
+	var results []*symbols.ImportedSymbol
+	for _, symbol := range imported {
+		if decl != nil {
+			if symbol.AstNode == decl {
+				results = append(results, symbol)
+			}
+			continue
+		}
+		// The names of the plain imports are not known to the type checker.
+		if symbol.Name == ident.Value && (a.typesInfo == nil || isPlainImport(symbol.Directive)) {
+			results = append(results, symbol)
+		}
+	}
+	return results
+}
+
+// isPlainImport reports whether the directive imports all the names of the
+// file e.g. `import "./A.sol";`.
+func isPlainImport(dir *ast.ImportDirective) bool {
+	return dir.UnitAlias == nil && len(dir.Symbols) == 0
+}
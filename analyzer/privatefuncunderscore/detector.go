@@ -0,0 +1,98 @@
+// privatefuncunderscore detects private and internal functions whose names
+// are not prefixed with an underscore. If CheckPublic is set, the public and
+// external functions prefixed with an underscore are reported too. The free
+// functions declared at a File level are skipped, since the convention is
+// about the members of the contracts.
+//
+// The suggested edits rename the function and all of its references. The
+// overrides are skipped, since their names are given by the overridden
+// functions. The virtual functions are reported without the edits, since they
+// can be overridden outside of the analyzed file. The edits are not suggested
+// either if the new name is already declared in the contract or in the
+// contracts inheriting it, since renaming could change which declaration the
+// references resolve to.
+package privatefuncunderscore
+
+import (
+	"strings"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/symbols"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+const (
+	title          = "Private and internal functions should be prefixed with an underscore"
+	severity       = "Best Practices"
+	descTempl      = "The names of the `private` and `internal` functions should start with an underscore, and the names of the `public` and `external` ones should not. The following functions don't follow this convention: {{ range .Locations }}\n- `{{ .Context }}`{{ if .Suggestion }} (consider `{{ .Suggestion }}`){{ end }}{{ end }}"
+	recommendation = "Consider renaming the functions to make their visibility clear at the call sites."
+)
+
+type Detector struct {
+	CheckPublic bool // Whether to report the public and external functions prefixed with an underscore.
+}
+
+func (d *Detector) Detect(node ast.Node, env *symbols.Environment) *reporter.Finding {
+	if _, ok := node.(*ast.File); !ok || env == nil {
+		return nil
+	}
+
+	finding := reporter.Finding{}
+
+	for _, contract := range symbols.GetAllSymbolsByType[*symbols.Contract](env) {
+		contractEnv := contract.GetInnerEnv()
+		if contractEnv == nil {
+			continue
+		}
+
+		for _, fn := range symbols.GetAllSymbolsByType[*symbols.Function](contractEnv) {
+			decl, ok := fn.AstNode.(*ast.FunctionDeclaration)
+			if !ok || decl.Override != nil {
+				continue
+			}
+
+			prefixed := strings.HasPrefix(fn.Name, "_")
+			var newName string
+			switch fn.Visibility {
+			case ast.Private, ast.Internal:
+				if prefixed {
+					continue
+				}
+				newName = "_" + fn.Name
+			case ast.Public, ast.External:
+				if !prefixed || !d.CheckPublic {
+					continue
+				}
+				newName = strings.TrimLeft(fn.Name, "_")
+			default:
+				continue
+			}
+
+			location := reporter.Location{
+				Position: token.Position{
+					Offset: fn.Offset,
+				},
+				Context: fn.Name,
+			}
+			// A name of underscores only has no unprefixed form.
+			if newName != "" {
+				location.Suggestion = newName
+				if !fn.Virtual && !symbols.IsDeclared(contract, newName) {
+					location.Edits = reporter.ReplaceAll(symbols.Occurrences(&fn.BaseSymbol), len(fn.Name), newName)
+				}
+			}
+			finding.Locations = append(finding.Locations, location)
+		}
+	}
+
+	if len(finding.Locations) == 0 {
+		return nil
+	}
+
+	finding.Title = title
+	finding.Severity = severity
+	finding.Description = reporter.GenerateCustomDescription(descTempl, finding.Locations)
+	finding.Recommendation = recommendation
+	return &finding
+}
@@ -0,0 +1,186 @@
+package privatefuncunderscore_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/analyzer"
+	"github.com/ChmielewskiKamil/solbot/analyzer/privatefuncunderscore"
+	"github.com/ChmielewskiKamil/solbot/parser"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+const src = `function helper() pure returns (uint256) {
+    return 1;
+}
+
+contract Base {
+    function hook() internal virtual {}
+
+    function _guarded() internal virtual {}
+}
+
+contract Vault is Base {
+    function deposit() public {
+        compute(1);
+        this._withdraw();
+    }
+
+    function compute(uint256 amount) private returns (uint256) {
+        return amount + helper();
+    }
+
+    function _withdraw() external {}
+
+    function _guarded() internal override {}
+
+    function validate() internal {
+        uint256 _validate = 1;
+    }
+}
+`
+
+func Test_DetectPrivateFuncUnderscore(t *testing.T) {
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := privatefuncunderscore.Detector{CheckPublic: true}
+
+	finding := d.Detect(file, a.GetCurrentFileEnv())
+	if finding == nil {
+		t.Fatalf("Expected a finding, got nil")
+	}
+
+	finding.CalculatePositions(file.SourceFile)
+
+	// The free function and the override are skipped. The virtual function
+	// and the function shadowed by the local variable are not renamed.
+	expectedLocations := []struct {
+		reporter.Location
+		edits int
+	}{
+		{reporter.Location{Position: token.Position{Line: 6, Column: 14}, Context: "hook", Suggestion: "_hook"}, 0},
+		{reporter.Location{Position: token.Position{Line: 17, Column: 14}, Context: "compute", Suggestion: "_compute"}, 2},
+		{reporter.Location{Position: token.Position{Line: 21, Column: 14}, Context: "_withdraw", Suggestion: "withdraw"}, 2},
+		{reporter.Location{Position: token.Position{Line: 25, Column: 14}, Context: "validate", Suggestion: "_validate"}, 0},
+	}
+
+	if len(finding.Locations) != len(expectedLocations) {
+		t.Fatalf("Expected %d locations, got %d: %v", len(expectedLocations), len(finding.Locations), finding.Locations)
+	}
+
+	for i, expected := range expectedLocations {
+		got := finding.Locations[i]
+		if got.Position.Line != expected.Position.Line || got.Position.Column != expected.Position.Column {
+			t.Errorf("Location %d: expected %d:%d, got %d:%d", i,
+				expected.Position.Line, expected.Position.Column, got.Position.Line, got.Position.Column)
+		}
+		if got.Context != expected.Context || got.Suggestion != expected.Suggestion {
+			t.Errorf("Location %d: expected %q (%q), got %q (%q)", i,
+				expected.Context, expected.Suggestion, got.Context, got.Suggestion)
+		}
+		if len(got.Edits) != expected.edits {
+			t.Errorf("Location %d: expected %d edits, got: %v", i, expected.edits, got.Edits)
+		}
+	}
+
+	expectedBody := `    function deposit() public {
+        _compute(1);
+        this.withdraw();
+    }
+
+    function _compute(uint256 amount) private returns (uint256) {
+        return amount + helper();
+    }
+
+    function withdraw() external {}
+`
+	var edits []reporter.Edit
+	for _, location := range finding.Locations {
+		edits = append(edits, location.Edits...)
+	}
+	fixed, err := reporter.ApplyEdits(file.SourceFile, edits)
+	if err != nil {
+		t.Fatalf("ApplyEdits failed: %v", err)
+	}
+	if !strings.Contains(fixed, expectedBody) {
+		t.Errorf("Expected the fixed source to contain:\n%s\ngot:\n%s", expectedBody, fixed)
+	}
+}
+
+func Test_DetectPrivateFuncUnderscore_PublicNotChecked(t *testing.T) {
+	src := `contract Vault {
+    function _withdraw() external {}
+
+    function _compute() internal {}
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := privatefuncunderscore.Detector{}
+
+	if finding := d.Detect(file, a.GetCurrentFileEnv()); finding != nil {
+		t.Errorf("Expected no finding, got: %v", finding.Locations)
+	}
+}
+
+func Test_DetectPrivateFuncUnderscore_ShadowedInDerivedContract(t *testing.T) {
+	// The param of the derived contract would shadow the renamed function.
+	src := `contract Base {
+    function refresh() internal {}
+}
+
+contract Derived is Base {
+    function update(uint256 _refresh) public {
+        refresh();
+    }
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := privatefuncunderscore.Detector{}
+
+	finding := d.Detect(file, a.GetCurrentFileEnv())
+	if finding == nil {
+		t.Fatalf("Expected a finding, got nil")
+	}
+
+	if len(finding.Locations) != 1 {
+		t.Fatalf("Expected 1 location, got %d: %v", len(finding.Locations), finding.Locations)
+	}
+	if got := finding.Locations[0]; got.Context != "refresh" || got.Suggestion != "_refresh" {
+		t.Errorf("Expected refresh (_refresh), got %q (%q)", got.Context, got.Suggestion)
+	}
+	if edits := finding.Locations[0].Edits; edits != nil {
+		t.Errorf("Expected no edits, got: %v", edits)
+	}
+}
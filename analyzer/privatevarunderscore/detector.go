@@ -0,0 +1,94 @@
+// privatevarunderscore detects private and internal state variables whose
+// names are not prefixed with an underscore. If CheckPublic is set, the public
+// state variables prefixed with an underscore are reported too. The constant
+// and immutable variables are skipped, since they are named in
+// SCREAMING_SNAKE_CASE, see screamingsnakeconst.
+//
+// The suggested edits rename the variable and all of its references. The
+// edits are not suggested if the new name is already declared in the
+// contract or in the contracts inheriting it, since renaming could change
+// which declaration the references resolve to.
+package privatevarunderscore
+
+import (
+	"strings"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/symbols"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+const (
+	title          = "Private and internal state variables should be prefixed with an underscore"
+	severity       = "Best Practices"
+	descTempl      = "The names of the `private` and `internal` state variables should start with an underscore, and the names of the `public` ones should not. The following state variables don't follow this convention: {{ range .Locations }}\n- `{{ .Context }}`{{ if .Suggestion }} (consider `{{ .Suggestion }}`){{ end }}{{ end }}"
+	recommendation = "Consider renaming the state variables to tell them apart from the local variables and the parameters."
+)
+
+type Detector struct {
+	CheckPublic bool // Whether to report the public state variables prefixed with an underscore.
+}
+
+func (d *Detector) Detect(node ast.Node, env *symbols.Environment) *reporter.Finding {
+	if _, ok := node.(*ast.File); !ok || env == nil {
+		return nil
+	}
+
+	finding := reporter.Finding{}
+
+	for _, contract := range symbols.GetAllSymbolsByType[*symbols.Contract](env) {
+		contractEnv := contract.GetInnerEnv()
+		if contractEnv == nil {
+			continue
+		}
+
+		for _, stateVar := range symbols.GetAllSymbolsByType[*symbols.StateVariable](contractEnv) {
+			if stateVar.Mutability == ast.Constant || stateVar.Mutability == ast.Immutable {
+				continue
+			}
+
+			prefixed := strings.HasPrefix(stateVar.Name, "_")
+			var newName string
+			switch stateVar.Visibility {
+			case ast.Private, ast.Internal:
+				if prefixed {
+					continue
+				}
+				newName = "_" + stateVar.Name
+			case ast.Public:
+				if !prefixed || !d.CheckPublic {
+					continue
+				}
+				newName = strings.TrimLeft(stateVar.Name, "_")
+			default:
+				continue
+			}
+
+			location := reporter.Location{
+				Position: token.Position{
+					Offset: stateVar.Offset,
+				},
+				Context: stateVar.Name,
+			}
+			// A name of underscores only has no unprefixed form.
+			if newName != "" {
+				location.Suggestion = newName
+				if !symbols.IsDeclared(contract, newName) {
+					location.Edits = reporter.ReplaceAll(symbols.Occurrences(&stateVar.BaseSymbol), len(stateVar.Name), newName)
+				}
+			}
+			finding.Locations = append(finding.Locations, location)
+		}
+	}
+
+	if len(finding.Locations) == 0 {
+		return nil
+	}
+
+	finding.Title = title
+	finding.Severity = severity
+	finding.Description = reporter.GenerateCustomDescription(descTempl, finding.Locations)
+	finding.Recommendation = recommendation
+	return &finding
+}
@@ -0,0 +1,225 @@
+package privatevarunderscore_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/analyzer"
+	"github.com/ChmielewskiKamil/solbot/analyzer/privatevarunderscore"
+	"github.com/ChmielewskiKamil/solbot/parser"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+const src = `contract Vault {
+    uint256 constant MAX = 100;
+    address immutable OWNER;
+    uint256 balance;
+    uint256 private _fees;
+    uint256 public _total;
+    address internal admin;
+
+    constructor() {
+        OWNER = msg.sender;
+    }
+
+    function deposit(address _admin) public {
+        balance += msg.value;
+        _total = balance + _fees;
+        admin = _admin;
+    }
+}
+`
+
+func Test_DetectPrivateVarUnderscore(t *testing.T) {
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := privatevarunderscore.Detector{CheckPublic: true}
+
+	finding := d.Detect(file, a.GetCurrentFileEnv())
+	if finding == nil {
+		t.Fatalf("Expected a finding, got nil")
+	}
+
+	finding.CalculatePositions(file.SourceFile)
+
+	// The constants and immutables are skipped. The variable shadowed by the
+	// param is not renamed.
+	expectedLocations := []struct {
+		reporter.Location
+		edits int
+	}{
+		{reporter.Location{Position: token.Position{Line: 4, Column: 13}, Context: "balance", Suggestion: "_balance"}, 3},
+		{reporter.Location{Position: token.Position{Line: 6, Column: 20}, Context: "_total", Suggestion: "total"}, 2},
+		{reporter.Location{Position: token.Position{Line: 7, Column: 22}, Context: "admin", Suggestion: "_admin"}, 0},
+	}
+
+	if len(finding.Locations) != len(expectedLocations) {
+		t.Fatalf("Expected %d locations, got %d: %v", len(expectedLocations), len(finding.Locations), finding.Locations)
+	}
+
+	for i, expected := range expectedLocations {
+		got := finding.Locations[i]
+		if got.Position.Line != expected.Position.Line || got.Position.Column != expected.Position.Column {
+			t.Errorf("Location %d: expected %d:%d, got %d:%d", i,
+				expected.Position.Line, expected.Position.Column, got.Position.Line, got.Position.Column)
+		}
+		if got.Context != expected.Context || got.Suggestion != expected.Suggestion {
+			t.Errorf("Location %d: expected %q (%q), got %q (%q)", i,
+				expected.Context, expected.Suggestion, got.Context, got.Suggestion)
+		}
+		if len(got.Edits) != expected.edits {
+			t.Errorf("Location %d: expected %d edits, got: %v", i, expected.edits, got.Edits)
+		}
+	}
+
+	expectedBody := `    function deposit(address _admin) public {
+        _balance += msg.value;
+        total = _balance + _fees;
+        admin = _admin;
+    }
+`
+	var edits []reporter.Edit
+	for _, location := range finding.Locations {
+		edits = append(edits, location.Edits...)
+	}
+	fixed, err := reporter.ApplyEdits(file.SourceFile, edits)
+	if err != nil {
+		t.Fatalf("ApplyEdits failed: %v", err)
+	}
+	if !strings.Contains(fixed, expectedBody) {
+		t.Errorf("Expected the fixed source to contain:\n%s\ngot:\n%s", expectedBody, fixed)
+	}
+}
+
+func Test_DetectPrivateVarUnderscore_AssemblyAndPush(t *testing.T) {
+	// The references in the assembly and of the pushed variable are both
+	// reads and writes, but each of them is renamed once.
+	src := `contract Vault {
+    uint256 slotVal;
+    bytes data;
+
+    function f(bytes1 b) public {
+        assembly {
+            sstore(slotVal.slot, 1)
+        }
+        data.push(b);
+    }
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := privatevarunderscore.Detector{}
+
+	finding := d.Detect(file, a.GetCurrentFileEnv())
+	if finding == nil {
+		t.Fatalf("Expected a finding, got nil")
+	}
+
+	var edits []reporter.Edit
+	for _, location := range finding.Locations {
+		if len(location.Edits) != 2 {
+			t.Errorf("Expected 2 edits of %s, got: %v", location.Context, location.Edits)
+		}
+		edits = append(edits, location.Edits...)
+	}
+	fixed, err := reporter.ApplyEdits(file.SourceFile, edits)
+	if err != nil {
+		t.Fatalf("ApplyEdits failed: %v", err)
+	}
+
+	expectedBody := `        assembly {
+            sstore(_slotVal.slot, 1)
+        }
+        _data.push(b);
+`
+	if !strings.Contains(fixed, expectedBody) {
+		t.Errorf("Expected the fixed source to contain:\n%s\ngot:\n%s", expectedBody, fixed)
+	}
+}
+
+func Test_DetectPrivateVarUnderscore_PublicNotChecked(t *testing.T) {
+	src := `contract Vault {
+    uint256 public _total;
+    uint256 private _fees;
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := privatevarunderscore.Detector{}
+
+	if finding := d.Detect(file, a.GetCurrentFileEnv()); finding != nil {
+		t.Errorf("Expected no finding, got: %v", finding.Locations)
+	}
+}
+
+func Test_DetectPrivateVarUnderscore_ShadowedInDerivedContract(t *testing.T) {
+	// The param of the derived contract would shadow the renamed variable.
+	src := `contract Base {
+    address owner;
+}
+
+contract Derived is Base {
+    function set(address _owner) public {
+        owner = _owner;
+    }
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := privatevarunderscore.Detector{}
+
+	finding := d.Detect(file, a.GetCurrentFileEnv())
+	if finding == nil {
+		t.Fatalf("Expected a finding, got nil")
+	}
+
+	if len(finding.Locations) != 1 {
+		t.Fatalf("Expected 1 location, got %d: %v", len(finding.Locations), finding.Locations)
+	}
+	if got := finding.Locations[0]; got.Context != "owner" || got.Suggestion != "_owner" {
+		t.Errorf("Expected owner (_owner), got %q (%q)", got.Context, got.Suggestion)
+	}
+	if edits := finding.Locations[0].Edits; edits != nil {
+		t.Errorf("Expected no edits, got: %v", edits)
+	}
+}
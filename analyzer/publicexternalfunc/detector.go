@@ -0,0 +1,64 @@
+// publicexternalfunc detects public functions that are never called
+// internally and can be declared as external. The functions marked as
+// virtual or override are skipped: a virtual function can be called
+// internally by a child contract outside of the analyzed file, and the
+// visibility of an override must stay compatible with the function it
+// overrides e.g. the function of an implemented interface.
+package publicexternalfunc
+
+import (
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/symbols"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+const (
+	title          = "A `public` function that is not called internally should be declared as `external`"
+	severity       = "Best Practices"
+	descTempl      = "The following `public` functions are never called from inside of the contracts: {{ range .Locations }}\n- `{{ .Context }}`{{ end }}"
+	recommendation = "Consider declaring the functions as `external`. It makes the intended usage of the functions clear and can save gas, since the params can be declared as `calldata`."
+)
+
+type Detector struct{}
+
+func (*Detector) Detect(node ast.Node, env *symbols.Environment) *reporter.Finding {
+	if _, ok := node.(*ast.File); !ok || env == nil {
+		return nil
+	}
+
+	finding := reporter.Finding{}
+
+	for _, fn := range symbols.GetAllSymbolsByTypeInFile[*symbols.Function](env) {
+		if fn.Visibility != ast.Public {
+			continue
+		}
+
+		decl, ok := fn.AstNode.(*ast.FunctionDeclaration)
+		if !ok || fn.Virtual || decl.Override != nil {
+			continue
+		}
+
+		// Any reference means the function is used inside of the file.
+		// Even the external calls e.g. `this.f()`, are counted, so the
+		// finding is never reported for a function that is used.
+		if len(fn.References) == 0 {
+			finding.Locations = append(finding.Locations, reporter.Location{
+				Position: token.Position{
+					Offset: fn.Offset,
+				},
+				Context: fn.Name,
+			})
+		}
+	}
+
+	if len(finding.Locations) == 0 {
+		return nil
+	}
+
+	finding.Title = title
+	finding.Severity = severity
+	finding.Description = reporter.GenerateCustomDescription(descTempl, finding.Locations)
+	finding.Recommendation = recommendation
+	return &finding
+}
@@ -0,0 +1,78 @@
+package publicexternalfunc_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/analyzer"
+	"github.com/ChmielewskiKamil/solbot/analyzer/publicexternalfunc"
+	"github.com/ChmielewskiKamil/solbot/parser"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+func Test_DetectPublicFunctionsThatCouldBeExternal(t *testing.T) {
+	src := `contract Base {
+    function hook() public virtual {}
+    function baseOnly() public {}
+}
+
+contract Vault is Base {
+    function hook() public override {}
+
+    function deposit() public {
+        withdraw();
+    }
+
+    function withdraw() public {}
+    function viaThis() public {}
+    function callViaThis() external {
+        this.viaThis();
+    }
+    function _internal() internal {}
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := publicexternalfunc.Detector{}
+
+	finding := d.Detect(file, a.GetCurrentFileEnv())
+	if finding == nil {
+		t.Fatalf("Expected a finding, got nil")
+	}
+
+	finding.CalculatePositions(file.SourceFile)
+
+	expectedLocations := []reporter.Location{
+		{Position: token.Position{Line: 3, Column: 14}, Context: "baseOnly"},
+		{Position: token.Position{Line: 9, Column: 14}, Context: "deposit"},
+	}
+
+	if len(finding.Locations) != len(expectedLocations) {
+		t.Fatalf("Expected %d findings, got %d: %v",
+			len(expectedLocations), len(finding.Locations), finding.Locations)
+	}
+
+	for i, loc := range finding.Locations {
+		if loc.Position.Line != expectedLocations[i].Position.Line ||
+			loc.Position.Column != expectedLocations[i].Position.Column {
+			t.Errorf("Expected %d:%d, got %d:%d",
+				expectedLocations[i].Position.Line, expectedLocations[i].Position.Column,
+				loc.Position.Line, loc.Position.Column)
+		}
+
+		if loc.Context != expectedLocations[i].Context {
+			t.Errorf("Expected context %s, got %s", expectedLocations[i].Context, loc.Context)
+		}
+	}
+}
@@ -1,62 +1,58 @@
-// screamingsnakeconst detects constant variables that are not declared in
-// SCREAMING_SNAKE_CASE. Constant variables can be declared either at a File
-// level or at a Contract level as state variables.
+// screamingsnakeconst detects constant and immutable variables that are not
+// declared in SCREAMING_SNAKE_CASE. Constant variables can be declared either
+// at a File level or at a Contract level as state variables. Immutable
+// variables can only be declared at a Contract level.
 package screamingsnakeconst
 
 import (
 	"github.com/ChmielewskiKamil/solbot/ast"
 	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/symbols"
 	"github.com/ChmielewskiKamil/solbot/token"
 	"regexp"
+	"strings"
+	"unicode"
 )
 
 const (
-	title          = "Variables declared as `constant` should be in `SCREAMING_SNAKE_CASE`"
+	title          = "Variables declared as `constant` or `immutable` should be in `SCREAMING_SNAKE_CASE`"
 	severity       = "Best Practices"
-	descTempl      = "Constant variables should be declared with a `SCREAMING_SNAKE_CASE`. The following variables don't follow this practice: {{ range .Locations }}\n- `{{ .Context }}`{{ end }}"
+	descTempl      = "Constant and immutable variables should be declared with a `SCREAMING_SNAKE_CASE`. The following variables don't follow this practice: {{ range .Locations }}\n- `{{ .Context }}` (consider `{{ .Suggestion }}`){{ end }}"
 	recommendation = "Consider renaming the variables to make the code more readable and less error-prone."
 )
 
 type Detector struct{}
 
-func (*Detector) Detect(node ast.Node) *reporter.Finding {
+func (*Detector) Detect(node ast.Node, _ *symbols.Environment) *reporter.Finding {
 	finding := reporter.Finding{}
-	matches := 0
 	switch n := node.(type) {
-	// TODO: This is wrong in a sense that it currently checks for constant variable delcarations
-	// outside of contracts. This detector should be extended to handle contract level
-	// constant and immutable variables.
 	case *ast.File:
 		for _, decl := range n.Declarations {
-			if c, ok := decl.(*ast.ContractDeclaration); ok {
-				for _, stateVar := range c.Body.Declarations {
-					if v, ok := stateVar.(*ast.StateVariableDeclaration); ok {
-						if v == nil {
-							// This handles an edge case where the AST was not properly built
-							// e.g. the parser added the declarations but they are empty.
-							continue
-						}
-						// TODO: Add immutable variables as well (but they can only be contract level)
-						if v.Mutability == ast.Constant {
-							if !isScreamingSnakeCase(v.Name.Value) {
-								finding.Locations = append(
-									finding.Locations, reporter.Location{
-										Position: token.Position{
-											Offset: v.Name.Pos,
-										},
-										// Save ident name for the report.
-										Context: v.Name.Value,
-									})
-								matches++
-							}
-						}
+			switch d := decl.(type) {
+			case *ast.ConstantVariableDeclaration:
+				if d == nil {
+					continue
+				}
+				check(&finding, d.Name)
+			case *ast.ContractDeclaration:
+				if d == nil || d.Body == nil {
+					continue
+				}
+				for _, stateVar := range d.Body.Declarations {
+					v, ok := stateVar.(*ast.StateVariableDeclaration)
+					if !ok || v == nil {
+						// This handles an edge case where the AST was not properly built
+						// e.g. the parser added the declarations but they are empty.
+						continue
+					}
+					if v.Mutability == ast.Constant || v.Mutability == ast.Immutable {
+						check(&finding, v.Name)
 					}
 				}
 			}
-
 		}
 
-		if matches > 0 {
+		if len(finding.Locations) > 0 {
 			// Add the rest of the fields to the finding
 			finding.Title = title
 			finding.Severity = severity
@@ -72,6 +68,23 @@ func (*Detector) Detect(node ast.Node) *reporter.Finding {
 	}
 }
 
+// check adds the location of the name to the finding if the name is not in
+// SCREAMING_SNAKE_CASE.
+func check(finding *reporter.Finding, name *ast.Identifier) {
+	if name == nil || isScreamingSnakeCase(name.Value) {
+		return
+	}
+
+	finding.Locations = append(finding.Locations, reporter.Location{
+		Position: token.Position{
+			Offset: name.Pos,
+		},
+		// Save ident name for the report.
+		Context:    name.Value,
+		Suggestion: toScreamingSnakeCase(name.Value),
+	})
+}
+
 func isScreamingSnakeCase(s string) bool {
 	// Regular expression to match SCREAMING_SNAKE_CASE:
 	// ^ and $ are anchors to say that the whole string must match the pattern.
@@ -81,3 +94,24 @@ func isScreamingSnakeCase(s string) bool {
 	regex := regexp.MustCompile(`^[A-Z0-9_]+$`)
 	return regex.MatchString(s)
 }
+
+// toScreamingSnakeCase converts camelCase, PascalCase and snake_case names
+// e.g. isOwner -> IS_OWNER, maxETHAmount -> MAX_ETH_AMOUNT. The words are
+// split before an uppercase letter that follows a lowercase letter or a
+// digit, and before the last uppercase letter of an acronym.
+func toScreamingSnakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && runes[i-1] != '_' {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && nextIsLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
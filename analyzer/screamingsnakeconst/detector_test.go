@@ -2,6 +2,8 @@ package screamingsnakeconst
 
 import (
 	"github.com/ChmielewskiKamil/solbot/parser"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/token"
 	"strings"
 	"testing"
 )
@@ -35,7 +37,7 @@ func Test_DetectSnakeCaseConst(t *testing.T) {
 
 	d := Detector{}
 
-	finding := d.Detect(file)
+	finding := d.Detect(file, nil)
 	if finding == nil {
 		t.Fatalf("Expected a finding, got nil")
 	}
@@ -46,31 +48,112 @@ func Test_DetectSnakeCaseConst(t *testing.T) {
 		t.Errorf("Expected %d findings, got %d", numResults, len(finding.Locations))
 	}
 
-	// TODO: CalculatePositions expects source file but with the new ParseFile
-	// API, the source file is not accessible.
-
-	// finding.CalculatePositions(file)
-
-	// expectedLocations := []reporter.Location{
-	// 	{Position: token.Position{Line: 4, Column: 19}, Context: "isOwner"},
-	// 	{Position: token.Position{Line: 5, Column: 19}, Context: "is_owner"},
-	// 	{Position: token.Position{Line: 7, Column: 22}, Context: "router"},
-	// 	{Position: token.Position{Line: 9, Column: 21}, Context: "ONE_hundred_IS_100"},
-	// }
-	//
-	// for i, loc := range finding.Locations {
-	// 	if loc.Position.Line != expectedLocations[i].Position.Line {
-	// 		t.Errorf("Expected line %d, got %d", expectedLocations[i].Position.Line, loc.Position.Line)
-	// 	}
-	//
-	// 	if loc.Position.Column != expectedLocations[i].Position.Column {
-	// 		t.Errorf("Expected column %d, got %d", expectedLocations[i].Position.Column, loc.Position.Column)
-	// 	}
-	//
-	// 	if loc.Context != expectedLocations[i].Context {
-	// 		t.Errorf("Expected context %s, got %s", expectedLocations[i].Context, loc.Context)
-	// 	}
-	// }
+	finding.CalculatePositions(file.SourceFile)
+
+	expectedLocations := []reporter.Location{
+		{Position: token.Position{Line: 4, Column: 19}, Context: "isOwner", Suggestion: "IS_OWNER"},
+		{Position: token.Position{Line: 5, Column: 19}, Context: "is_owner", Suggestion: "IS_OWNER"},
+		{Position: token.Position{Line: 7, Column: 22}, Context: "router", Suggestion: "ROUTER"},
+		{Position: token.Position{Line: 9, Column: 21}, Context: "ONE_hundred_IS_100", Suggestion: "ONE_HUNDRED_IS_100"},
+	}
+
+	for i, loc := range finding.Locations {
+		if loc.Position.Line != expectedLocations[i].Position.Line {
+			t.Errorf("Expected line %d, got %d", expectedLocations[i].Position.Line, loc.Position.Line)
+		}
+
+		if loc.Position.Column != expectedLocations[i].Position.Column {
+			t.Errorf("Expected column %d, got %d", expectedLocations[i].Position.Column, loc.Position.Column)
+		}
+
+		if loc.Context != expectedLocations[i].Context {
+			t.Errorf("Expected context %s, got %s", expectedLocations[i].Context, loc.Context)
+		}
+
+		if loc.Suggestion != expectedLocations[i].Suggestion {
+			t.Errorf("Expected suggestion %s, got %s", expectedLocations[i].Suggestion, loc.Suggestion)
+		}
+	}
+}
+
+func Test_DetectSnakeCaseFileLevelConstAndImmutable(t *testing.T) {
+	src := `uint256 constant maxSupply = 1_000;         // match
+uint256 constant MAX_FEE = 100;                 // no match
+
+contract Test {
+    address immutable owner;                    // match
+    address immutable ROUTER;                   // no match
+    uint256 immutable maxETHAmount;             // match
+    uint256 public transient counter;           // no match
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	d := Detector{}
+
+	finding := d.Detect(file, nil)
+	if finding == nil {
+		t.Fatalf("Expected a finding, got nil")
+	}
+
+	finding.CalculatePositions(file.SourceFile)
+
+	expectedLocations := []reporter.Location{
+		{Position: token.Position{Line: 1, Column: 18}, Context: "maxSupply", Suggestion: "MAX_SUPPLY"},
+		{Position: token.Position{Line: 5, Column: 23}, Context: "owner", Suggestion: "OWNER"},
+		{Position: token.Position{Line: 7, Column: 23}, Context: "maxETHAmount", Suggestion: "MAX_ETH_AMOUNT"},
+	}
+
+	if len(finding.Locations) != len(expectedLocations) {
+		t.Fatalf("Expected %d findings, got %d", len(expectedLocations), len(finding.Locations))
+	}
+
+	for i, loc := range finding.Locations {
+		if loc.Position.Line != expectedLocations[i].Position.Line ||
+			loc.Position.Column != expectedLocations[i].Position.Column {
+			t.Errorf("Expected %d:%d, got %d:%d",
+				expectedLocations[i].Position.Line, expectedLocations[i].Position.Column,
+				loc.Position.Line, loc.Position.Column)
+		}
+
+		if loc.Context != expectedLocations[i].Context {
+			t.Errorf("Expected context %s, got %s", expectedLocations[i].Context, loc.Context)
+		}
+
+		if loc.Suggestion != expectedLocations[i].Suggestion {
+			t.Errorf("Expected suggestion %s, got %s", expectedLocations[i].Suggestion, loc.Suggestion)
+		}
+	}
+
+	if !strings.Contains(finding.Description, "- `maxSupply` (consider `MAX_SUPPLY`)") {
+		t.Errorf("Expected the description to suggest the new name, got: %s", finding.Description)
+	}
+}
+
+func Test_ToScreamingSnakeCase(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"isOwner", "IS_OWNER"},
+		{"is_owner", "IS_OWNER"},
+		{"router", "ROUTER"},
+		{"MaxSupply", "MAX_SUPPLY"},
+		{"maxETHAmount", "MAX_ETH_AMOUNT"},
+		{"ONE_hundred_IS_100", "ONE_HUNDRED_IS_100"},
+		{"fee2Percent", "FEE2_PERCENT"},
+		{"_decimals", "_DECIMALS"},
+	}
+
+	for _, tt := range tests {
+		if got := toScreamingSnakeCase(tt.input); got != tt.expected {
+			t.Errorf("toScreamingSnakeCase(%q): expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
 }
 
 func Test_ShouldReturnNilIfNoVariables(t *testing.T) {
@@ -85,7 +168,7 @@ func Test_ShouldReturnNilIfNoVariables(t *testing.T) {
 
 	d := Detector{}
 
-	finding := d.Detect(file)
+	finding := d.Detect(file, nil)
 
 	if finding != nil {
 		t.Fatalf("Expected nil, got a finding")
@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Vault {
+    event Deposit(address from);
+    event Deposit(address from, uint256 amount);
+    event Deposit(uint256 amount);
+
+    uint256 public total;
+
+    function deposit() public {
+        total += msg.value;
+        emit Deposit(msg.sender, msg.value);
+    }
+
+    function depositFor() public {
+        emit Deposit(msg.value);
+    }
+
+    function register() public {
+        emit Deposit(msg.sender);
+    }
+}
@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Ownable {
+    event OwnershipTransferred(address previousOwner, address newOwner);
+
+    address owner;
+}
+
+contract Pausable {
+    event Paused(address account);
+
+    bool paused;
+}
+
+contract Vault is Ownable, Pausable {
+    function pause() public {
+        paused = true;
+        emit Paused(msg.sender);
+    }
+
+    function transferOwnership() public {
+        emit OwnershipTransferred(owner, msg.sender);
+        owner = msg.sender;
+    }
+}
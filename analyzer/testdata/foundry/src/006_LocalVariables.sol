@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Locals {
+    uint256 total;
+
+    function add(uint256 amount) public {
+        uint256 before = total;
+        if (amount > 0) {
+            uint256 total = before + amount;
+            bool done = total > before;
+        } else {
+            unchecked {
+                uint256 diff = before - amount;
+            }
+        }
+        (uint256 first, , bool last) = values();
+    }
+
+    function values() public returns (uint256, uint256, bool) {}
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Base {
+    uint256 fees;
+
+    function collect(uint256 amount) internal {
+        fees += amount;
+    }
+}
+
+contract Usage is Base {
+    address owner;
+    uint256 total;
+    uint256 count;
+    bool paused;
+
+    modifier onlyOwner() {
+        require(msg.sender == owner, "not owner");
+        _;
+    }
+
+    function deposit(uint256 amount) public onlyOwner {
+        total = total + amount;
+        count++;
+        collect(amount);
+        (total, paused) = values(amount);
+        delete paused;
+        super.collect(1);
+    }
+
+    function values(uint256 amount) public view returns (uint256, bool) {
+        return (amount, paused);
+    }
+}
@@ -0,0 +1,166 @@
+// unuseddecl detects the declarations that are never referenced. It is a
+// family of detectors, one per Kind: the structs, the enums, the modifiers
+// and the custom errors. Each kind is reported in its own finding, so the
+// kinds can be enabled separately, e.g. with the "unusedDeclarations" option
+// of solbot.json, which lists the names of the kinds (see ParseKind).
+//
+// A declaration is used if it is referenced outside of its own declaration
+// e.g. a struct used only in its own members is unused. The modifiers marked
+// as virtual or override are skipped, like the functions in unusedfunction:
+// a virtual modifier can be used by a child contract outside of the analyzed
+// file, and an override is invoked through the modifier it overrides.
+package unuseddecl
+
+import (
+	"fmt"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/symbols"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+// Kind is the kind of the declarations checked by the detector.
+type Kind int
+
+const (
+	_ Kind = iota
+	Struct
+	Enum
+	Modifier
+	Error
+)
+
+// Kinds are all the kinds, in the order they are reported.
+var Kinds = []Kind{Struct, Enum, Modifier, Error}
+
+var kindNames = map[Kind]string{
+	Struct:   "struct",
+	Enum:     "enum",
+	Modifier: "modifier",
+	Error:    "error",
+}
+
+func (k Kind) String() string {
+	return kindNames[k]
+}
+
+// ParseKind returns the kind of the name: "struct", "enum", "modifier" or
+// "error".
+func ParseKind(name string) (Kind, error) {
+	for kind, kindName := range kindNames {
+		if kindName == name {
+			return kind, nil
+		}
+	}
+	return 0, fmt.Errorf("Unknown kind of the unused declarations: %q.", name)
+}
+
+const severity = "Best Practices"
+
+// report is the content of the finding of the kind.
+type report struct {
+	title          string
+	descTempl      string
+	recommendation string
+}
+
+var reports = map[Kind]report{
+	Struct: {
+		title:          "Unused structs should be removed",
+		descTempl:      "The following structs are declared but never used: {{ range .Locations }}\n- `{{ .Context }}`{{ end }}",
+		recommendation: "Consider removing the unused structs to make the code easier to read and maintain.",
+	},
+	Enum: {
+		title:          "Unused enums should be removed",
+		descTempl:      "The following enums are declared but never used: {{ range .Locations }}\n- `{{ .Context }}`{{ end }}",
+		recommendation: "Consider removing the unused enums to make the code easier to read and maintain.",
+	},
+	Modifier: {
+		title:          "Unused modifiers should be removed",
+		descTempl:      "The following modifiers are declared but never invoked: {{ range .Locations }}\n- `{{ .Context }}`{{ end }}",
+		recommendation: "Consider applying the modifiers to the functions they were meant to protect or removing them.",
+	},
+	Error: {
+		title:          "Unused custom errors should be removed",
+		descTempl:      "The following custom errors are declared but never used: {{ range .Locations }}\n- `{{ .Context }}`{{ end }}",
+		recommendation: "Consider reverting with the custom errors where they are meant to be used or removing them.",
+	},
+}
+
+type Detector struct {
+	Kind Kind
+}
+
+func (d *Detector) Detect(node ast.Node, env *symbols.Environment) *reporter.Finding {
+	r, ok := reports[d.Kind]
+	if _, isFile := node.(*ast.File); !ok || !isFile || env == nil {
+		return nil
+	}
+
+	finding := reporter.Finding{}
+
+	for _, symbol := range declarations(d.Kind, env) {
+		if isUsed(symbol) {
+			continue
+		}
+		finding.Locations = append(finding.Locations, reporter.Location{
+			Position: token.Position{
+				Offset: symbol.Offset,
+			},
+			Context: symbol.Name,
+		})
+	}
+
+	if len(finding.Locations) == 0 {
+		return nil
+	}
+
+	finding.Title = r.title
+	finding.Severity = severity
+	finding.Description = reporter.GenerateCustomDescription(r.descTempl, finding.Locations)
+	finding.Recommendation = r.recommendation
+	return &finding
+}
+
+// declarations returns the symbols of the kind declared in the file env and
+// in its contracts.
+func declarations(kind Kind, env *symbols.Environment) []*symbols.BaseSymbol {
+	var results []*symbols.BaseSymbol
+	switch kind {
+	case Struct:
+		for _, s := range symbols.GetAllSymbolsByTypeInFile[*symbols.Struct](env) {
+			results = append(results, &s.BaseSymbol)
+		}
+	case Enum:
+		for _, s := range symbols.GetAllSymbolsByTypeInFile[*symbols.Enum](env) {
+			results = append(results, &s.BaseSymbol)
+		}
+	case Modifier:
+		for _, s := range symbols.GetAllSymbolsByTypeInFile[*symbols.Modifier](env) {
+			decl, ok := s.AstNode.(*ast.ModifierDeclaration)
+			if !ok || s.Virtual || decl.Override != nil {
+				continue
+			}
+			results = append(results, &s.BaseSymbol)
+		}
+	case Error:
+		for _, s := range symbols.GetAllSymbolsByTypeInFile[*symbols.CustomError](env) {
+			results = append(results, &s.BaseSymbol)
+		}
+	}
+	return results
+}
+
+// isUsed reports whether the declaration is referenced outside of itself.
+func isUsed(symbol *symbols.BaseSymbol) bool {
+	if symbol.AstNode == nil {
+		return len(symbol.References) > 0
+	}
+	for _, ref := range symbol.References {
+		if ref.Offset < symbol.AstNode.Start() || ref.Offset >= symbol.AstNode.End() {
+			return true
+		}
+	}
+	return false
+}
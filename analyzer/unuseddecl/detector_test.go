@@ -0,0 +1,152 @@
+package unuseddecl_test
+
+import (
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/analyzer/analyzertest"
+	"github.com/ChmielewskiKamil/solbot/analyzer/unuseddecl"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+const src = `struct Used { uint256 amount; }
+struct Unused { uint256 amount; }
+enum Status { Open, Closed }
+error NeverReverted();
+
+contract Base {
+    struct Node { uint256 value; bool active; }
+    enum Mode { Fast, Slow }
+    error Unauthorized(address caller);
+
+    modifier onlyOwner() { _; }
+    modifier neverInvoked() { _; }
+    modifier hook() virtual { _; }
+
+    function f(Used memory u) internal onlyOwner returns (Status) {
+        if (u.amount == 0) revert Unauthorized(msg.sender);
+        return Status.Open;
+    }
+}
+`
+
+func Test_DetectUnusedDeclarations(t *testing.T) {
+	file, env := analyzertest.Analyze(t, src)
+
+	tests := []struct {
+		kind      unuseddecl.Kind
+		title     string
+		locations []reporter.Location
+	}{
+		{
+			unuseddecl.Struct,
+			"Unused structs should be removed",
+			[]reporter.Location{
+				{Position: token.Position{Line: 2, Column: 8}, Context: "Unused"},
+				{Position: token.Position{Line: 7, Column: 12}, Context: "Node"},
+			},
+		},
+		{
+			unuseddecl.Enum,
+			"Unused enums should be removed",
+			[]reporter.Location{
+				{Position: token.Position{Line: 8, Column: 10}, Context: "Mode"},
+			},
+		},
+		{
+			unuseddecl.Modifier,
+			"Unused modifiers should be removed",
+			// The virtual modifiers can be used by the child contracts.
+			[]reporter.Location{
+				{Position: token.Position{Line: 12, Column: 14}, Context: "neverInvoked"},
+			},
+		},
+		{
+			unuseddecl.Error,
+			"Unused custom errors should be removed",
+			[]reporter.Location{
+				{Position: token.Position{Line: 4, Column: 7}, Context: "NeverReverted"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			d := unuseddecl.Detector{Kind: tt.kind}
+
+			finding := d.Detect(file, env)
+			if finding == nil {
+				t.Fatalf("Expected a finding, got nil")
+			}
+
+			finding.CalculatePositions(file.SourceFile)
+
+			if finding.Title != tt.title {
+				t.Errorf("Expected the title %q, got %q", tt.title, finding.Title)
+			}
+
+			if len(finding.Locations) != len(tt.locations) {
+				t.Fatalf("Expected %d findings, got %d: %v",
+					len(tt.locations), len(finding.Locations), finding.Locations)
+			}
+
+			for i, loc := range finding.Locations {
+				if loc.Position.Line != tt.locations[i].Position.Line ||
+					loc.Position.Column != tt.locations[i].Position.Column {
+					t.Errorf("Expected %d:%d, got %d:%d",
+						tt.locations[i].Position.Line, tt.locations[i].Position.Column,
+						loc.Position.Line, loc.Position.Column)
+				}
+
+				if loc.Context != tt.locations[i].Context {
+					t.Errorf("Expected context %s, got %s", tt.locations[i].Context, loc.Context)
+				}
+			}
+		})
+	}
+}
+
+func Test_DetectUnusedDeclarations_ShouldReturnNil(t *testing.T) {
+	src := `struct Position { uint256 amount; }
+enum Status { Open, Closed }
+error Unauthorized();
+
+contract Vault {
+    Position position;
+
+    modifier whenOpen(Status status) {
+        if (status != Status.Open) revert Unauthorized();
+        _;
+    }
+
+    function deposit() public whenOpen(Status.Open) {
+        position.amount += msg.value;
+    }
+}
+`
+
+	file, env := analyzertest.Analyze(t, src)
+
+	for _, kind := range unuseddecl.Kinds {
+		d := unuseddecl.Detector{Kind: kind}
+		if finding := d.Detect(file, env); finding != nil {
+			t.Errorf("Expected no finding, got: %v", finding.Locations)
+		}
+	}
+}
+
+func Test_ParseKind(t *testing.T) {
+	for _, kind := range unuseddecl.Kinds {
+		parsed, err := unuseddecl.ParseKind(kind.String())
+		if err != nil {
+			t.Fatalf("ParseKind(%q) failed: %v", kind.String(), err)
+		}
+		if parsed != kind {
+			t.Errorf("Expected %v, got %v", kind, parsed)
+		}
+	}
+
+	if _, err := unuseddecl.ParseKind("event"); err == nil {
+		t.Errorf("Expected an error for an unknown kind, got nil")
+	}
+}
@@ -0,0 +1,60 @@
+// unusedevent detects events that are never emitted. Events can be declared
+// either at a File level or at a Contract level. An event declared in a base
+// contract is used if any contract inheriting from it emits the event.
+package unusedevent
+
+import (
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/symbols"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+const (
+	title          = "Unused events should be removed"
+	severity       = "Best Practices"
+	descTempl      = "The following events are declared but never emitted: {{ range .Locations }}\n- `{{ .Context }}`{{ end }}"
+	recommendation = "Consider emitting the events where they are meant to be emitted or removing them."
+)
+
+type Detector struct{}
+
+func (*Detector) Detect(node ast.Node, env *symbols.Environment) *reporter.Finding {
+	if _, ok := node.(*ast.File); !ok || env == nil {
+		return nil
+	}
+
+	finding := reporter.Finding{}
+
+	// The emits of the inherited events are resolved to the symbols of the
+	// base contracts, so the references of each event are all its emits.
+	for _, event := range symbols.GetAllSymbolsByTypeInFile[*symbols.Event](env) {
+		if !isEmitted(event) {
+			finding.Locations = append(finding.Locations, reporter.Location{
+				Position: token.Position{
+					Offset: event.Offset,
+				},
+				Context: event.Name,
+			})
+		}
+	}
+
+	if len(finding.Locations) == 0 {
+		return nil
+	}
+
+	finding.Title = title
+	finding.Severity = severity
+	finding.Description = reporter.GenerateCustomDescription(descTempl, finding.Locations)
+	finding.Recommendation = recommendation
+	return &finding
+}
+
+func isEmitted(event *symbols.Event) bool {
+	for _, ref := range event.References {
+		if ref.Context.Usage == symbols.EMIT {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package unusedevent_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/analyzer"
+	"github.com/ChmielewskiKamil/solbot/analyzer/unusedevent"
+	"github.com/ChmielewskiKamil/solbot/parser"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+func Test_DetectUnusedEvents(t *testing.T) {
+	src := `event Used(uint256 number);
+event Unused(uint256 number);
+
+contract Ownable {
+    event OwnershipTransferred(address previousOwner, address newOwner);
+    event NeverEmitted();
+}
+
+contract Vault is Ownable {
+    event Deposit(uint256 amount);
+    event Deposit(uint256 amount, address to);
+
+    function deposit(uint256 amount) public {
+        emit Deposit(amount);
+        emit Used(amount);
+    }
+
+    function transferOwnership() public {
+        emit OwnershipTransferred(msg.sender, msg.sender);
+    }
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := unusedevent.Detector{}
+
+	finding := d.Detect(file, a.GetCurrentFileEnv())
+	if finding == nil {
+		t.Fatalf("Expected a finding, got nil")
+	}
+
+	finding.CalculatePositions(file.SourceFile)
+
+	// The unused overload of an emitted event is reported too.
+	expectedLocations := []reporter.Location{
+		{Position: token.Position{Line: 2, Column: 7}, Context: "Unused"},
+		{Position: token.Position{Line: 6, Column: 11}, Context: "NeverEmitted"},
+		{Position: token.Position{Line: 11, Column: 11}, Context: "Deposit"},
+	}
+
+	if len(finding.Locations) != len(expectedLocations) {
+		t.Fatalf("Expected %d findings, got %d: %v",
+			len(expectedLocations), len(finding.Locations), finding.Locations)
+	}
+
+	for i, loc := range finding.Locations {
+		if loc.Position.Line != expectedLocations[i].Position.Line ||
+			loc.Position.Column != expectedLocations[i].Position.Column {
+			t.Errorf("Expected %d:%d, got %d:%d",
+				expectedLocations[i].Position.Line, expectedLocations[i].Position.Column,
+				loc.Position.Line, loc.Position.Column)
+		}
+
+		if loc.Context != expectedLocations[i].Context {
+			t.Errorf("Expected context %s, got %s", expectedLocations[i].Context, loc.Context)
+		}
+	}
+}
+
+func Test_ShouldReturnNilIfAllEventsAreEmitted(t *testing.T) {
+	src := `contract Counter {
+    event Incremented(uint256 count);
+
+    function increment() public {
+        emit Incremented(1);
+    }
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+
+	d := unusedevent.Detector{}
+
+	if finding := d.Detect(file, a.GetCurrentFileEnv()); finding != nil {
+		t.Fatalf("Expected nil, got a finding: %v", finding.Locations)
+	}
+}
@@ -0,0 +1,72 @@
+// unusedfunction detects internal and private functions that are never
+// called. The free functions declared at a File level are internal too. The
+// functions marked as virtual or override are skipped: a virtual function can
+// be used by a child contract outside of the analyzed file, and an override
+// is called through the function it overrides e.g. from a base contract.
+package unusedfunction
+
+import (
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/symbols"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+const (
+	title          = "Unused `internal` and `private` functions should be removed"
+	severity       = "Best Practices"
+	descTempl      = "The following `internal` and `private` functions are never called: {{ range .Locations }}\n- `{{ .Context }}`{{ end }}"
+	recommendation = "Consider removing the unused functions to reduce the size of the code and make it easier to maintain."
+)
+
+type Detector struct{}
+
+func (*Detector) Detect(node ast.Node, env *symbols.Environment) *reporter.Finding {
+	if _, ok := node.(*ast.File); !ok || env == nil {
+		return nil
+	}
+
+	finding := reporter.Finding{}
+
+	for _, fn := range symbols.GetAllSymbolsByTypeInFile[*symbols.Function](env) {
+		if fn.Visibility != ast.Internal && fn.Visibility != ast.Private {
+			continue
+		}
+
+		decl, ok := fn.AstNode.(*ast.FunctionDeclaration)
+		if !ok || fn.Virtual || decl.Override != nil {
+			continue
+		}
+
+		if !isUsed(fn, decl) {
+			finding.Locations = append(finding.Locations, reporter.Location{
+				Position: token.Position{
+					Offset: fn.Offset,
+				},
+				Context: fn.Name,
+			})
+		}
+	}
+
+	if len(finding.Locations) == 0 {
+		return nil
+	}
+
+	finding.Title = title
+	finding.Severity = severity
+	finding.Description = reporter.GenerateCustomDescription(descTempl, finding.Locations)
+	finding.Recommendation = recommendation
+	return &finding
+}
+
+// isUsed reports whether the function is referenced outside of its own body.
+// Besides the calls, the internal functions can be read e.g. assigned to the
+// variables of function types. A function that only calls itself is unused.
+func isUsed(fn *symbols.Function, decl *ast.FunctionDeclaration) bool {
+	for _, ref := range fn.References {
+		if ref.Offset < decl.Start() || ref.Offset >= decl.End() {
+			return true
+		}
+	}
+	return false
+}
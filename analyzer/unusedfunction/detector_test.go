@@ -0,0 +1,92 @@
+package unusedfunction_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/analyzer"
+	"github.com/ChmielewskiKamil/solbot/analyzer/unusedfunction"
+	"github.com/ChmielewskiKamil/solbot/parser"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+func Test_DetectUnusedFunctions(t *testing.T) {
+	src := `function freeUsed() pure returns (uint256) { return 1; }
+function freeUnused() pure returns (uint256) { return 2; }
+
+contract Base {
+    function _hook() internal virtual {}
+    function _baseOnly() internal {}
+    function _calledBySuper() internal virtual {}
+    function _calledByChild() internal {}
+}
+
+contract Vault is Base {
+    function _hook() internal override {}
+    function _calledBySuper() internal override {
+        super._calledBySuper();
+    }
+
+    function deposit() public {
+        _calledByChild();
+        _calledBySuper();
+        _private();
+        freeUsed();
+    }
+
+    function _private() private {}
+    function _unusedPrivate() private {}
+    function _recursive(uint256 n) internal {
+        if (n > 0) {
+            _recursive(n - 1);
+        }
+    }
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := unusedfunction.Detector{}
+
+	finding := d.Detect(file, a.GetCurrentFileEnv())
+	if finding == nil {
+		t.Fatalf("Expected a finding, got nil")
+	}
+
+	finding.CalculatePositions(file.SourceFile)
+
+	expectedLocations := []reporter.Location{
+		{Position: token.Position{Line: 2, Column: 10}, Context: "freeUnused"},
+		{Position: token.Position{Line: 6, Column: 14}, Context: "_baseOnly"},
+		{Position: token.Position{Line: 25, Column: 14}, Context: "_unusedPrivate"},
+		{Position: token.Position{Line: 26, Column: 14}, Context: "_recursive"},
+	}
+
+	if len(finding.Locations) != len(expectedLocations) {
+		t.Fatalf("Expected %d findings, got %d: %v",
+			len(expectedLocations), len(finding.Locations), finding.Locations)
+	}
+
+	for i, loc := range finding.Locations {
+		if loc.Position.Line != expectedLocations[i].Position.Line ||
+			loc.Position.Column != expectedLocations[i].Position.Column {
+			t.Errorf("Expected %d:%d, got %d:%d",
+				expectedLocations[i].Position.Line, expectedLocations[i].Position.Column,
+				loc.Position.Line, loc.Position.Column)
+		}
+
+		if loc.Context != expectedLocations[i].Context {
+			t.Errorf("Expected context %s, got %s", expectedLocations[i].Context, loc.Context)
+		}
+	}
+}
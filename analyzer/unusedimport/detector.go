@@ -0,0 +1,83 @@
+// unusedimport detects imports whose names are never used in the importing
+// file. The symbols of `import {A, B as C} from "./A.sol";` are reported
+// separately. The other imports are reported as a whole: the alias of
+// `import * as M from "./A.sol";` is unused or none of the names exported
+// by the file of `import "./A.sol";` are used. A name is used if it appears
+// anywhere in the file e.g. only as the base of a contract.
+package unusedimport
+
+import (
+	"sort"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/symbols"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+const (
+	title          = "Unused imports should be removed"
+	severity       = "Best Practices"
+	descTempl      = "The following imports are never used in the file: {{ range .Locations }}\n- `{{ .Context }}`{{ end }}"
+	recommendation = "Consider removing the unused imports to make the dependencies of the file clear."
+)
+
+type Detector struct{}
+
+func (*Detector) Detect(node ast.Node, env *symbols.Environment) *reporter.Finding {
+	if _, ok := node.(*ast.File); !ok || env == nil {
+		return nil
+	}
+
+	finding := reporter.Finding{}
+
+	// The names of the plain imports are declared per name, so the
+	// directive is used if any of them is. The directives of the plain
+	// imports that could not be resolved have no symbols and are skipped.
+	var directives []*ast.ImportDirective
+	used := make(map[*ast.ImportDirective]bool)
+
+	for _, symbol := range symbols.GetAllSymbolsByType[*symbols.ImportedSymbol](env) {
+		if sym, ok := symbol.AstNode.(*ast.ImportSymbol); ok {
+			if len(symbol.References) == 0 {
+				finding.Locations = append(finding.Locations, reporter.Location{
+					Position: token.Position{
+						Offset: symbol.Offset,
+					},
+					Context: sym.String(),
+				})
+			}
+			continue
+		}
+
+		if _, ok := used[symbol.Directive]; !ok {
+			directives = append(directives, symbol.Directive)
+		}
+		used[symbol.Directive] = used[symbol.Directive] || len(symbol.References) > 0
+	}
+
+	for _, dir := range directives {
+		if !used[dir] {
+			finding.Locations = append(finding.Locations, reporter.Location{
+				Position: token.Position{
+					Offset: dir.Pos,
+				},
+				Context: dir.String(),
+			})
+		}
+	}
+
+	if len(finding.Locations) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(finding.Locations, func(i, j int) bool {
+		return finding.Locations[i].Position.Offset < finding.Locations[j].Position.Offset
+	})
+
+	finding.Title = title
+	finding.Severity = severity
+	finding.Description = reporter.GenerateCustomDescription(descTempl, finding.Locations)
+	finding.Recommendation = recommendation
+	return &finding
+}
@@ -0,0 +1,157 @@
+package unusedimport_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/analyzer"
+	"github.com/ChmielewskiKamil/solbot/analyzer/unusedimport"
+	"github.com/ChmielewskiKamil/solbot/parser"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+func Test_DetectUnusedImports(t *testing.T) {
+	src := `import {Ownable, Pausable as P} from "./Ownable.sol";
+import * as M from "./Math.sol";
+import "./Math.sol" as MathLib;
+import "./Unused.sol";
+import "./Missing.sol";
+import "./Shapes.sol";
+
+contract Vault is Ownable {
+    function f(uint256 a) public returns (uint256) {
+        return M.max(a, 1);
+    }
+}
+`
+
+	// The imports are resolved relative to the file.
+	file, err := parser.ParseFile("testdata/test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	// The missing file is not reported, since its names are not known.
+	warnings := a.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Msg, "could not open the imported file ./Missing.sol") {
+		t.Errorf("Expected a warning about the missing file, got: %v", warnings)
+	}
+
+	d := unusedimport.Detector{}
+
+	finding := d.Detect(file, a.GetCurrentFileEnv())
+	if finding == nil {
+		t.Fatalf("Expected a finding, got nil")
+	}
+
+	finding.CalculatePositions(file.SourceFile)
+
+	expectedLocations := []reporter.Location{
+		{Position: token.Position{Line: 1, Column: 30}, Context: "Pausable as P"},
+		{Position: token.Position{Line: 3, Column: 1}, Context: `import * as MathLib from "./Math.sol";`},
+		{Position: token.Position{Line: 4, Column: 1}, Context: `import "./Unused.sol";`},
+		{Position: token.Position{Line: 6, Column: 1}, Context: `import "./Shapes.sol";`},
+	}
+
+	if len(finding.Locations) != len(expectedLocations) {
+		t.Fatalf("Expected %d locations, got %d: %v", len(expectedLocations), len(finding.Locations), finding.Locations)
+	}
+
+	for i, expected := range expectedLocations {
+		got := finding.Locations[i]
+		if got.Position.Line != expected.Position.Line || got.Position.Column != expected.Position.Column {
+			t.Errorf("Location %d: expected %d:%d, got %d:%d", i,
+				expected.Position.Line, expected.Position.Column, got.Position.Line, got.Position.Column)
+		}
+		if got.Context != expected.Context {
+			t.Errorf("Location %d: expected context %q, got %q", i, expected.Context, got.Context)
+		}
+	}
+}
+
+func Test_ShouldReturnNilIfAllImportsAreUsed(t *testing.T) {
+	// Ownable is imported by Base.sol, so the plain import of Base.sol is
+	// used only by the inheritance from Ownable. Pausable is used only as
+	// the type of a param.
+	src := `import "./Base.sol";
+import {max} from "./Math.sol";
+import {Pausable} from "./Ownable.sol";
+
+contract Vault is Ownable {
+    function f(uint256 a, Pausable p) public returns (uint256) {
+        return max(a, 1);
+    }
+}
+`
+
+	file, err := parser.ParseFile("testdata/test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := unusedimport.Detector{}
+
+	if finding := d.Detect(file, a.GetCurrentFileEnv()); finding != nil {
+		t.Errorf("Expected no finding, got: %v", finding.Locations)
+	}
+}
+
+func Test_ShouldReturnNilIfImportIsUsedOnlyForStructOrError(t *testing.T) {
+	// Types.sol declares a contract too, but only its struct or its error
+	// is used.
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"struct", `import "./Types.sol";
+
+contract Vault {
+    function f(Point memory p) public returns (uint256) {
+        return p.x;
+    }
+}
+`},
+		{"error", `import "./Types.sol";
+
+contract Vault {
+    function f() public {
+        revert Denied(msg.sender);
+    }
+}
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := parser.ParseFile("testdata/test.sol", strings.NewReader(tt.src))
+			if err != nil {
+				t.Fatalf("ParseFile failed: %v", err)
+			}
+
+			a := analyzer.Analyzer{}
+			a.AnalyzeFile(file)
+			for _, e := range a.Errors() {
+				t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+			}
+
+			d := unusedimport.Detector{}
+
+			if finding := d.Detect(file, a.GetCurrentFileEnv()); finding != nil {
+				t.Errorf("Expected no finding, got: %v", finding.Locations)
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+import "./Ownable.sol";
+
+contract Base is Ownable {}
@@ -0,0 +1,6 @@
+function max(uint256 a, uint256 b) pure returns (uint256) {
+    if (a > b) {
+        return a;
+    }
+    return b;
+}
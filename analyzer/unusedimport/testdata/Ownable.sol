@@ -0,0 +1,7 @@
+contract Ownable {
+    address owner;
+}
+
+contract Pausable {
+    bool paused;
+}
@@ -0,0 +1,4 @@
+struct Point {
+    uint256 x;
+    uint256 y;
+}
@@ -0,0 +1,8 @@
+struct Point {
+    uint256 x;
+    uint256 y;
+}
+
+error Denied(address caller);
+
+contract Registry {}
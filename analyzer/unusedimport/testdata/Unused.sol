@@ -0,0 +1 @@
+contract Unused {}
@@ -0,0 +1,141 @@
+// unusedlocalvar detects local variables that are declared but never read.
+// The variables that are only written to are unused as well, unless they are
+// storage pointers, since writing through a pointer changes the state. The
+// variables used in the inline assembly are assumed to be read.
+package unusedlocalvar
+
+import (
+	"strings"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/ast/astutil"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/symbols"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+const (
+	title          = "Unused local variables should be removed"
+	severity       = "Best Practices"
+	descTempl      = "The following local variables are never read: {{ range .Locations }}\n- `{{ .Context }}`{{ end }}"
+	recommendation = "Consider removing the unused local variables. The initial values with side effects e.g. function calls, can be kept as expression statements."
+)
+
+type Detector struct{}
+
+func (*Detector) Detect(node ast.Node, env *symbols.Environment) *reporter.Finding {
+	file, ok := node.(*ast.File)
+	if !ok || env == nil {
+		return nil
+	}
+
+	finding := reporter.Finding{}
+
+	// The components of the tuple declarations are deleted from the tuple,
+	// not as whole statements.
+	tupleComponents := make(map[*ast.VariableDeclarationStatement]bool)
+	astutil.Apply(file, func(c *astutil.Cursor) bool {
+		if tuple, ok := c.Node().(*ast.VariableDeclarationTupleStatement); ok {
+			for _, decl := range tuple.Declarations {
+				tupleComponents[decl] = true
+			}
+		}
+		return true
+	}, nil)
+
+	for _, local := range symbols.GetAllSymbolsByTypeInTree[*symbols.LocalVariable](env) {
+		if isRead(local) {
+			continue
+		}
+
+		location := reporter.Location{
+			Position: token.Position{
+				Offset: local.Offset,
+			},
+			Context: local.Name,
+		}
+		// The variables that are written to can't be removed without the
+		// assignments, so only the never used ones are removed.
+		if decl, ok := local.AstNode.(*ast.VariableDeclarationStatement); ok && len(local.References) == 0 {
+			location.Edits = deletion(file.SourceFile, decl, tupleComponents[decl])
+		}
+		finding.Locations = append(finding.Locations, location)
+	}
+
+	if len(finding.Locations) == 0 {
+		return nil
+	}
+
+	finding.Title = title
+	finding.Severity = severity
+	finding.Description = reporter.GenerateCustomDescription(descTempl, finding.Locations)
+	finding.Recommendation = recommendation
+	return &finding
+}
+
+func isRead(local *symbols.LocalVariable) bool {
+	for _, ref := range local.References {
+		if ref.Context.Usage != symbols.WRITE || local.DataLocation == ast.Storage {
+			return true
+		}
+	}
+	return false
+}
+
+// deletion returns the edits removing the declaration. The tuple components
+// are removed leaving the comma in place e.g. `(uint a, ) = f();`. The
+// statements are removed with their lines, unless the initial value has side
+// effects, in which case the value is kept e.g. `uint x = f();` becomes
+// `f();`.
+func deletion(file *token.SourceFile, decl *ast.VariableDeclarationStatement, tupleComponent bool) []reporter.Edit {
+	if tupleComponent {
+		return []reporter.Edit{{Pos: decl.Start(), End: decl.End()}}
+	}
+
+	if decl.Value != nil && hasSideEffects(decl.Value) {
+		return []reporter.Edit{{Pos: decl.Start(), End: decl.Value.Start()}}
+	}
+
+	content := file.Content()
+	start, end := file.Offset(decl.Start()), file.Offset(decl.End())
+	if end < len(content) && content[end] == ';' {
+		end++
+	}
+
+	// Remove the whole line if nothing else is on it.
+	lineStart := strings.LastIndexByte(content[:start], '\n') + 1
+	lineEnd := strings.IndexByte(content[end:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(content)
+	} else {
+		lineEnd += end + 1
+	}
+	if strings.TrimSpace(content[lineStart:start]) == "" && strings.TrimSpace(content[end:lineEnd]) == "" {
+		start, end = lineStart, lineEnd
+	}
+
+	return []reporter.Edit{{Pos: file.Pos(start), End: file.Pos(end)}}
+}
+
+// hasSideEffects reports whether evaluating the expression can change the
+// state e.g. it calls a function or assigns to a variable.
+func hasSideEffects(expr ast.Expression) bool {
+	found := false
+	astutil.Apply(expr, func(c *astutil.Cursor) bool {
+		switch n := c.Node().(type) {
+		case *ast.CallExpression, *ast.PostfixExpression:
+			found = true
+		case *ast.PrefixExpression:
+			switch n.Operator.Type {
+			case token.INC, token.DEC, token.DELETE:
+				found = true
+			}
+		case *ast.InfixExpression:
+			if token.IsAssignment(n.Operator.Type) {
+				found = true
+			}
+		}
+		return !found
+	}, nil)
+	return found
+}
@@ -0,0 +1,135 @@
+package unusedlocalvar_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/analyzer"
+	"github.com/ChmielewskiKamil/solbot/analyzer/unusedlocalvar"
+	"github.com/ChmielewskiKamil/solbot/parser"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+func Test_DetectUnusedLocalVars(t *testing.T) {
+	src := `contract Vault {
+    function values() internal returns (uint256, uint256) {
+        return (1, 2);
+    }
+
+    function g() internal returns (uint256) {
+        return 1;
+    }
+
+    function f(uint256 amount) public returns (uint256) {
+        uint256 unused = amount;
+        uint256 onlyWritten;
+        onlyWritten = amount;
+        uint256 result = amount + 1;
+        uint256 fromCall = g();
+        (uint256 a, uint256 b) = values();
+        if (amount > 0) {
+            uint256 inBlock = 1;
+        }
+        uint256 inAssembly;
+        assembly {
+            inAssembly := 1
+        }
+        return result + a;
+    }
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := unusedlocalvar.Detector{}
+
+	finding := d.Detect(file, a.GetCurrentFileEnv())
+	if finding == nil {
+		t.Fatalf("Expected a finding, got nil")
+	}
+
+	finding.CalculatePositions(file.SourceFile)
+
+	expectedLocations := []reporter.Location{
+		{Position: token.Position{Line: 11, Column: 17}, Context: "unused"},
+		{Position: token.Position{Line: 12, Column: 17}, Context: "onlyWritten"},
+		{Position: token.Position{Line: 15, Column: 17}, Context: "fromCall"},
+		{Position: token.Position{Line: 16, Column: 29}, Context: "b"},
+		{Position: token.Position{Line: 18, Column: 21}, Context: "inBlock"},
+	}
+
+	if len(finding.Locations) != len(expectedLocations) {
+		t.Fatalf("Expected %d locations, got %d: %v", len(expectedLocations), len(finding.Locations), finding.Locations)
+	}
+
+	for i, expected := range expectedLocations {
+		got := finding.Locations[i]
+		if got.Position.Line != expected.Position.Line || got.Position.Column != expected.Position.Column {
+			t.Errorf("Location %d: expected %d:%d, got %d:%d", i,
+				expected.Position.Line, expected.Position.Column, got.Position.Line, got.Position.Column)
+		}
+		if got.Context != expected.Context {
+			t.Errorf("Location %d: expected context %q, got %q", i, expected.Context, got.Context)
+		}
+	}
+
+	// The value with side effects is kept. The variable that is written to
+	// is not removed, since the assignments would have to be removed too.
+	expectedBody := `    function f(uint256 amount) public returns (uint256) {
+        uint256 onlyWritten;
+        onlyWritten = amount;
+        uint256 result = amount + 1;
+        g();
+        (uint256 a, ) = values();
+        if (amount > 0) {
+        }
+`
+	var edits []reporter.Edit
+	for _, location := range finding.Locations {
+		edits = append(edits, location.Edits...)
+	}
+	fixed, err := reporter.ApplyEdits(file.SourceFile, edits)
+	if err != nil {
+		t.Fatalf("ApplyEdits failed: %v", err)
+	}
+	if !strings.Contains(fixed, expectedBody) {
+		t.Errorf("Expected the fixed source to contain:\n%s\ngot:\n%s", expectedBody, fixed)
+	}
+}
+
+func Test_ShouldReturnNilIfAllLocalVarsAreRead(t *testing.T) {
+	src := `contract Vault {
+    function f(uint256 amount) public returns (uint256) {
+        uint256 doubled = amount * 2;
+        return doubled;
+    }
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := unusedlocalvar.Detector{}
+
+	if finding := d.Detect(file, a.GetCurrentFileEnv()); finding != nil {
+		t.Errorf("Expected no finding, got: %v", finding.Locations)
+	}
+}
@@ -0,0 +1,100 @@
+// unusedparams detects function parameters that are never read. The params
+// of the functions without bodies and of the virtual functions and modifiers
+// are skipped, since they describe the interface for the overriding
+// functions. The unnamed params have no symbols, so they are skipped too.
+// The constructors and modifiers are checked like the functions.
+package unusedparams
+
+import (
+	"sort"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/symbols"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+const (
+	title          = "Unused function parameters should be removed"
+	severity       = "Best Practices"
+	descTempl      = "The following parameters are never used: {{ range .Locations }}\n- `{{ .Context }}`{{ if .Suggestion }} (consider `{{ .Suggestion }}`){{ end }}{{ end }}"
+	recommendation = "Consider removing the unused parameters. If the signature must stay the same e.g. to override a function, comment out the names of the parameters."
+)
+
+type Detector struct{}
+
+func (*Detector) Detect(node ast.Node, env *symbols.Environment) *reporter.Finding {
+	if _, ok := node.(*ast.File); !ok || env == nil {
+		return nil
+	}
+
+	finding := reporter.Finding{}
+
+	for _, fn := range symbols.GetAllSymbolsByTypeInFile[*symbols.Function](env) {
+		if fn.Body != nil && !fn.Virtual {
+			check(&finding, fn.Parameters)
+		}
+	}
+	for _, constructor := range symbols.GetAllSymbolsByTypeInFile[*symbols.Constructor](env) {
+		if constructor.Body != nil {
+			check(&finding, constructor.Parameters)
+		}
+	}
+	for _, modifier := range symbols.GetAllSymbolsByTypeInFile[*symbols.Modifier](env) {
+		if modifier.Body != nil && !modifier.Virtual {
+			check(&finding, modifier.Parameters)
+		}
+	}
+
+	if len(finding.Locations) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(finding.Locations, func(i, j int) bool {
+		return finding.Locations[i].Position.Offset < finding.Locations[j].Position.Offset
+	})
+
+	finding.Title = title
+	finding.Severity = severity
+	finding.Description = reporter.GenerateCustomDescription(descTempl, finding.Locations)
+	finding.Recommendation = recommendation
+	return &finding
+}
+
+// check adds the locations of the unused params to the finding. The
+// suggested edit comments out the name of the param. The params that are
+// written to keep their names, since the assignments would not compile.
+func check(finding *reporter.Finding, params []*symbols.Param) {
+	for _, param := range params {
+		if isRead(param) {
+			continue
+		}
+
+		location := reporter.Location{
+			Position: token.Position{
+				Offset: param.Offset,
+			},
+			Context: param.Name,
+		}
+		if len(param.References) == 0 {
+			location.Suggestion = "/* " + param.Name + " */"
+			location.Edits = []reporter.Edit{{
+				Pos:     param.Offset,
+				End:     param.Offset + token.Pos(len(param.Name)),
+				NewText: location.Suggestion,
+			}}
+		}
+		finding.Locations = append(finding.Locations, location)
+	}
+}
+
+// isRead reports whether the param is read. Writing to a param has no
+// effect outside of the function, unless the param is a storage pointer.
+func isRead(param *symbols.Param) bool {
+	for _, ref := range param.References {
+		if ref.Context.Usage != symbols.WRITE || param.DataLocation == ast.Storage {
+			return true
+		}
+	}
+	return false
+}
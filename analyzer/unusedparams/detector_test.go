@@ -0,0 +1,128 @@
+package unusedparams_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/analyzer"
+	"github.com/ChmielewskiKamil/solbot/analyzer/unusedparams"
+	"github.com/ChmielewskiKamil/solbot/parser"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+func Test_DetectUnusedParams(t *testing.T) {
+	src := `contract Base {
+    modifier onlyPositive(uint256 amount) {
+        _;
+    }
+
+    function hook(uint256 amount) internal virtual {}
+
+    function stub(uint256 amount) internal;
+}
+
+contract Vault is Base {
+    constructor(uint256 initial) {}
+
+    function f(uint256 amount, address to, uint256) public returns (uint256) {
+        to = msg.sender;
+        return 1;
+    }
+
+    function g(uint256 amount) public returns (uint256) {
+        return amount;
+    }
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := unusedparams.Detector{}
+
+	finding := d.Detect(file, a.GetCurrentFileEnv())
+	if finding == nil {
+		t.Fatalf("Expected a finding, got nil")
+	}
+
+	finding.CalculatePositions(file.SourceFile)
+
+	// The param that is written to keeps its name.
+	expectedLocations := []reporter.Location{
+		{Position: token.Position{Line: 2, Column: 35}, Context: "amount", Suggestion: "/* amount */"},
+		{Position: token.Position{Line: 12, Column: 25}, Context: "initial", Suggestion: "/* initial */"},
+		{Position: token.Position{Line: 14, Column: 24}, Context: "amount", Suggestion: "/* amount */"},
+		{Position: token.Position{Line: 14, Column: 40}, Context: "to"},
+	}
+
+	if len(finding.Locations) != len(expectedLocations) {
+		t.Fatalf("Expected %d locations, got %d: %v", len(expectedLocations), len(finding.Locations), finding.Locations)
+	}
+
+	for i, expected := range expectedLocations {
+		got := finding.Locations[i]
+		if got.Position.Line != expected.Position.Line || got.Position.Column != expected.Position.Column {
+			t.Errorf("Location %d: expected %d:%d, got %d:%d", i,
+				expected.Position.Line, expected.Position.Column, got.Position.Line, got.Position.Column)
+		}
+		if got.Context != expected.Context || got.Suggestion != expected.Suggestion {
+			t.Errorf("Location %d: expected %q (%q), got %q (%q)", i,
+				expected.Context, expected.Suggestion, got.Context, got.Suggestion)
+		}
+
+		if expected.Suggestion == "" {
+			if len(got.Edits) != 0 {
+				t.Errorf("Location %d: expected no edits, got: %v", i, got.Edits)
+			}
+			continue
+		}
+
+		if len(got.Edits) != 1 {
+			t.Fatalf("Location %d: expected 1 edit, got: %v", i, got.Edits)
+		}
+		edit := got.Edits[0]
+		content := file.SourceFile.Content()
+		replaced := content[file.SourceFile.Offset(edit.Pos):file.SourceFile.Offset(edit.End)]
+		if replaced != expected.Context || edit.NewText != expected.Suggestion {
+			t.Errorf("Location %d: expected the edit %q -> %q, got %q -> %q", i,
+				expected.Context, expected.Suggestion, replaced, edit.NewText)
+		}
+	}
+}
+
+func Test_ShouldReturnNilIfAllParamsAreUsed(t *testing.T) {
+	src := `contract Vault {
+    uint256 total;
+
+    function deposit(uint256 amount, uint256) public {
+        total = total + amount;
+    }
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := unusedparams.Detector{}
+
+	if finding := d.Detect(file, a.GetCurrentFileEnv()); finding != nil {
+		t.Errorf("Expected no finding, got: %v", finding.Locations)
+	}
+}
@@ -0,0 +1,87 @@
+// unusedreturn detects named return variables that are never assigned nor
+// read in the functions returning their values with explicit `return`
+// statements e.g. `function f() returns (uint256 total) { return 1; }`. The
+// names of such return variables only mislead the reader.
+package unusedreturn
+
+import (
+	"strings"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/ast/astutil"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/symbols"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+const (
+	title          = "Unused named returns should be removed"
+	severity       = "Best Practices"
+	descTempl      = "The following named returns are never used, since the functions return the values explicitly: {{ range .Locations }}\n- `{{ .Context }}`{{ end }}"
+	recommendation = "Consider removing the names of the unused return variables or using them instead of the explicit `return` statements."
+)
+
+type Detector struct{}
+
+func (*Detector) Detect(node ast.Node, env *symbols.Environment) *reporter.Finding {
+	file, ok := node.(*ast.File)
+	if !ok || env == nil {
+		return nil
+	}
+
+	finding := reporter.Finding{}
+
+	for _, fn := range symbols.GetAllSymbolsByTypeInFile[*symbols.Function](env) {
+		if fn.Body == nil || len(fn.Results) == 0 || !returnsExplicitly(fn.Body) {
+			continue
+		}
+
+		for _, result := range fn.Results {
+			if len(result.References) > 0 {
+				continue
+			}
+
+			finding.Locations = append(finding.Locations, reporter.Location{
+				Position: token.Position{
+					Offset: result.Offset,
+				},
+				Context: result.Name,
+				Edits:   []reporter.Edit{deletion(file.SourceFile, result)},
+			})
+		}
+	}
+
+	if len(finding.Locations) == 0 {
+		return nil
+	}
+
+	finding.Title = title
+	finding.Severity = severity
+	finding.Description = reporter.GenerateCustomDescription(descTempl, finding.Locations)
+	finding.Recommendation = recommendation
+	return &finding
+}
+
+// returnsExplicitly reports whether the body has a `return` statement with
+// a value.
+func returnsExplicitly(body *ast.BlockStatement) bool {
+	found := false
+	astutil.Apply(body, func(c *astutil.Cursor) bool {
+		if ret, ok := c.Node().(*ast.ReturnStatement); ok && ret.Result != nil {
+			found = true
+		}
+		return !found
+	}, nil)
+	return found
+}
+
+// deletion returns the edit removing the name of the return variable with
+// the whitespace before it e.g. `uint256 total` becomes `uint256`.
+func deletion(file *token.SourceFile, result *symbols.Param) reporter.Edit {
+	content := file.Content()
+	start := file.Offset(result.Offset)
+	end := start + len(result.Name)
+	start = len(strings.TrimRight(content[:start], " \t\n\r"))
+
+	return reporter.Edit{Pos: file.Pos(start), End: file.Pos(end)}
+}
@@ -0,0 +1,93 @@
+package unusedreturn_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/analyzer"
+	"github.com/ChmielewskiKamil/solbot/analyzer/unusedreturn"
+	"github.com/ChmielewskiKamil/solbot/parser"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+func Test_DetectUnusedReturns(t *testing.T) {
+	src := `contract Vault {
+    uint256 total;
+
+    function explicit() public returns (uint256 amount, bool ok) {
+        return (total, true);
+    }
+
+    function assigned() public returns (uint256 amount) {
+        amount = total;
+    }
+
+    function mixed(bool flag) public returns (uint256 amount) {
+        if (flag) {
+            return 1;
+        }
+        amount = total;
+    }
+
+    function implicit() public returns (uint256 amount) {
+        return;
+    }
+}
+`
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	a := analyzer.Analyzer{}
+	a.AnalyzeFile(file)
+	for _, e := range a.Errors() {
+		t.Fatalf("Analyzer error: %s At location: %s", e.Msg, e.Loc)
+	}
+
+	d := unusedreturn.Detector{}
+
+	finding := d.Detect(file, a.GetCurrentFileEnv())
+	if finding == nil {
+		t.Fatalf("Expected a finding, got nil")
+	}
+
+	finding.CalculatePositions(file.SourceFile)
+
+	// The named returns used together with the explicit returns are fine.
+	// The bare `return;` returns the named values, so it is not explicit.
+	expectedLocations := []struct {
+		location reporter.Location
+		fixed    string
+	}{
+		{reporter.Location{Position: token.Position{Line: 4, Column: 49}, Context: "amount"}, "returns (uint256, bool ok)"},
+		{reporter.Location{Position: token.Position{Line: 4, Column: 62}, Context: "ok"}, "returns (uint256 amount, bool)"},
+	}
+
+	if len(finding.Locations) != len(expectedLocations) {
+		t.Fatalf("Expected %d locations, got %d: %v", len(expectedLocations), len(finding.Locations), finding.Locations)
+	}
+
+	for i, expected := range expectedLocations {
+		got := finding.Locations[i]
+		if got.Position.Line != expected.location.Position.Line || got.Position.Column != expected.location.Position.Column {
+			t.Errorf("Location %d: expected %d:%d, got %d:%d", i,
+				expected.location.Position.Line, expected.location.Position.Column, got.Position.Line, got.Position.Column)
+		}
+		if got.Context != expected.location.Context {
+			t.Errorf("Location %d: expected context %q, got %q", i, expected.location.Context, got.Context)
+		}
+
+		if len(got.Edits) != 1 {
+			t.Fatalf("Location %d: expected 1 edit, got: %v", i, got.Edits)
+		}
+		edit := got.Edits[0]
+		content := file.SourceFile.Content()
+		fixed := content[:file.SourceFile.Offset(edit.Pos)] + edit.NewText + content[file.SourceFile.Offset(edit.End):]
+		if !strings.Contains(fixed, expected.fixed) {
+			t.Errorf("Location %d: expected the fixed source to contain %q", i, expected.fixed)
+		}
+	}
+}
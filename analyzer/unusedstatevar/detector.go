@@ -0,0 +1,55 @@
+// unusedstatevar detects state variables that are never read nor written.
+// The public state variables are skipped, since their getters can be called
+// from outside of the contracts. The variables used in the inline assembly
+// e.g. `sstore(x.slot, 1)`, and through the storage pointers are used, since
+// the pointers are initialized by reading the variables.
+package unusedstatevar
+
+import (
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/symbols"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+const (
+	title          = "Unused state variables should be removed"
+	severity       = "Best Practices"
+	descTempl      = "The following state variables are never used: {{ range .Locations }}\n- `{{ .Context }}`{{ end }}"
+	recommendation = "Consider removing the unused state variables. Be careful with the upgradeable contracts, where removing a variable changes the storage layout."
+)
+
+type Detector struct{}
+
+func (*Detector) Detect(node ast.Node, env *symbols.Environment) *reporter.Finding {
+	if _, ok := node.(*ast.File); !ok || env == nil {
+		return nil
+	}
+
+	finding := reporter.Finding{}
+
+	// The references from the contracts inheriting the variable are
+	// resolved to the symbol of the base contract's variable.
+	for _, stateVar := range symbols.GetAllSymbolsByTypeInFile[*symbols.StateVariable](env) {
+		if stateVar.Visibility == ast.Public || len(stateVar.References) > 0 {
+			continue
+		}
+
+		finding.Locations = append(finding.Locations, reporter.Location{
+			Position: token.Position{
+				Offset: stateVar.Offset,
+			},
+			Context: stateVar.Name,
+		})
+	}
+
+	if len(finding.Locations) == 0 {
+		return nil
+	}
+
+	finding.Title = title
+	finding.Severity = severity
+	finding.Description = reporter.GenerateCustomDescription(descTempl, finding.Locations)
+	finding.Recommendation = recommendation
+	return &finding
+}
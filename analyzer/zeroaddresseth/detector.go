@@ -0,0 +1,246 @@
+// zeroaddresseth detects `address(0)` used to represent Ether e.g. a token
+// address compared with `address(0)` to choose between the Ether and the
+// token transfers. The zero address is also the default value of the
+// addresses, so a forgotten initialization is treated as Ether.
+//
+// A comparison with `address(0)` is reported if the branch taken for the
+// zero address handles Ether: it reads `msg.value` or sends the value with
+// the `{value: ...}` call option. The branch is the consequence of `==` and
+// the alternative of `!=`. The constants equal to `address(0)` e.g.
+// `address constant ETH = address(0)` are treated like `address(0)`.
+//
+// The variables compared in such branches are the Ether sentinels, so the
+// assignments of `address(0)` to them are reported too, including the
+// declarations of the constants.
+package zeroaddresseth
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/ast/astutil"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/symbols"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+const (
+	title          = "`address(0)` should not be used to represent Ether"
+	severity       = "Best Practices"
+	descTempl      = "The zero address is used to represent Ether in the following places: {{ range .Locations }}\n- `{{ .Context }}`{{ end }}\n\nThe zero address is the default value of the addresses, so an address that was never set is handled as Ether."
+	recommendation = "Consider using a dedicated non-zero sentinel for Ether e.g. `0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE`, or handling Ether in separate functions."
+)
+
+type Detector struct{}
+
+func (*Detector) Detect(node ast.Node, env *symbols.Environment) *reporter.Finding {
+	file, ok := node.(*ast.File)
+	if !ok || env == nil {
+		return nil
+	}
+
+	d := detection{variables: variables(env), sentinels: make(map[*symbols.BaseSymbol]bool)}
+
+	// The comparisons are found first, since they determine the sentinels.
+	astutil.Apply(file, func(c *astutil.Cursor) bool {
+		if stmt, ok := c.Node().(*ast.IfStatement); ok {
+			d.checkIfStatement(stmt)
+		}
+		return true
+	}, nil)
+
+	astutil.Apply(file, func(c *astutil.Cursor) bool {
+		switch n := c.Node().(type) {
+		case *ast.InfixExpression:
+			if n.Operator.Type == token.ASSIGN && isZeroAddress(n.Right) {
+				d.checkAssignment(n, n.Left)
+			}
+		case *ast.VariableDeclarationStatement:
+			if n.Name != nil && isZeroAddress(n.Value) {
+				d.checkAssignment(n, n.Name)
+			}
+		case *ast.StateVariableDeclaration:
+			if n.Name != nil && isZeroAddress(n.Value) {
+				d.checkAssignment(n, n.Name)
+			}
+		case *ast.ConstantVariableDeclaration:
+			if n.Name != nil && isZeroAddress(n.Value) {
+				d.checkAssignment(n, n.Name)
+			}
+		}
+		return true
+	}, nil)
+
+	if len(d.finding.Locations) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(d.finding.Locations, func(i, j int) bool {
+		return d.finding.Locations[i].Position.Offset < d.finding.Locations[j].Position.Offset
+	})
+
+	d.finding.Title = title
+	d.finding.Severity = severity
+	d.finding.Description = reporter.GenerateCustomDescription(descTempl, d.finding.Locations)
+	d.finding.Recommendation = recommendation
+	return &d.finding
+}
+
+type detection struct {
+	finding   reporter.Finding
+	variables map[token.Pos]*symbols.BaseSymbol // The variables by the offsets of their names and references.
+	sentinels map[*symbols.BaseSymbol]bool      // The variables compared with the zero address to handle Ether.
+}
+
+// checkIfStatement reports the comparisons with the zero address in the
+// condition, if the branch taken for the zero address handles Ether.
+func (d *detection) checkIfStatement(stmt *ast.IfStatement) {
+	astutil.Apply(stmt.Condition, func(c *astutil.Cursor) bool {
+		cmp, ok := c.Node().(*ast.InfixExpression)
+		if !ok || (cmp.Operator.Type != token.EQUAL && cmp.Operator.Type != token.NOT_EQUAL) {
+			return true
+		}
+
+		var compared ast.Expression
+		switch {
+		case d.isEther(cmp.Right):
+			compared = cmp.Left
+		case d.isEther(cmp.Left):
+			compared = cmp.Right
+		default:
+			return true
+		}
+
+		branch := stmt.Consequence
+		if cmp.Operator.Type == token.NOT_EQUAL {
+			branch = stmt.Alternative
+		}
+		if branch == nil || !handlesEther(branch) {
+			return true
+		}
+
+		d.report(cmp)
+		if ident, ok := compared.(*ast.Identifier); ok {
+			if variable := d.variables[ident.Pos]; variable != nil {
+				d.sentinels[variable] = true
+			}
+		}
+		return false
+	}, nil)
+}
+
+// checkAssignment reports the assignment of the zero address to the target,
+// if the target is an Ether sentinel.
+func (d *detection) checkAssignment(assignment ast.Node, target ast.Expression) {
+	ident, ok := target.(*ast.Identifier)
+	if !ok {
+		return
+	}
+	if variable := d.variables[ident.Pos]; variable != nil && d.sentinels[variable] {
+		d.report(assignment)
+	}
+}
+
+func (d *detection) report(node ast.Node) {
+	d.finding.Locations = append(d.finding.Locations, reporter.Location{
+		Position: token.Position{
+			Offset: node.Start(),
+		},
+		Context: strings.TrimSuffix(strings.TrimPrefix(node.String(), "("), ")"),
+	})
+}
+
+// isEther reports whether the expression is the zero address or a constant
+// equal to it. The constants are the sentinels once they are compared.
+func (d *detection) isEther(expr ast.Expression) bool {
+	if isZeroAddress(expr) {
+		return true
+	}
+
+	ident, ok := expr.(*ast.Identifier)
+	if !ok {
+		return false
+	}
+	variable := d.variables[ident.Pos]
+	if variable == nil {
+		return false
+	}
+
+	var value ast.Expression
+	switch decl := variable.AstNode.(type) {
+	case *ast.StateVariableDeclaration:
+		if decl.Mutability != ast.Constant {
+			return false
+		}
+		value = decl.Value
+	case *ast.ConstantVariableDeclaration:
+		value = decl.Value
+	}
+	if !isZeroAddress(value) {
+		return false
+	}
+
+	d.sentinels[variable] = true
+	return true
+}
+
+// isZeroAddress reports whether the expression is `address(0)`.
+func isZeroAddress(expr ast.Expression) bool {
+	conversion, ok := expr.(*ast.ElementaryTypeExpression)
+	if !ok || conversion.Kind.Type != token.ADDRESS {
+		return false
+	}
+	number, ok := conversion.Value.(*ast.NumberLiteral)
+	return ok && number.Value.Sign() == 0
+}
+
+// handlesEther reports whether the statement reads `msg.value` or sends
+// Ether with the `{value: ...}` call option.
+func handlesEther(stmt ast.Statement) bool {
+	found := false
+	astutil.Apply(stmt, func(c *astutil.Cursor) bool {
+		switch n := c.Node().(type) {
+		case *ast.MemberAccessExpression:
+			if msg, ok := n.Expression.(*ast.Identifier); ok && msg.Value == "msg" &&
+				n.Member != nil && n.Member.Value == "value" {
+				found = true
+			}
+		case *ast.CallOptionsExpression:
+			for _, name := range n.Names {
+				if name.Value == "value" {
+					found = true
+				}
+			}
+		}
+		return !found
+	}, nil)
+	return found
+}
+
+// variables returns the variables of the file env by the offsets of their
+// names and references, so the identifiers can be mapped to the variables.
+func variables(env *symbols.Environment) map[token.Pos]*symbols.BaseSymbol {
+	var all []*symbols.BaseSymbol
+	for _, s := range symbols.GetAllSymbolsByTypeInTree[*symbols.StateVariable](env) {
+		all = append(all, &s.BaseSymbol)
+	}
+	for _, s := range symbols.GetAllSymbolsByTypeInTree[*symbols.ConstantVariable](env) {
+		all = append(all, &s.BaseSymbol)
+	}
+	for _, s := range symbols.GetAllSymbolsByTypeInTree[*symbols.LocalVariable](env) {
+		all = append(all, &s.BaseSymbol)
+	}
+	for _, s := range symbols.GetAllSymbolsByTypeInTree[*symbols.Param](env) {
+		all = append(all, &s.BaseSymbol)
+	}
+
+	results := make(map[token.Pos]*symbols.BaseSymbol)
+	for _, variable := range all {
+		results[variable.Offset] = variable
+		for _, ref := range variable.References {
+			results[ref.Offset] = variable
+		}
+	}
+	return results
+}
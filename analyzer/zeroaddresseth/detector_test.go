@@ -0,0 +1,111 @@
+package zeroaddresseth_test
+
+import (
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/analyzer/analyzertest"
+	"github.com/ChmielewskiKamil/solbot/analyzer/zeroaddresseth"
+	"github.com/ChmielewskiKamil/solbot/reporter"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+func Test_DetectZeroAddressEth(t *testing.T) {
+	src := `address constant ETH = address(0);
+
+contract Vault {
+    address public asset;
+
+    function deposit(address token, uint256 amount) external payable {
+        if (token == address(0)) {
+            require(msg.value == amount);
+        }
+    }
+
+    function withdraw(uint256 amount) external {
+        if (asset != address(0)) {
+            transfer(asset, amount);
+        } else {
+            (bool ok, ) = msg.sender.call{value: amount}("");
+            require(ok);
+        }
+    }
+
+    function pay(address token, uint256 amount) external payable {
+        if (token == ETH && amount > 0) {
+            require(msg.value == amount);
+        }
+    }
+
+    function reset() external {
+        asset = address(0);
+    }
+
+    function transfer(address to, uint256 amount) internal {}
+}
+`
+
+	file, env := analyzertest.Analyze(t, src)
+
+	d := zeroaddresseth.Detector{}
+	finding := d.Detect(file, env)
+	if finding == nil {
+		t.Fatalf("Expected a finding, got nil")
+	}
+
+	finding.CalculatePositions(file.SourceFile)
+
+	expected := []reporter.Location{
+		{Position: token.Position{Line: 1, Column: 1}, Context: "address constant ETH = address(0);"},
+		{Position: token.Position{Line: 7, Column: 13}, Context: "token == address(0)"},
+		{Position: token.Position{Line: 13, Column: 13}, Context: "asset != address(0)"},
+		{Position: token.Position{Line: 22, Column: 13}, Context: "token == ETH"},
+		{Position: token.Position{Line: 28, Column: 9}, Context: "asset = address(0)"},
+	}
+
+	if len(finding.Locations) != len(expected) {
+		t.Fatalf("Expected %d findings, got %d: %v", len(expected), len(finding.Locations), finding.Locations)
+	}
+
+	for i, loc := range finding.Locations {
+		if loc.Position.Line != expected[i].Position.Line ||
+			loc.Position.Column != expected[i].Position.Column {
+			t.Errorf("Expected %d:%d, got %d:%d",
+				expected[i].Position.Line, expected[i].Position.Column,
+				loc.Position.Line, loc.Position.Column)
+		}
+
+		if loc.Context != expected[i].Context {
+			t.Errorf("Expected context %q, got %q", expected[i].Context, loc.Context)
+		}
+	}
+}
+
+func Test_DetectZeroAddressEth_ShouldReturnNil(t *testing.T) {
+	src := `contract Vault {
+    address public owner;
+
+    function setOwner(address newOwner) external {
+        require(newOwner != address(0));
+        owner = newOwner;
+    }
+
+    function deposit(address token, uint256 amount) external payable {
+        if (token == address(0)) {
+            revert();
+        }
+        require(msg.value == amount);
+    }
+
+    function renounce() external {
+        owner = address(0);
+    }
+}
+`
+
+	file, env := analyzertest.Analyze(t, src)
+
+	d := zeroaddresseth.Detector{}
+	if finding := d.Detect(file, env); finding != nil {
+		t.Errorf("Expected no finding, got: %v", finding.Locations)
+	}
+}
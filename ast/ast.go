@@ -85,6 +85,12 @@ type (
 		Value bool
 	}
 
+	StringLiteral struct {
+		Pos   token.Pos   // position of the opening quote
+		Kind  token.Token // contains the token kind and the quoted literal e.g. "\"hello\""
+		Value string      // value of the literal without the quotes
+	}
+
 	PrefixExpression struct {
 		Pos      token.Pos   // position of the operator
 		Operator token.Token // operator token
@@ -110,12 +116,32 @@ type (
 		Args  []Expression // Comma-separated list of arguments
 	}
 
+	// CallOptionsExpression represents the options of an external call e.g.
+	// `to.call{value: amount}` in `to.call{value: amount}("")`. The call
+	// itself is the CallExpression enclosing it.
+	CallOptionsExpression struct {
+		Expression Expression    // the called expression e.g. `to.call`
+		LeftBrace  token.Pos     // position of "{"
+		Names      []*Identifier // option names e.g. value, gas or salt
+		Values     []Expression  // option values in the order of the names
+		RightBrace token.Pos     // position of "}"
+	}
+
 	MemberAccessExpression struct {
 		Pos        token.Pos   // Position of the expression being accessed
 		Expression Expression  // The expression on the left of the dot, e.g., 'super'
 		Member     *Identifier // The identifier on the right of the dot, e.g., 'pausable'
 	}
 
+	// TupleExpression represents a tuple of expressions e.g. the left-hand
+	// side of `(a, , b) = f()`. The parenthesized single expressions are
+	// represented by the inner expression.
+	TupleExpression struct {
+		Opening    token.Pos    // position of "("
+		Components []Expression // components of the tuple; the omitted components are nil
+		Closing    token.Pos    // position of ")"
+	}
+
 	ElementaryTypeExpression struct {
 		Pos  token.Pos   // position of the type keyword e.g. `a` in "address"
 		Kind token.Token // type of the literal e.g. token.ADDRESS, token.UINT_256, token.BOOL
@@ -142,27 +168,67 @@ func (x *BooleanLiteral) End() token.Pos {
 	}
 	return token.Pos(int(x.Pos) + 5) // length of "false"
 }
+func (x *StringLiteral) Start() token.Pos { return x.Pos }
+func (x *StringLiteral) End() token.Pos {
+	return token.Pos(int(x.Pos) + len(x.Kind.Literal))
+}
 func (x *PrefixExpression) Start() token.Pos { return x.Pos }
 func (x *PrefixExpression) End() token.Pos {
-	return x.Right.End()
+	if x.Right != nil {
+		return x.Right.End()
+	}
+	return token.Pos(int(x.Pos) + len(x.Operator.Literal))
 }
 func (x *InfixExpression) Start() token.Pos { return x.Pos }
 func (x *InfixExpression) End() token.Pos {
-	return x.Right.End()
+	if x.Right != nil {
+		return x.Right.End()
+	}
+	return token.Pos(int(x.Operator.Pos) + len(x.Operator.Literal))
+}
+func (x *PostfixExpression) Start() token.Pos {
+	if x.Left != nil {
+		return x.Left.Start()
+	}
+	return x.Pos
 }
-func (x *PostfixExpression) Start() token.Pos { return x.Left.Start() }
 func (x *PostfixExpression) End() token.Pos {
 	return token.Pos(int(x.Operator.Pos) + len(x.Operator.Literal))
 }
 func (x *CallExpression) Start() token.Pos { return x.Pos }
 func (x *CallExpression) End() token.Pos {
-	if len(x.Args) > 0 {
+	if len(x.Args) > 0 && x.Args[len(x.Args)-1] != nil {
 		return x.Args[len(x.Args)-1].End() + 1 // TODO: Shouldnt +2?
 	}
-	return x.Pos + 2 // length of "()"
+	if x.Ident != nil {
+		return x.Ident.End() + 2 // length of "()"
+	}
+	return x.Pos + 2
+}
+func (x *CallOptionsExpression) Start() token.Pos {
+	if x.Expression != nil {
+		return x.Expression.Start()
+	}
+	return x.LeftBrace
+}
+func (x *CallOptionsExpression) End() token.Pos { return x.RightBrace + 1 }
+func (x *MemberAccessExpression) Start() token.Pos {
+	if x.Expression != nil {
+		return x.Expression.Start()
+	}
+	return x.Pos
+}
+func (x *MemberAccessExpression) End() token.Pos {
+	if x.Member != nil {
+		return x.Member.End()
+	}
+	if x.Expression != nil {
+		return x.Expression.End() + 1 // length of "."
+	}
+	return x.Pos
 }
-func (x *MemberAccessExpression) Start() token.Pos   { return x.Expression.Start() }
-func (x *MemberAccessExpression) End() token.Pos     { return x.Member.End() }
+func (x *TupleExpression) Start() token.Pos          { return x.Opening }
+func (x *TupleExpression) End() token.Pos            { return x.Closing + 1 }
 func (x *ElementaryTypeExpression) Start() token.Pos { return x.Pos }
 func (x *ElementaryTypeExpression) End() token.Pos {
 	if x.Value != nil {
@@ -178,11 +244,14 @@ func (x *ElementaryTypeExpression) End() token.Pos {
 func (*Identifier) expressionNode()               {}
 func (*NumberLiteral) expressionNode()            {}
 func (*BooleanLiteral) expressionNode()           {}
+func (*StringLiteral) expressionNode()            {}
 func (*PrefixExpression) expressionNode()         {}
 func (*InfixExpression) expressionNode()          {}
 func (*PostfixExpression) expressionNode()        {}
 func (*CallExpression) expressionNode()           {}
+func (*CallOptionsExpression) expressionNode()    {}
 func (*MemberAccessExpression) expressionNode()   {}
+func (*TupleExpression) expressionNode()          {}
 func (*ElementaryTypeExpression) expressionNode() {}
 
 // String() implementations for Expressions
@@ -195,6 +264,7 @@ func (x *BooleanLiteral) String() string {
 	}
 	return "false"
 }
+func (x *StringLiteral) String() string { return x.Kind.Literal }
 func (x *PrefixExpression) String() string {
 	var out bytes.Buffer
 	out.WriteString("(")
@@ -235,6 +305,35 @@ func (x *CallExpression) String() string {
 	out.WriteString(")")
 	return out.String()
 }
+func (x *TupleExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString("(")
+	for i, component := range x.Components {
+		if i > 0 {
+			out.WriteString(", ")
+		}
+		if component != nil {
+			out.WriteString(component.String())
+		}
+	}
+	out.WriteString(")")
+	return out.String()
+}
+func (x *CallOptionsExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString(x.Expression.String())
+	out.WriteString("{")
+	for i, name := range x.Names {
+		if i > 0 {
+			out.WriteString(", ")
+		}
+		out.WriteString(name.String())
+		out.WriteString(": ")
+		out.WriteString(x.Values[i].String())
+	}
+	out.WriteString("}")
+	return out.String()
+}
 func (x *MemberAccessExpression) String() string {
 	return "(" + x.Expression.String() + "." + x.Member.String() + ")"
 }
@@ -261,8 +360,9 @@ func (x *ElementaryTypeExpression) String() string {
 // fixed, fixed-bytes or ufixed. NOT a Contract, Function, mapping (these are
 // the four other types)
 type ElementaryType struct {
-	Pos  token.Pos   // position of the type keyword e.g. `a` in "address"
-	Kind token.Token // type of the literal e.g. token.ADDRESS, token.UINT_256, token.BOOL
+	Pos     token.Pos   // position of the type keyword e.g. `a` in "address"
+	Kind    token.Token // type of the literal e.g. token.ADDRESS, token.UINT_256, token.BOOL
+	Payable token.Pos   // position of the "payable" in "address payable"; or 0
 }
 
 type UserDefinedType struct {
@@ -332,23 +432,67 @@ type EventParam struct {
 
 // Start() and End() implementations for Expression type Nodes
 
-func (t *ElementaryType) Start() token.Pos  { return t.Pos }
-func (t *ElementaryType) End() token.Pos    { return token.Pos(int(t.Pos) + len(t.Kind.Literal)) }
-func (t *UserDefinedType) Start() token.Pos { return t.Name.Start() }
-func (t *UserDefinedType) End() token.Pos   { return t.Name.End() }
-func (t *Param) Start() token.Pos           { return t.Type.Start() }
+func (t *ElementaryType) Start() token.Pos { return t.Pos }
+func (t *ElementaryType) End() token.Pos {
+	if t.Payable != 0 {
+		return t.Payable + token.Pos(len("payable"))
+	}
+	return token.Pos(int(t.Pos) + len(t.Kind.Literal))
+}
+func (t *UserDefinedType) Start() token.Pos {
+	if t.Name != nil {
+		return t.Name.Start()
+	}
+	return 0
+}
+func (t *UserDefinedType) End() token.Pos {
+	if t.Name != nil {
+		return t.Name.End()
+	}
+	return 0
+}
+func (t *Param) Start() token.Pos {
+	if t.Type != nil {
+		return t.Type.Start()
+	}
+	if t.Name != nil {
+		return t.Name.Start()
+	}
+	return 0
+}
 func (t *Param) End() token.Pos {
 	if t.Name != nil {
 		return t.Name.End()
 	}
 	// TODO: What about the case when there is no name, but there IS the
 	// data location?
-	return t.Type.End()
+	if t.Type != nil {
+		return t.Type.End()
+	}
+	return 0
+}
+func (t *ParamList) Start() token.Pos      { return t.Opening }
+func (t *ParamList) End() token.Pos        { return t.Closing + 1 }
+func (t *EventParamList) Start() token.Pos { return t.Opening }
+func (t *EventParamList) End() token.Pos   { return t.Closing + 1 }
+func (t *EventParam) Start() token.Pos {
+	if t.Type != nil {
+		return t.Type.Start()
+	}
+	if t.Name != nil {
+		return t.Name.Start()
+	}
+	return 0
+}
+func (t *EventParam) End() token.Pos {
+	if t.Name != nil {
+		return t.Name.End()
+	}
+	if t.Type != nil {
+		return t.Type.End()
+	}
+	return 0
 }
-func (t *ParamList) Start() token.Pos  { return t.Opening }
-func (t *ParamList) End() token.Pos    { return t.Closing + 1 }
-func (t *EventParam) Start() token.Pos { return t.Type.Start() }
-func (t *EventParam) End() token.Pos   { return t.Name.End() }
 
 // typeNode() implementations
 
@@ -356,6 +500,7 @@ func (*ElementaryType) typeNode()  {}
 func (*UserDefinedType) typeNode() {}
 func (*Param) typeNode()           {}
 func (*ParamList) typeNode()       {}
+func (*EventParamList) typeNode()  {}
 func (*EventParam) typeNode()      {}
 
 // String() implementations for Types
@@ -363,6 +508,9 @@ func (*EventParam) typeNode()      {}
 func (t *ElementaryType) String() string {
 	var out bytes.Buffer
 	out.WriteString(t.Kind.Literal)
+	if t.Payable != 0 {
+		out.WriteString(" payable")
+	}
 	return out.String()
 }
 
@@ -400,11 +548,31 @@ func (t *ParamList) String() string {
 	return out.String()
 }
 
+func (t *EventParamList) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	for i, p := range t.List {
+		if i > 0 {
+			out.WriteString(", ")
+		}
+		out.WriteString(p.String())
+	}
+	out.WriteString(")")
+
+	return out.String()
+}
+
 func (t *EventParam) String() string {
 	var out bytes.Buffer
 	out.WriteString(t.Type.String())
-	out.WriteString(" ")
-	out.WriteString(t.Name.String())
+	if t.IsIndexed {
+		out.WriteString(" indexed")
+	}
+	if t.Name != nil {
+		out.WriteString(" ")
+		out.WriteString(t.Name.String())
+	}
 	return out.String()
 }
 
@@ -479,16 +647,54 @@ type (
 		Pos        token.Pos  // position of the "emit" keyword
 		Expression Expression // expression to evaluate; it must refer to an event.
 	}
+
+	// RevertStatement reverts with a custom error e.g.
+	// `revert Unauthorized(msg.sender);`. The reverts with a message e.g.
+	// `revert("reason")` are the calls of the revert function.
+	RevertStatement struct {
+		Pos        token.Pos  // position of the "revert" keyword
+		Expression Expression // call of the error to revert with
+	}
+
+	// AssemblyStatement is an inline assembly block e.g.
+	// `assembly ("memory-safe") { sstore(x.slot, 1) }`. The Yul code inside
+	// is not parsed yet; only the identifiers it refers to are kept, so the
+	// Solidity variables used in the assembly are known.
+	AssemblyStatement struct {
+		Pos         token.Pos     // position of the "assembly" keyword
+		LeftBrace   token.Pos     // position of the left curly brace
+		Identifiers []*Identifier // identifiers in the block, without the members e.g. `x` of `x.slot`
+		RightBrace  token.Pos     // position of the right curly brace
+	}
 )
 
 // Start() and End() implementations for Statement type Nodes
 
-func (s *BlockStatement) Start() token.Pos                    { return s.LeftBrace }
-func (s *BlockStatement) End() token.Pos                      { return s.RightBrace + 1 }
-func (s *UncheckedBlockStatement) Start() token.Pos           { return s.LeftBrace }
-func (s *UncheckedBlockStatement) End() token.Pos             { return s.RightBrace + 1 }
-func (s *VariableDeclarationStatement) Start() token.Pos      { return s.Type.Start() }
-func (s *VariableDeclarationStatement) End() token.Pos        { return s.Value.End() }
+func (s *BlockStatement) Start() token.Pos          { return s.LeftBrace }
+func (s *BlockStatement) End() token.Pos            { return s.RightBrace + 1 }
+func (s *UncheckedBlockStatement) Start() token.Pos { return s.LeftBrace }
+func (s *UncheckedBlockStatement) End() token.Pos   { return s.RightBrace + 1 }
+func (s *VariableDeclarationStatement) Start() token.Pos {
+	if s.Type != nil {
+		return s.Type.Start()
+	}
+	if s.Name != nil {
+		return s.Name.Start()
+	}
+	return 0
+}
+func (s *VariableDeclarationStatement) End() token.Pos {
+	if s.Value != nil {
+		return s.Value.End()
+	}
+	if s.Name != nil {
+		return s.Name.End()
+	}
+	if s.Type != nil {
+		return s.Type.End()
+	}
+	return 0
+}
 func (s *VariableDeclarationTupleStatement) Start() token.Pos { return s.Opening }
 func (s *VariableDeclarationTupleStatement) End() token.Pos   { return s.Closing + 1 }
 func (s *ReturnStatement) Start() token.Pos                   { return s.Pos }
@@ -499,8 +705,13 @@ func (s *ReturnStatement) End() token.Pos {
 	return s.Pos + 6 // length of "return"
 }
 func (s *ExpressionStatement) Start() token.Pos { return s.Pos }
-func (s *ExpressionStatement) End() token.Pos   { return s.Expression.End() }
-func (s *IfStatement) Start() token.Pos         { return s.Pos }
+func (s *ExpressionStatement) End() token.Pos {
+	if s.Expression != nil {
+		return s.Expression.End()
+	}
+	return s.Pos
+}
+func (s *IfStatement) Start() token.Pos { return s.Pos }
 func (s *IfStatement) End() token.Pos {
 	endPos := s.Pos + 2 // The length of "if".
 
@@ -515,7 +726,21 @@ func (s *IfStatement) End() token.Pos {
 	return endPos
 }
 func (s *EmitStatement) Start() token.Pos { return s.Pos }
-func (s *EmitStatement) End() token.Pos   { return s.Expression.End() }
+func (s *EmitStatement) End() token.Pos {
+	if s.Expression != nil {
+		return s.Expression.End()
+	}
+	return s.Pos + 4 // length of "emit"
+}
+func (s *RevertStatement) Start() token.Pos { return s.Pos }
+func (s *RevertStatement) End() token.Pos {
+	if s.Expression != nil {
+		return s.Expression.End()
+	}
+	return s.Pos + 6 // length of "revert"
+}
+func (s *AssemblyStatement) Start() token.Pos { return s.Pos }
+func (s *AssemblyStatement) End() token.Pos   { return s.RightBrace + 1 }
 
 // statementNode() ensures that only statement nodes can be assigned to a Statement.
 func (*BlockStatement) statementNode()                    {}
@@ -526,6 +751,8 @@ func (*ReturnStatement) statementNode()                   {}
 func (*ExpressionStatement) statementNode()               {}
 func (*IfStatement) statementNode()                       {}
 func (*EmitStatement) statementNode()                     {}
+func (*RevertStatement) statementNode()                   {}
+func (*AssemblyStatement) statementNode()                 {}
 
 // String() implementations for Statements
 
@@ -601,8 +828,9 @@ func (s *VariableDeclarationTupleStatement) String() string {
 
 func (s *ReturnStatement) String() string {
 	var out bytes.Buffer
-	out.WriteString("return ")
+	out.WriteString("return")
 	if s.Result != nil {
+		out.WriteString(" ")
 		out.WriteString(s.Result.String())
 	}
 	out.WriteString(";")
@@ -641,12 +869,55 @@ func (s *EmitStatement) String() string {
 	return out.String()
 }
 
+func (s *RevertStatement) String() string {
+	var out bytes.Buffer
+	out.WriteString("revert ")
+	out.WriteString(s.Expression.String())
+	out.WriteString(";")
+
+	return out.String()
+}
+
+func (s *AssemblyStatement) String() string {
+	return "assembly { ... }"
+}
+
 /*~*~*~*~*~*~*~*~*~*~*~*~ Declarations ~*~*~*~*~*~*~*~*~*~*~*~*~*/
 
 // TODO: Add Struct declaration
 // TODO: Add Enum declaration
 // TODO: Add Error declaration
 
+// ImportSymbol represents an item in the symbol list of an import
+// directive e.g. `A` or `B as C` in `import {A, B as C} from "./A.sol";`.
+type ImportSymbol struct {
+	Name  *Identifier // The symbol exported by the imported file.
+	Alias *Identifier // The local name of the symbol or nil if not aliased.
+}
+
+// LocalName returns the name under which the symbol is visible in the
+// importing file.
+func (s *ImportSymbol) LocalName() *Identifier {
+	if s.Alias != nil {
+		return s.Alias
+	}
+	return s.Name
+}
+
+// ImportDirective represents an import directive in one of the forms:
+//
+//	import "./A.sol";
+//	import "./A.sol" as A;
+//	import * as A from "./A.sol";
+//	import {A, B as C} from "./A.sol";
+type ImportDirective struct {
+	Pos       token.Pos       // position of the 'import' keyword
+	Path      *StringLiteral  // The path of the imported file.
+	UnitAlias *Identifier     // The alias of `"./A.sol" as A` and `* as A` or nil.
+	Symbols   []*ImportSymbol // The symbols of `{A, B as C}`; empty for the other forms.
+	Semicolon token.Pos       // position of the semicolon
+}
+
 // UsingForObject represents an item in a `using for` list.
 // e.g., `add`, `sub as sub_`, or `isEqual as ==`
 type UsingForObject struct {
@@ -723,20 +994,57 @@ type ModifierDeclaration struct {
 	Semicolon token.Pos          // position of the semicolon for abstract modifiers
 }
 
+// ModifierInvocation represents a modifier applied to a function in its
+// header e.g. `onlyOwner` or `nonReentrant(1)`.
+type ModifierInvocation struct {
+	Name    *Identifier  // modifier name
+	Opening token.Pos    // position of the opening '('; or 0 if there are no parentheses
+	Args    []Expression // arguments of the invocation
+	Closing token.Pos    // position of the closing ')'; or 0 if there are no parentheses
+}
+
 type FunctionDeclaration struct {
-	Pos        token.Pos       // position of the "function" keyword
-	Name       *Identifier     // function name
-	Params     *ParamList      // input parameters; or nil
-	Results    *ParamList      // output parameters; or nil
-	Mutability Mutability      // mutability specifier e.g. pure, view, payable
-	Visibility Visibility      // visibility specifier e.g. public, private, internal, external
-	Virtual    bool            // whether a function is marked as virtual
-	Body       *BlockStatement // function body inside curly braces
-	// TODO: Add modifier invocations *CallExpression
-	// TODO: Add override specifier
+	Pos        token.Pos             // position of the "function" keyword
+	Name       *Identifier           // function name
+	Params     *ParamList            // input parameters; or nil
+	Results    *ParamList            // output parameters; or nil
+	Mutability Mutability            // mutability specifier e.g. pure, view, payable
+	Visibility Visibility            // visibility specifier e.g. public, private, internal, external
+	Virtual    bool                  // whether a function is marked as virtual
+	Override   *OverrideSpecifier    // override specifier; nil if not present
+	Modifiers  []*ModifierInvocation // modifier invocations in the order of the declaration
+	Body       *BlockStatement       // function body inside curly braces; nil for functions without implementation
+	Semicolon  token.Pos             // position of the semicolon for functions without implementation
 	// TODO: Add documentation comments
 }
 
+// ConstructorDeclaration represents the constructor of a contract e.g.
+// `constructor(address owner) Ownable(owner) payable { ... }`.
+type ConstructorDeclaration struct {
+	Pos        token.Pos             // position of the "constructor" keyword
+	Params     *ParamList            // input parameters
+	Mutability Mutability            // payable or none
+	Visibility Visibility            // the deprecated public or internal; or none
+	Modifiers  []*ModifierInvocation // modifier invocations and calls to the base constructors e.g. `Ownable(owner)`
+	Body       *BlockStatement       // constructor body inside curly braces
+}
+
+// SpecialFunctionDeclaration represents the receive or the fallback function
+// of a contract e.g. `receive() external payable { ... }`. Only the fallback
+// function can have params and results.
+type SpecialFunctionDeclaration struct {
+	Kind       token.Token           // the "receive" or "fallback" keyword
+	Params     *ParamList            // input parameters
+	Results    *ParamList            // output parameters; or nil
+	Mutability Mutability            // payable, view, pure or none
+	Visibility Visibility            // external
+	Virtual    bool                  // whether a function is marked as virtual
+	Override   *OverrideSpecifier    // override specifier; nil if not present
+	Modifiers  []*ModifierInvocation // modifier invocations in the order of the declaration
+	Body       *BlockStatement       // function body inside curly braces; nil for functions without implementation
+	Semicolon  token.Pos             // position of the semicolon for functions without implementation
+}
+
 // StateVariableDeclaration represents a state variable declared inside a contract.
 type StateVariableDeclaration struct {
 	Name       *Identifier // variable name
@@ -747,6 +1055,15 @@ type StateVariableDeclaration struct {
 	// TODO: State variables can have override specifier as well.
 }
 
+// ConstantVariableDeclaration represents a constant declared at the file level
+// e.g. `uint256 constant MAX = 100;`. Only the constants can be declared
+// outside of the contracts.
+type ConstantVariableDeclaration struct {
+	Name  *Identifier // variable name
+	Type  Type        // e.g. ElementaryType
+	Value Expression  // initial value; nil only if the source is invalid
+}
+
 type EventDeclaration struct {
 	Pos         token.Pos       // position of the "event" keyword
 	Name        *Identifier     // event name
@@ -754,19 +1071,83 @@ type EventDeclaration struct {
 	IsAnonymous bool            // whether the event is anonymous; true if anonymous, false if not (default)
 }
 
+// StructDeclaration represents a struct definition e.g.
+// `struct Position { uint256 amount; address owner; }`. The members are
+// the params without the data location.
+type StructDeclaration struct {
+	Pos        token.Pos   // position of the "struct" keyword
+	Name       *Identifier // struct name
+	Members    []*Param    // struct members in the order of the declaration
+	RightBrace token.Pos   // position of the right curly brace
+}
+
+// EnumDeclaration represents an enum definition e.g.
+// `enum Status { Active, Paused }`.
+type EnumDeclaration struct {
+	Pos        token.Pos     // position of the "enum" keyword
+	Name       *Identifier   // enum name
+	Members    []*Identifier // enum members in the order of the declaration
+	RightBrace token.Pos     // position of the right curly brace
+}
+
+// ErrorDeclaration represents a custom error definition e.g.
+// `error Unauthorized(address caller);`.
+type ErrorDeclaration struct {
+	Pos       token.Pos   // position of the "error" keyword
+	Name      *Identifier // error name
+	Params    *ParamList  // error parameters
+	Semicolon token.Pos   // position of the semicolon
+}
+
 // Start() and End() implementations for Declaration type Nodes
 
-func (o *UsingForObject) Start() token.Pos { return o.Path.Start() }
+func (s *ImportSymbol) Start() token.Pos {
+	if s.Name != nil {
+		return s.Name.Start()
+	}
+	if s.Alias != nil {
+		return s.Alias.Start()
+	}
+	return 0
+}
+func (s *ImportSymbol) End() token.Pos {
+	if s.Alias != nil {
+		return s.Alias.End()
+	}
+	if s.Name != nil {
+		return s.Name.End()
+	}
+	return 0
+}
+func (d *ImportDirective) Start() token.Pos { return d.Pos }
+func (d *ImportDirective) End() token.Pos   { return d.Semicolon + 1 }
+func (o *UsingForObject) Start() token.Pos {
+	if o.Path != nil {
+		return o.Path.Start()
+	}
+	return o.Alias.Pos
+}
 func (o *UsingForObject) End() token.Pos {
 	if o.Alias.Type != token.ILLEGAL {
 		return token.Pos(int(o.Alias.Pos) + len(o.Alias.Literal))
 	}
-	return o.Path.End()
+	if o.Path != nil {
+		return o.Path.End()
+	}
+	return 0
 }
 func (d *UsingForDirective) Start() token.Pos { return d.Pos }
 func (d *UsingForDirective) End() token.Pos   { return d.Semicolon + 1 }
 func (d *ContractBase) Start() token.Pos      { return d.Pos }
-func (d *ContractBase) End() token.Pos        { return d.Body.End() }
+func (d *ContractBase) End() token.Pos {
+	if d.Body != nil {
+		return d.Body.End()
+	}
+	if d.Name != nil {
+		return d.Name.End()
+	}
+	return d.Pos
+}
 func (d *ContractBody) Start() token.Pos      { return d.LeftBrace }
 func (d *ContractBody) End() token.Pos        { return d.RightBrace + 1 }
 func (d *OverrideSpecifier) Start() token.Pos { return d.Pos }
@@ -785,31 +1166,183 @@ func (d *ModifierDeclaration) End() token.Pos {
 	// It's an abstract modifier ending with a semicolon.
 	return d.Semicolon + 1
 }
-func (d *StateVariableDeclaration) Start() token.Pos { return d.Type.Start() }
-func (d *StateVariableDeclaration) End() token.Pos   { return d.Value.End() }
-func (d *FunctionDeclaration) Start() token.Pos      { return d.Name.Start() }
-func (d *FunctionDeclaration) End() token.Pos        { return d.Body.End() }
-func (d *EventDeclaration) Start() token.Pos         { return d.Pos }
+func (d *StateVariableDeclaration) Start() token.Pos {
+	if d.Type != nil {
+		return d.Type.Start()
+	}
+	if d.Name != nil {
+		return d.Name.Start()
+	}
+	return 0
+}
+func (d *StateVariableDeclaration) End() token.Pos {
+	if d.Value != nil {
+		return d.Value.End()
+	}
+	if d.Name != nil {
+		return d.Name.End()
+	}
+	if d.Type != nil {
+		return d.Type.End()
+	}
+	return 0
+}
+func (d *ModifierInvocation) Start() token.Pos {
+	if d.Name != nil {
+		return d.Name.Start()
+	}
+	return d.Opening
+}
+func (d *ModifierInvocation) End() token.Pos {
+	if d.Closing > 0 {
+		return d.Closing + 1
+	}
+	if d.Name != nil {
+		return d.Name.End()
+	}
+	return d.Opening
+}
+func (d *FunctionDeclaration) Start() token.Pos { return d.Pos }
+func (d *FunctionDeclaration) End() token.Pos {
+	if d.Body != nil {
+		return d.Body.End()
+	}
+	if d.Semicolon > 0 {
+		return d.Semicolon + 1
+	}
+	if d.Params != nil {
+		return d.Params.End()
+	}
+	if d.Name != nil {
+		return d.Name.End()
+	}
+	return d.Pos + 8 // length of "function"
+}
+func (d *ConstructorDeclaration) Start() token.Pos { return d.Pos }
+func (d *ConstructorDeclaration) End() token.Pos {
+	if d.Body != nil {
+		return d.Body.End()
+	}
+	if d.Params != nil {
+		return d.Params.End()
+	}
+	return d.Pos + 11 // length of "constructor"
+}
+func (d *SpecialFunctionDeclaration) Start() token.Pos { return d.Kind.Pos }
+func (d *SpecialFunctionDeclaration) End() token.Pos {
+	if d.Body != nil {
+		return d.Body.End()
+	}
+	if d.Semicolon > 0 {
+		return d.Semicolon + 1
+	}
+	if d.Params != nil {
+		return d.Params.End()
+	}
+	return token.Pos(int(d.Kind.Pos) + len(d.Kind.Literal))
+}
+func (d *ConstantVariableDeclaration) Start() token.Pos {
+	if d.Type != nil {
+		return d.Type.Start()
+	}
+	if d.Name != nil {
+		return d.Name.Start()
+	}
+	return 0
+}
+func (d *ConstantVariableDeclaration) End() token.Pos {
+	if d.Value != nil {
+		return d.Value.End()
+	}
+	if d.Name != nil {
+		return d.Name.End()
+	}
+	if d.Type != nil {
+		return d.Type.End()
+	}
+	return 0
+}
+func (d *EventDeclaration) Start() token.Pos { return d.Pos }
 
 // TODO: This is incorrect for anonymous events. They have the anonymous keyword
 // after the params.
-func (d *EventDeclaration) End() token.Pos { return d.Params.Closing }
+func (d *EventDeclaration) End() token.Pos {
+	if d.Params != nil {
+		return d.Params.Closing + 1
+	}
+	if d.Name != nil {
+		return d.Name.End()
+	}
+	return d.Pos + 5 // length of "event"
+}
+func (d *StructDeclaration) Start() token.Pos { return d.Pos }
+func (d *StructDeclaration) End() token.Pos   { return d.RightBrace + 1 }
+func (d *EnumDeclaration) Start() token.Pos   { return d.Pos }
+func (d *EnumDeclaration) End() token.Pos     { return d.RightBrace + 1 }
+func (d *ErrorDeclaration) Start() token.Pos  { return d.Pos }
+func (d *ErrorDeclaration) End() token.Pos    { return d.Semicolon + 1 }
 
 // declarationNode() implementations to ensure that only declaration nodes can
 // be assigned to a Declaration.
 
-func (*UsingForObject) declarationNode()           {}
-func (*UsingForDirective) declarationNode()        {}
-func (*ContractBase) declarationNode()             {}
-func (*ContractBody) declarationNode()             {}
-func (*OverrideSpecifier) declarationNode()        {}
-func (*ModifierDeclaration) declarationNode()      {}
-func (*StateVariableDeclaration) declarationNode() {}
-func (*FunctionDeclaration) declarationNode()      {}
-func (*EventDeclaration) declarationNode()         {}
+func (*ImportSymbol) declarationNode()                {}
+func (*ImportDirective) declarationNode()             {}
+func (*UsingForObject) declarationNode()              {}
+func (*UsingForDirective) declarationNode()           {}
+func (*ContractBase) declarationNode()                {}
+func (*ContractBody) declarationNode()                {}
+func (*OverrideSpecifier) declarationNode()           {}
+func (*ModifierDeclaration) declarationNode()         {}
+func (*StateVariableDeclaration) declarationNode()    {}
+func (*ConstantVariableDeclaration) declarationNode() {}
+func (*ModifierInvocation) declarationNode()          {}
+func (*FunctionDeclaration) declarationNode()         {}
+func (*ConstructorDeclaration) declarationNode()      {}
+func (*SpecialFunctionDeclaration) declarationNode()  {}
+func (*EventDeclaration) declarationNode()            {}
+func (*StructDeclaration) declarationNode()           {}
+func (*EnumDeclaration) declarationNode()             {}
+func (*ErrorDeclaration) declarationNode()            {}
 
 // String() implementations for Declarations
 
+func (s *ImportSymbol) String() string {
+	if s.Alias != nil {
+		return s.Name.String() + " as " + s.Alias.String()
+	}
+	return s.Name.String()
+}
+
+func (d *ImportDirective) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("import ")
+
+	switch {
+	case len(d.Symbols) > 0:
+		out.WriteString("{")
+		for i, sym := range d.Symbols {
+			if i > 0 {
+				out.WriteString(", ")
+			}
+			out.WriteString(sym.String())
+		}
+		out.WriteString("} from ")
+		out.WriteString(d.Path.Kind.Literal)
+	case d.UnitAlias != nil:
+		out.WriteString("* as ")
+		out.WriteString(d.UnitAlias.String())
+		out.WriteString(" from ")
+		out.WriteString(d.Path.Kind.Literal)
+	default:
+		out.WriteString(d.Path.Kind.Literal)
+	}
+
+	out.WriteString(";")
+
+	return out.String()
+}
+
 func (o *UsingForObject) String() string {
 	if o.Alias.Type != token.ILLEGAL {
 		return o.Path.String() + " as " + o.Alias.Literal
@@ -961,6 +1494,38 @@ func (d *StateVariableDeclaration) String() string {
 	return out.String()
 }
 
+func (d *ConstantVariableDeclaration) String() string {
+	var out bytes.Buffer
+	out.WriteString(d.Type.String())
+	out.WriteString(" constant ")
+	out.WriteString(d.Name.String())
+
+	if d.Value != nil {
+		out.WriteString(" = ")
+		out.WriteString(d.Value.String())
+	}
+
+	out.WriteString(";")
+
+	return out.String()
+}
+
+func (d *ModifierInvocation) String() string {
+	var out bytes.Buffer
+	out.WriteString(d.Name.String())
+	if d.Opening > 0 {
+		out.WriteString("(")
+		for i, arg := range d.Args {
+			if i > 0 {
+				out.WriteString(", ")
+			}
+			out.WriteString(arg.String())
+		}
+		out.WriteString(")")
+	}
+	return out.String()
+}
+
 // TODO: Implement String() for FunctionDeclaration
 func (d *FunctionDeclaration) String() string {
 	var out bytes.Buffer
@@ -973,6 +1538,64 @@ func (d *FunctionDeclaration) String() string {
 	return out.String()
 }
 
+func (d *ConstructorDeclaration) String() string {
+	var out bytes.Buffer
+	out.WriteString("constructor")
+	out.WriteString(d.Params.String())
+
+	for _, modifier := range d.Modifiers {
+		out.WriteString(" ")
+		out.WriteString(modifier.String())
+	}
+
+	if d.Mutability != 0 {
+		out.WriteString(" ")
+		out.WriteString(d.Mutability.String())
+	}
+
+	if d.Body != nil {
+		out.WriteString(" ")
+		out.WriteString(d.Body.String())
+	}
+
+	return out.String()
+}
+
+func (d *SpecialFunctionDeclaration) String() string {
+	var out bytes.Buffer
+	out.WriteString(d.Kind.Literal)
+	out.WriteString(d.Params.String())
+
+	if d.Visibility != 0 {
+		out.WriteString(" ")
+		out.WriteString(d.Visibility.String())
+	}
+
+	if d.Mutability != 0 {
+		out.WriteString(" ")
+		out.WriteString(d.Mutability.String())
+	}
+
+	for _, modifier := range d.Modifiers {
+		out.WriteString(" ")
+		out.WriteString(modifier.String())
+	}
+
+	if d.Results != nil {
+		out.WriteString(" returns ")
+		out.WriteString(d.Results.String())
+	}
+
+	if d.Body != nil {
+		out.WriteString(" ")
+		out.WriteString(d.Body.String())
+	} else {
+		out.WriteString(";")
+	}
+
+	return out.String()
+}
+
 func (d *EventDeclaration) String() string {
 	var out bytes.Buffer
 	out.WriteString("event ")
@@ -985,6 +1608,46 @@ func (d *EventDeclaration) String() string {
 	return out.String()
 }
 
+func (d *StructDeclaration) String() string {
+	var out bytes.Buffer
+	out.WriteString("struct ")
+	out.WriteString(d.Name.String())
+	out.WriteString(" { ")
+	for _, member := range d.Members {
+		out.WriteString(member.String())
+		out.WriteString("; ")
+	}
+	out.WriteString("}")
+
+	return out.String()
+}
+
+func (d *EnumDeclaration) String() string {
+	var out bytes.Buffer
+	out.WriteString("enum ")
+	out.WriteString(d.Name.String())
+	out.WriteString(" { ")
+	for i, member := range d.Members {
+		if i > 0 {
+			out.WriteString(", ")
+		}
+		out.WriteString(member.String())
+	}
+	out.WriteString(" }")
+
+	return out.String()
+}
+
+func (d *ErrorDeclaration) String() string {
+	var out bytes.Buffer
+	out.WriteString("error ")
+	out.WriteString(d.Name.String())
+	out.WriteString(d.Params.String())
+	out.WriteString(";")
+
+	return out.String()
+}
+
 /*~*~*~*~*~*~*~*~*~*~*~*~*~* Files ~*~*~*~*~*~*~*~*~*~*~*~*~*~*~*/
 
 // In Solidity grammar it's called "SourceUnit" and represents the entire source
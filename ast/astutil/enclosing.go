@@ -0,0 +1,108 @@
+package astutil
+
+import (
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+// PathEnclosingInterval returns the node that encloses the source interval
+// [start, end), and all its ancestors up to the AST root.
+//
+// The first element of the path is the innermost node, the last one is
+// always the root *ast.File. If no node other than the root encloses the
+// interval, the path contains just the root.
+//
+// The exact result is true if the interval contains only the innermost node
+// and nothing else, i.e. the interval is equal to [Start(), End()) of path[0].
+//
+// A zero-width interval (start == end) is useful to ask about a cursor
+// position e.g. in the LSP hover or go-to-definition requests. In that case
+// a node ending exactly at the cursor is considered to enclose it.
+func PathEnclosingInterval(root *ast.File, start, end token.Pos) (path []ast.Node, exact bool) {
+	if end < start {
+		start, end = end, start
+	}
+
+	Apply(root, func(c *Cursor) bool {
+		n := c.Node()
+		if n == nil {
+			return false
+		}
+
+		// Only descend into the last node on the path. Sibling nodes can't
+		// contain the interval, unless their positions are broken.
+		if len(path) > 0 && c.Parent() != path[len(path)-1] {
+			return false
+		}
+
+		if n != ast.Node(root) {
+			nodeStart, nodeEnd, ok := nodeRange(n)
+			if !ok || start < nodeStart || nodeEnd < end {
+				return false
+			}
+		}
+
+		path = append(path, n)
+		return true
+	}, nil)
+
+	// Reverse the path so that the innermost node comes first.
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	if len(path) > 0 {
+		nodeStart, nodeEnd, ok := nodeRange(path[0])
+		exact = ok && nodeStart == start && nodeEnd == end
+	}
+
+	return path, exact
+}
+
+// Parents returns a map from every node in the tree rooted at root to its
+// direct parent. The root itself is not present in the map.
+//
+// It is useful when a lot of "what is above me" questions are asked about
+// the same tree, e.g. by detectors that need to know in which function or
+// contract a node is located. For a single lookup PathEnclosingInterval
+// is cheaper.
+func Parents(root ast.Node) map[ast.Node]ast.Node {
+	parents := make(map[ast.Node]ast.Node)
+
+	Apply(root, func(c *Cursor) bool {
+		n := c.Node()
+		if n == nil {
+			return false
+		}
+		if n != root {
+			parents[n] = c.Parent()
+		}
+		return true
+	}, nil)
+
+	return parents
+}
+
+// Enclosing returns the innermost node of type T on the path returned from
+// PathEnclosingInterval, e.g. Enclosing[*ast.FunctionDeclaration](path)
+// answers "in which function is this node?". It returns false if there is
+// no such node on the path.
+func Enclosing[T ast.Node](path []ast.Node) (T, bool) {
+	for _, n := range path {
+		if typed, ok := n.(T); ok {
+			return typed, true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
+
+// nodeRange returns the [Start(), End()) interval of a node. Partially
+// parsed nodes (e.g. from a half-written file in the LSP) can miss the
+// children their positions are computed from, so the interval can be
+// broken. For such nodes the returned ok is false.
+func nodeRange(n ast.Node) (start, end token.Pos, ok bool) {
+	start, end = n.Start(), n.End()
+	return start, end, start <= end
+}
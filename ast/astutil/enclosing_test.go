@@ -0,0 +1,150 @@
+package astutil_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/ast/astutil"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+func Test_PathEnclosingInterval(t *testing.T) {
+	src := `contract Vault {
+    function deposit(uint256 amount) {
+        balance = balance + amount;
+    }
+}`
+	file := parseSource(t, src)
+
+	// Point at the second 'balance', the left operand of the '+'.
+	offset := token.Pos(strings.Index(src, "balance + amount"))
+
+	path, exact := astutil.PathEnclosingInterval(file, offset, offset+token.Pos(len("balance")))
+
+	expectedTypes := []any{
+		(*ast.Identifier)(nil),
+		(*ast.InfixExpression)(nil), // balance + amount
+		(*ast.InfixExpression)(nil), // balance = ...
+		(*ast.ExpressionStatement)(nil),
+		(*ast.BlockStatement)(nil),
+		(*ast.FunctionDeclaration)(nil),
+		(*ast.ContractBody)(nil),
+		(*ast.ContractDeclaration)(nil),
+		(*ast.File)(nil),
+	}
+
+	if len(path) != len(expectedTypes) {
+		t.Fatalf("Expected path of length %d, got %d: %v", len(expectedTypes), len(path), path)
+	}
+
+	for i, expected := range expectedTypes {
+		if reflect.TypeOf(path[i]) != reflect.TypeOf(expected) {
+			t.Fatalf("Node %d: expected %T, got %T", i, expected, path[i])
+		}
+	}
+
+	if !exact {
+		t.Errorf("Expected the interval to match the identifier exactly")
+	}
+
+	if ident := path[0].(*ast.Identifier); ident.Value != "balance" || ident.Pos != offset {
+		t.Errorf("Expected 'balance' at %d, got '%s' at %d", offset, ident.Value, ident.Pos)
+	}
+
+	fn, ok := astutil.Enclosing[*ast.FunctionDeclaration](path)
+	if !ok || fn.Name.Value != "deposit" {
+		t.Fatalf("Expected enclosing function 'deposit', got %v", fn)
+	}
+
+	if _, ok := astutil.Enclosing[*ast.EventDeclaration](path); ok {
+		t.Fatalf("Expected no enclosing event declaration")
+	}
+}
+
+func Test_PathEnclosingInterval_OutsideOfDeclarations(t *testing.T) {
+	src := `contract A {}
+
+
+contract B {}`
+	file := parseSource(t, src)
+
+	offset := token.Pos(strings.Index(src, "\n\n"))
+	path, exact := astutil.PathEnclosingInterval(file, offset+1, offset+1)
+
+	if len(path) != 1 {
+		t.Fatalf("Expected only the root in the path, got %d nodes", len(path))
+	}
+
+	if _, ok := path[0].(*ast.File); !ok {
+		t.Fatalf("Expected the root to be a File, got %T", path[0])
+	}
+
+	if exact {
+		t.Fatalf("Expected the interval to not match the file exactly")
+	}
+}
+
+func Test_PathEnclosingInterval_PartialNodes(t *testing.T) {
+	// function f
+	// event E
+	// uint256 x = ;
+	// The partially parsed nodes miss the children their positions are
+	// computed from.
+	function := &ast.FunctionDeclaration{
+		Pos:  0,
+		Name: &ast.Identifier{Pos: 9, Value: "f"},
+	}
+	event := &ast.EventDeclaration{
+		Pos:  11,
+		Name: &ast.Identifier{Pos: 17, Value: "E"},
+	}
+	variable := &ast.StateVariableDeclaration{
+		Type: &ast.UserDefinedType{},
+		Name: &ast.Identifier{Pos: 27, Value: "x"},
+	}
+	file := &ast.File{Declarations: []ast.Declaration{function, event, variable}}
+
+	path, exact := astutil.PathEnclosingInterval(file, 9, 10)
+	if len(path) != 3 || path[0] != function.Name || path[1] != function {
+		t.Fatalf("Expected the path [Identifier FunctionDeclaration File], got %v", path)
+	}
+	if !exact {
+		t.Errorf("Expected the interval to match the function name exactly")
+	}
+
+	path, _ = astutil.PathEnclosingInterval(file, 17, 17)
+	if len(path) != 3 || path[1] != event {
+		t.Fatalf("Expected the path [Identifier EventDeclaration File], got %v", path)
+	}
+
+	path, _ = astutil.PathEnclosingInterval(file, 27, 27)
+	if len(path) != 3 || path[1] != variable {
+		t.Fatalf("Expected the path [Identifier StateVariableDeclaration File], got %v", path)
+	}
+}
+
+func Test_Parents(t *testing.T) {
+	src := `function foo() {
+    return a + b;
+}`
+	file := parseSource(t, src)
+	parents := astutil.Parents(file)
+
+	if _, ok := parents[file]; ok {
+		t.Fatalf("Expected the root to have no parent")
+	}
+
+	fn := file.Declarations[0].(*ast.FunctionDeclaration)
+	ret := fn.Body.Statements[0].(*ast.ReturnStatement)
+	infix := ret.Result.(*ast.InfixExpression)
+
+	// Walk up from the 'b' identifier to the root.
+	chain := []ast.Node{infix.Right, infix, ret, fn.Body, fn, file}
+	for i := 0; i < len(chain)-1; i++ {
+		if parents[chain[i]] != chain[i+1] {
+			t.Fatalf("Expected parent of %T to be %T, got %T", chain[i], chain[i+1], parents[chain[i]])
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package astutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+// JSONNode is the JSON representation of an AST node. The encoding is
+// stable: the node type is the name of the Go type in the ast package,
+// attributes are keyed by the struct field names and children are listed
+// in source order, so the output of two parser versions can be diffed.
+//
+// For example, the identifier `x` at the beginning of a file is encoded as:
+//
+//	{
+//	  "type": "Identifier",
+//	  "start": {"offset": 0, "line": 1, "column": 1},
+//	  "end": {"offset": 1, "line": 1, "column": 2},
+//	  "attributes": {"Value": "x"}
+//	}
+type JSONNode struct {
+	Type       string            `json:"type"`
+	Field      string            `json:"field,omitempty"` // field of the parent containing the node e.g. "Left"
+	Index      *int              `json:"index,omitempty"` // index in the parent's field if the field is a list
+	Start      *JSONPosition     `json:"start,omitempty"` // nil if the position can't be computed
+	End        *JSONPosition     `json:"end,omitempty"`   // nil if the position can't be computed
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Children   []*JSONNode       `json:"children,omitempty"`
+}
+
+type JSONPosition struct {
+	Offset int `json:"offset"`
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// MarshalJSON returns the indented JSON encoding of the file's AST.
+func MarshalJSON(file *ast.File) ([]byte, error) {
+	return json.MarshalIndent(ToJSONNode(file, file.SourceFile), "", "  ")
+}
+
+// ToJSONNode converts the tree rooted at root into its JSON representation.
+// The source file is used to compute the lines and columns of the nodes;
+// if it is nil, only the offsets are reported.
+func ToJSONNode(root ast.Node, sourceFile *token.SourceFile) *JSONNode {
+	var result *JSONNode
+	var stack []*JSONNode
+
+	Apply(root, func(c *Cursor) bool {
+		n := c.Node()
+		if n == nil {
+			return false
+		}
+
+		node := newJSONNode(n, sourceFile)
+		if len(stack) == 0 {
+			result = node
+		} else {
+			node.Field = c.Name()
+			if i := c.Index(); i >= 0 {
+				node.Index = &i
+			}
+			parent := stack[len(stack)-1]
+			parent.Children = append(parent.Children, node)
+		}
+
+		stack = append(stack, node)
+		return true
+	}, func(c *Cursor) bool {
+		stack = stack[:len(stack)-1]
+		return true
+	})
+
+	return result
+}
+
+func newJSONNode(n ast.Node, sourceFile *token.SourceFile) *JSONNode {
+	node := &JSONNode{
+		Type:       strings.TrimPrefix(reflect.TypeOf(n).String(), "*ast."),
+		Attributes: map[string]string{},
+	}
+
+	if start, end, ok := nodeRange(n); ok {
+		node.Start = newJSONPosition(start, sourceFile)
+		node.End = newJSONPosition(end, sourceFile)
+	}
+
+	collectAttributes(reflect.Indirect(reflect.ValueOf(n)), node.Attributes)
+
+	if len(node.Attributes) == 0 {
+		node.Attributes = nil
+	}
+
+	return node
+}
+
+// collectAttributes adds all the fields of a node that are not positions
+// and not other nodes to the attributes map.
+func collectAttributes(v reflect.Value, attrs map[string]string) {
+	nodeType := reflect.TypeOf((*ast.Node)(nil)).Elem()
+	posType := reflect.TypeOf(token.Pos(0))
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		value := v.Field(i)
+
+		// Embedded structs e.g. ContractBase.
+		if field.Anonymous && value.Kind() == reflect.Struct {
+			collectAttributes(value, attrs)
+			continue
+		}
+
+		if !field.IsExported() || field.Type == posType {
+			continue
+		}
+
+		// Children are encoded separately.
+		if field.Type.Implements(nodeType) || field.Type.Kind() == reflect.Interface ||
+			(field.Type.Kind() == reflect.Slice && field.Type.Elem().Implements(nodeType)) {
+			continue
+		}
+
+		switch val := value.Interface().(type) {
+		case token.Token:
+			if val.Type != token.ILLEGAL {
+				attrs[field.Name] = val.Literal
+			}
+		case big.Int:
+			attrs[field.Name] = val.String()
+		case *token.SourceFile:
+			// The source file is reported through the positions.
+		case bool:
+			attrs[field.Name] = strconv.FormatBool(val)
+		case fmt.Stringer:
+			if s := val.String(); s != "" {
+				attrs[field.Name] = s
+			}
+		case string:
+			if val != "" {
+				attrs[field.Name] = val
+			}
+		}
+	}
+}
+
+func newJSONPosition(pos token.Pos, sourceFile *token.SourceFile) *JSONPosition {
+	position := &JSONPosition{Offset: int(pos)}
+	if sourceFile == nil {
+		return position
+	}
+
+	position.Offset = sourceFile.Offset(pos)
+	position.Line, position.Column = sourceFile.GetLineAndColumn(pos)
+
+	return position
+}
@@ -0,0 +1,92 @@
+package astutil_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/ast/astutil"
+)
+
+func Test_MarshalJSON(t *testing.T) {
+	src := `contract Vault is Ownable {
+    uint256 public constant FEE = 100;
+}`
+	file := parseSource(t, src)
+
+	out, err := astutil.MarshalJSON(file)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+
+	var root astutil.JSONNode
+	if err := json.Unmarshal(out, &root); err != nil {
+		t.Fatalf("Could not decode the JSON output: %v", err)
+	}
+
+	if root.Type != "File" {
+		t.Fatalf("Expected root type File, got %s", root.Type)
+	}
+
+	if len(root.Children) != 1 {
+		t.Fatalf("Expected 1 child of the root, got %d", len(root.Children))
+	}
+
+	contract := root.Children[0]
+	if contract.Type != "ContractDeclaration" || contract.Field != "Declarations" || *contract.Index != 0 {
+		t.Fatalf("Unexpected contract node: %s in %s[%d]", contract.Type, contract.Field, *contract.Index)
+	}
+
+	if contract.Attributes["Abstract"] != "false" {
+		t.Errorf("Expected Abstract attribute to be false, got %q", contract.Attributes["Abstract"])
+	}
+
+	expectedChildren := []struct {
+		nodeType string
+		field    string
+	}{
+		{"Identifier", "Name"},
+		{"Identifier", "Parents"},
+		{"ContractBody", "Body"},
+	}
+
+	if len(contract.Children) != len(expectedChildren) {
+		t.Fatalf("Expected %d children of the contract, got %d", len(expectedChildren), len(contract.Children))
+	}
+
+	for i, expected := range expectedChildren {
+		child := contract.Children[i]
+		if child.Type != expected.nodeType || child.Field != expected.field {
+			t.Errorf("Child %d: expected %s in %s, got %s in %s",
+				i, expected.nodeType, expected.field, child.Type, child.Field)
+		}
+	}
+
+	stateVar := contract.Children[2].Children[0]
+	if stateVar.Type != "StateVariableDeclaration" {
+		t.Fatalf("Expected StateVariableDeclaration, got %s", stateVar.Type)
+	}
+
+	expectedAttributes := map[string]string{
+		"Visibility": "public",
+		"Mutability": "constant",
+	}
+
+	if len(stateVar.Attributes) != len(expectedAttributes) {
+		t.Errorf("Expected %d attributes, got %v", len(expectedAttributes), stateVar.Attributes)
+	}
+
+	for key, value := range expectedAttributes {
+		if stateVar.Attributes[key] != value {
+			t.Errorf("Expected attribute %s to be %q, got %q", key, value, stateVar.Attributes[key])
+		}
+	}
+
+	if stateVar.Start == nil || stateVar.Start.Line != 2 || stateVar.Start.Column != 5 {
+		t.Errorf("Expected the state variable to start at 2:5, got %+v", stateVar.Start)
+	}
+
+	// The file ends right after the closing brace of the contract.
+	if root.End == nil || root.End.Offset != len(src) || root.End.Line != 3 || root.End.Column != 2 {
+		t.Errorf("Expected the file to end at offset %d (3:2), got %+v", len(src), root.End)
+	}
+}
@@ -0,0 +1,435 @@
+// Package astutil contains utilities for working with the solbot AST that
+// go beyond the read-only traversal offered by ast.Walk.
+package astutil
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+)
+
+// An ApplyFunc is invoked by Apply for each node n, even if n is nil,
+// before and/or after the node's children, using a Cursor describing
+// the current node and providing operations on it.
+//
+// The return value of ApplyFunc controls the syntax tree traversal.
+// See Apply for details.
+type ApplyFunc func(*Cursor) bool
+
+// Apply traverses a syntax tree recursively, starting with root,
+// and calling pre and post for each node as described below.
+// Apply returns the syntax tree, possibly modified.
+//
+// If pre is not nil, it is called for each node before the node's
+// children are traversed (pre-order). If pre returns false, no
+// children are traversed, and post is not called for that node.
+//
+// If post is not nil, and a prior call of pre didn't return false,
+// post is called for each node after its children are traversed
+// (post-order). If post returns false, traversal is terminated and
+// Apply returns immediately.
+//
+// Only fields that refer to AST nodes are considered children;
+// i.e., token.Pos, token.Token, Visibility, Mutability and other
+// attributes of the nodes are not traversed.
+//
+// Children are traversed in the order in which they appear in the source,
+// e.g. the type of a parameter is visited before its name.
+//
+// Replaced, deleted and inserted nodes keep the positions they were created
+// with. Apply never re-computes offsets, so a node synthesized by a fix
+// should either copy the positions of the node it replaces or leave them at
+// zero. The rewritten tree can be printed back with the String() methods.
+func Apply(root ast.Node, pre, post ApplyFunc) (result ast.Node) {
+	parent := &struct{ ast.Node }{root}
+	defer func() {
+		if r := recover(); r != nil && r != abort {
+			panic(r)
+		}
+		result = parent.Node
+	}()
+	a := &application{pre: pre, post: post}
+	a.apply(parent, "Node", nil, root)
+	return
+}
+
+var abort = new(int) // singleton, to signal termination of Apply
+
+// A Cursor describes a node encountered during Apply.
+// Information about the node and its parent is available
+// from the Node, Parent, Name, and Index methods.
+//
+// If p is a variable of type and value of the current parent node
+// c.Parent(), and f is the field identifier with name c.Name(),
+// the following invariants hold:
+//
+//	p.f            == c.Node()  if c.Index() <  0
+//	p.f[c.Index()] == c.Node()  if c.Index() >= 0
+//
+// The methods Replace, Delete, InsertBefore, and InsertAfter
+// can be used to change the AST without disrupting Apply.
+type Cursor struct {
+	parent ast.Node
+	name   string
+	iter   *iterator // valid if non-nil
+	node   ast.Node
+}
+
+// Node returns the current Node.
+func (c *Cursor) Node() ast.Node { return c.node }
+
+// Parent returns the parent of the current Node.
+func (c *Cursor) Parent() ast.Node { return c.parent }
+
+// Name returns the name of the parent Node field that contains the current Node.
+func (c *Cursor) Name() string { return c.name }
+
+// Index reports the index >= 0 of the current Node in the slice of Nodes that
+// contains it, or a value < 0 if the current Node is not part of a slice.
+// The index of the current node changes if InsertBefore is called while
+// processing the current node.
+func (c *Cursor) Index() int {
+	if c.iter != nil {
+		return c.iter.index
+	}
+	return -1
+}
+
+// field returns the current node's parent field value.
+func (c *Cursor) field() reflect.Value {
+	return reflect.Indirect(reflect.ValueOf(c.parent)).FieldByName(c.name)
+}
+
+// Replace replaces the current Node with n.
+// The replacement node is not walked by Apply.
+func (c *Cursor) Replace(n ast.Node) {
+	v := c.field()
+	if i := c.Index(); i >= 0 {
+		v = v.Index(i)
+	}
+	v.Set(valueOf(n, v.Type()))
+	c.node = n
+}
+
+// Delete deletes the current Node from its containing slice.
+// If the current Node is not part of a slice, Delete panics.
+// As a special case, if the current node is an element of a
+// VariableDeclarationTupleStatement or a TupleExpression, Delete leaves an
+// empty slot (nil) in its place, since the slots of a tuple are positional.
+func (c *Cursor) Delete() {
+	i := c.Index()
+	if i < 0 {
+		panic("Delete node not contained in slice")
+	}
+	v := c.field()
+	switch c.parent.(type) {
+	case *ast.VariableDeclarationTupleStatement, *ast.TupleExpression:
+		v.Index(i).Set(reflect.Zero(v.Type().Elem()))
+		return
+	}
+	l := v.Len()
+	reflect.Copy(v.Slice(i, l), v.Slice(i+1, l))
+	v.Index(l - 1).Set(reflect.Zero(v.Type().Elem()))
+	v.SetLen(l - 1)
+	c.iter.step--
+}
+
+// InsertAfter inserts n after the current Node in its containing slice.
+// If the current Node is not part of a slice, InsertAfter panics.
+// Apply does not walk n.
+func (c *Cursor) InsertAfter(n ast.Node) {
+	i := c.Index()
+	if i < 0 {
+		panic("InsertAfter node not contained in slice")
+	}
+	v := c.field()
+	v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+	l := v.Len()
+	reflect.Copy(v.Slice(i+2, l), v.Slice(i+1, l))
+	v.Index(i + 1).Set(valueOf(n, v.Type().Elem()))
+	c.iter.step++
+}
+
+// InsertBefore inserts n before the current Node in its containing slice.
+// If the current Node is not part of a slice, InsertBefore panics.
+// Apply will not walk n.
+func (c *Cursor) InsertBefore(n ast.Node) {
+	i := c.Index()
+	if i < 0 {
+		panic("InsertBefore node not contained in slice")
+	}
+	v := c.field()
+	v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+	l := v.Len()
+	reflect.Copy(v.Slice(i+1, l), v.Slice(i, l))
+	v.Index(i).Set(valueOf(n, v.Type().Elem()))
+	c.iter.index++
+}
+
+// valueOf converts n into a value assignable to a field of type t. A nil n
+// becomes the zero value of t, so that e.g. an optional expression can be
+// removed with Replace(nil).
+func valueOf(n ast.Node, t reflect.Type) reflect.Value {
+	if n == nil {
+		return reflect.Zero(t)
+	}
+	v := reflect.ValueOf(n)
+	if !v.Type().AssignableTo(t) {
+		panic(fmt.Sprintf("astutil: cannot use %T as %s", n, t))
+	}
+	return v
+}
+
+// application carries all the shared data so we can pass it around cheaply.
+type application struct {
+	pre, post ApplyFunc
+	cursor    Cursor
+	iter      iterator
+}
+
+func (a *application) apply(parent ast.Node, name string, iter *iterator, n ast.Node) {
+	// Convert typed nil into untyped nil.
+	if v := reflect.ValueOf(n); v.Kind() == reflect.Ptr && v.IsNil() {
+		n = nil
+	}
+
+	// Avoid heap-allocating a new cursor for each apply call; reuse a.cursor instead.
+	saved := a.cursor
+	a.cursor.parent = parent
+	a.cursor.name = name
+	a.cursor.iter = iter
+	a.cursor.node = n
+
+	if a.pre != nil && !a.pre(&a.cursor) {
+		a.cursor = saved
+		return
+	}
+
+	// Walk children. Fields are addressed by name, so embedded fields
+	// (e.g. the Name and Body of ContractBase) can be used directly.
+	switch n := n.(type) {
+	case nil:
+		// Nothing to do.
+
+	// Files
+	case *ast.File:
+		a.applyList(n, "Declarations")
+
+	// Declarations
+	case *ast.ContractDeclaration:
+		a.apply(n, "Name", nil, n.Name)
+		a.applyList(n, "Parents")
+		a.apply(n, "Body", nil, n.Body)
+
+	case *ast.ContractBody:
+		a.applyList(n, "Declarations")
+
+	case *ast.FunctionDeclaration:
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "Params", nil, n.Params)
+		a.apply(n, "Override", nil, n.Override)
+		a.applyList(n, "Modifiers")
+		a.apply(n, "Results", nil, n.Results)
+		a.apply(n, "Body", nil, n.Body)
+
+	case *ast.ConstructorDeclaration:
+		a.apply(n, "Params", nil, n.Params)
+		a.applyList(n, "Modifiers")
+		a.apply(n, "Body", nil, n.Body)
+
+	case *ast.SpecialFunctionDeclaration:
+		a.apply(n, "Params", nil, n.Params)
+		a.apply(n, "Override", nil, n.Override)
+		a.applyList(n, "Modifiers")
+		a.apply(n, "Results", nil, n.Results)
+		a.apply(n, "Body", nil, n.Body)
+
+	case *ast.ModifierInvocation:
+		a.apply(n, "Name", nil, n.Name)
+		a.applyList(n, "Args")
+
+	case *ast.ModifierDeclaration:
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "Params", nil, n.Params)
+		a.apply(n, "Override", nil, n.Override)
+		a.apply(n, "Body", nil, n.Body)
+
+	case *ast.OverrideSpecifier:
+		a.applyList(n, "Overrides")
+
+	case *ast.StateVariableDeclaration:
+		a.apply(n, "Type", nil, n.Type)
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "Value", nil, n.Value)
+
+	case *ast.ConstantVariableDeclaration:
+		a.apply(n, "Type", nil, n.Type)
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "Value", nil, n.Value)
+
+	case *ast.EventDeclaration:
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "Params", nil, n.Params)
+
+	case *ast.StructDeclaration:
+		a.apply(n, "Name", nil, n.Name)
+		a.applyList(n, "Members")
+
+	case *ast.EnumDeclaration:
+		a.apply(n, "Name", nil, n.Name)
+		a.applyList(n, "Members")
+
+	case *ast.ErrorDeclaration:
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "Params", nil, n.Params)
+
+	case *ast.ImportDirective:
+		a.applyList(n, "Symbols")
+		a.apply(n, "UnitAlias", nil, n.UnitAlias)
+		a.apply(n, "Path", nil, n.Path)
+
+	case *ast.ImportSymbol:
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "Alias", nil, n.Alias)
+
+	case *ast.UsingForDirective:
+		a.apply(n, "LibraryName", nil, n.LibraryName)
+		a.applyList(n, "List")
+		a.apply(n, "ForType", nil, n.ForType)
+
+	case *ast.UsingForObject:
+		a.apply(n, "Path", nil, n.Path)
+
+	// Types
+	case *ast.ParamList:
+		a.applyList(n, "List")
+
+	case *ast.EventParamList:
+		a.applyList(n, "List")
+
+	case *ast.Param:
+		a.apply(n, "Type", nil, n.Type)
+		a.apply(n, "Name", nil, n.Name)
+
+	case *ast.EventParam:
+		a.apply(n, "Type", nil, n.Type)
+		a.apply(n, "Name", nil, n.Name)
+
+	case *ast.UserDefinedType:
+		a.apply(n, "Name", nil, n.Name)
+
+	// Statements
+	case *ast.BlockStatement:
+		a.applyList(n, "Statements")
+
+	case *ast.UncheckedBlockStatement:
+		a.applyList(n, "Statements")
+
+	case *ast.VariableDeclarationStatement:
+		a.apply(n, "Type", nil, n.Type)
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "Value", nil, n.Value)
+
+	case *ast.VariableDeclarationTupleStatement:
+		a.applyList(n, "Declarations")
+		a.apply(n, "Value", nil, n.Value)
+
+	case *ast.ReturnStatement:
+		a.apply(n, "Result", nil, n.Result)
+
+	case *ast.ExpressionStatement:
+		a.apply(n, "Expression", nil, n.Expression)
+
+	case *ast.IfStatement:
+		a.apply(n, "Condition", nil, n.Condition)
+		a.apply(n, "Consequence", nil, n.Consequence)
+		a.apply(n, "Alternative", nil, n.Alternative)
+
+	case *ast.EmitStatement:
+		a.apply(n, "Expression", nil, n.Expression)
+
+	case *ast.RevertStatement:
+		a.apply(n, "Expression", nil, n.Expression)
+
+	case *ast.AssemblyStatement:
+		a.applyList(n, "Identifiers")
+
+	// Expressions
+	case *ast.PrefixExpression:
+		a.apply(n, "Right", nil, n.Right)
+
+	case *ast.InfixExpression:
+		a.apply(n, "Left", nil, n.Left)
+		a.apply(n, "Right", nil, n.Right)
+
+	case *ast.PostfixExpression:
+		a.apply(n, "Left", nil, n.Left)
+
+	case *ast.CallExpression:
+		a.apply(n, "Ident", nil, n.Ident)
+		a.applyList(n, "Args")
+
+	case *ast.CallOptionsExpression:
+		a.apply(n, "Expression", nil, n.Expression)
+		a.applyList(n, "Names")
+		a.applyList(n, "Values")
+
+	case *ast.MemberAccessExpression:
+		a.apply(n, "Expression", nil, n.Expression)
+		a.apply(n, "Member", nil, n.Member)
+
+	case *ast.TupleExpression:
+		a.applyList(n, "Components")
+
+	case *ast.ElementaryTypeExpression:
+		a.apply(n, "Value", nil, n.Value)
+
+	////// Leaf Node Cases //////
+	case
+		*ast.Identifier,
+		*ast.NumberLiteral,
+		*ast.BooleanLiteral,
+		*ast.StringLiteral,
+		*ast.ElementaryType:
+		// No children to walk.
+
+	default:
+		panic(fmt.Sprintf("Apply: unexpected node type %T", n))
+	}
+
+	if a.post != nil && !a.post(&a.cursor) {
+		panic(abort)
+	}
+
+	a.cursor = saved
+}
+
+// An iterator controls iteration over a slice of nodes.
+type iterator struct {
+	index, step int
+}
+
+func (a *application) applyList(parent ast.Node, name string) {
+	// Avoid heap-allocating a new iterator for each applyList call; reuse a.iter instead.
+	saved := a.iter
+	a.iter.index = 0
+	for {
+		// Must reload parent.name each time, since cursor modifications might change it.
+		v := reflect.Indirect(reflect.ValueOf(parent)).FieldByName(name)
+		if a.iter.index >= v.Len() {
+			break
+		}
+
+		// Element x may be nil in a bad AST - be cautious.
+		var x ast.Node
+		if e := v.Index(a.iter.index); e.IsValid() && !e.IsNil() {
+			x = e.Interface().(ast.Node)
+		}
+
+		a.iter.step = 1
+		a.apply(parent, name, &a.iter, x)
+		a.iter.index += a.iter.step
+	}
+	a.iter = saved
+}
@@ -0,0 +1,145 @@
+package astutil_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/ast/astutil"
+	"github.com/ChmielewskiKamil/solbot/parser"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+func Test_Apply_ReplaceIdentifier(t *testing.T) {
+	src := `contract Test {
+    uint256 constant maxSupply = 100;
+    uint256 balance = 10;
+}`
+	file := parseSource(t, src)
+
+	astutil.Apply(file, func(c *astutil.Cursor) bool {
+		v, ok := c.Node().(*ast.StateVariableDeclaration)
+		if ok && v.Mutability == ast.Constant {
+			// Keep the position of the replaced identifier.
+			v.Name = &ast.Identifier{Pos: v.Name.Pos, Value: "MAX_SUPPLY"}
+		}
+		return true
+	}, nil)
+
+	astutil.Apply(file, func(c *astutil.Cursor) bool {
+		if ident, ok := c.Node().(*ast.Identifier); ok && ident.Value == "balance" {
+			if c.Name() != "Name" {
+				t.Fatalf("Expected the cursor to be on the 'Name' field, got: %s", c.Name())
+			}
+			if _, ok := c.Parent().(*ast.StateVariableDeclaration); !ok {
+				t.Fatalf("Expected parent to be StateVariableDeclaration, got: %T", c.Parent())
+			}
+			c.Replace(&ast.Identifier{Pos: ident.Pos, Value: "_balance"})
+		}
+		return true
+	}, nil)
+
+	contract := file.Declarations[0].(*ast.ContractDeclaration)
+	expected := []string{"MAX_SUPPLY", "_balance"}
+	for i, decl := range contract.Body.Declarations {
+		v := decl.(*ast.StateVariableDeclaration)
+		if v.Name.Value != expected[i] {
+			t.Errorf("Declaration %d: expected name '%s', got '%s'", i, expected[i], v.Name.Value)
+		}
+	}
+}
+
+func Test_Apply_DeleteAndInsertStatements(t *testing.T) {
+	src := `function foo() {
+    a = 1;
+    b = 2;
+    c = 3;
+}`
+	file := parseSource(t, src)
+
+	astutil.Apply(file, func(c *astutil.Cursor) bool {
+		stmt, ok := c.Node().(*ast.ExpressionStatement)
+		if !ok {
+			return true
+		}
+
+		switch stmt.String() {
+		case "(a = 1)":
+			c.InsertBefore(newAssignment("x", stmt.Pos))
+		case "(b = 2)":
+			c.Delete()
+		case "(c = 3)":
+			c.InsertAfter(newAssignment("y", stmt.Pos))
+		}
+		return false
+	}, nil)
+
+	fn := file.Declarations[0].(*ast.FunctionDeclaration)
+
+	expected := []string{"x", "(a = 1)", "(c = 3)", "y"}
+	if len(fn.Body.Statements) != len(expected) {
+		t.Fatalf("Expected %d statements, got %d", len(expected), len(fn.Body.Statements))
+	}
+
+	for i, stmt := range fn.Body.Statements {
+		if stmt.String() != expected[i] {
+			t.Errorf("Statement %d: expected '%s', got '%s'", i, expected[i], stmt.String())
+		}
+	}
+}
+
+func Test_Apply_PostAbortsTraversal(t *testing.T) {
+	src := `contract Test {
+    uint256 a = 1;
+    uint256 b = 2;
+}`
+	file := parseSource(t, src)
+
+	visited := 0
+	result := astutil.Apply(file, nil, func(c *astutil.Cursor) bool {
+		if _, ok := c.Node().(*ast.StateVariableDeclaration); ok {
+			visited++
+			return false
+		}
+		return true
+	})
+
+	if visited != 1 {
+		t.Fatalf("Expected traversal to stop after the first declaration, visited %d", visited)
+	}
+
+	if result != ast.Node(file) {
+		t.Fatalf("Expected Apply to return the root node")
+	}
+}
+
+func Test_Apply_ReplaceRoot(t *testing.T) {
+	file := parseSource(t, `contract Test {}`)
+	replacement := &ast.File{Name: "replacement.sol"}
+
+	result := astutil.Apply(file, func(c *astutil.Cursor) bool {
+		if _, ok := c.Node().(*ast.File); ok {
+			c.Replace(replacement)
+		}
+		return false
+	}, nil)
+
+	if result != ast.Node(replacement) {
+		t.Fatalf("Expected the root to be replaced, got %T", result)
+	}
+}
+
+func newAssignment(name string, pos token.Pos) *ast.ExpressionStatement {
+	return &ast.ExpressionStatement{
+		Pos:        pos,
+		Expression: &ast.Identifier{Pos: pos, Value: name},
+	}
+}
+
+func parseSource(t *testing.T, src string) *ast.File {
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	return file
+}
@@ -46,6 +46,46 @@ func Walk(v Visitor, node Node) {
 			Walk(v, n.Params)
 		}
 
+		for _, modifier := range n.Modifiers {
+			if modifier != nil {
+				Walk(v, modifier)
+			}
+		}
+
+		if n.Results != nil {
+			Walk(v, n.Results)
+		}
+
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+
+	case *ConstructorDeclaration:
+		if n.Params != nil {
+			Walk(v, n.Params)
+		}
+
+		for _, modifier := range n.Modifiers {
+			if modifier != nil {
+				Walk(v, modifier)
+			}
+		}
+
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+
+	case *SpecialFunctionDeclaration:
+		if n.Params != nil {
+			Walk(v, n.Params)
+		}
+
+		for _, modifier := range n.Modifiers {
+			if modifier != nil {
+				Walk(v, modifier)
+			}
+		}
+
 		if n.Results != nil {
 			Walk(v, n.Results)
 		}
@@ -54,6 +94,17 @@ func Walk(v Visitor, node Node) {
 			Walk(v, n.Body)
 		}
 
+	case *ModifierInvocation:
+		if n.Name != nil {
+			Walk(v, n.Name)
+		}
+
+		for _, arg := range n.Args {
+			if arg != nil {
+				Walk(v, arg)
+			}
+		}
+
 	case *ParamList:
 		for _, param := range n.List {
 			if param != nil {
@@ -98,6 +149,7 @@ func Walk(v Visitor, node Node) {
 	// when these were visited.
 	case
 		*Identifier,
+		*StringLiteral,
 		*ElementaryType:
 		// No children to walk.
 	}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ChmielewskiKamil/solbot/ast/astutil"
+	"github.com/ChmielewskiKamil/solbot/parser"
+)
+
+// runAstCommand implements `solbot ast --file X.sol --format json|text`.
+// It prints the AST of the file to stdout. Parsing errors are reported on
+// stderr, but the (possibly partial) AST is printed anyway, since that is
+// exactly what is needed when debugging the parser.
+func runAstCommand(args []string) error {
+	fs := flag.NewFlagSet("ast", flag.ExitOnError)
+	filePath := fs.String("file", "", "File path to parse")
+	format := fs.String("format", "json", "Output format: json or text")
+	fs.Parse(args)
+
+	if *filePath == "" {
+		return fmt.Errorf("File path is required.\nUse --file path/to/file.sol to print its AST.")
+	}
+
+	f, err := os.Open(*filePath)
+	if err != nil {
+		return fmt.Errorf("Could not open the file %s: %w", *filePath, err)
+	}
+	defer f.Close()
+
+	file, err := parser.ParseFile(*filePath, f)
+	if file == nil {
+		return err
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+	}
+
+	switch *format {
+	case "json":
+		out, err := astutil.MarshalJSON(file)
+		if err != nil {
+			return fmt.Errorf("Could not encode the AST: %w", err)
+		}
+		fmt.Println(string(out))
+	case "text":
+		fmt.Println(file.String())
+	default:
+		return fmt.Errorf("Unknown format: `%s` Available formats: `json` or `text`.", *format)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ChmielewskiKamil/solbot/analyzer"
+	"github.com/ChmielewskiKamil/solbot/symbols"
+)
+
+// runSymbolsCommand implements `solbot symbols --file X.sol --format text|json`.
+// It analyzes the file and prints the scope tree with the symbols declared in
+// each scope and the references to them. The analysis errors and warnings are
+// reported on stderr, but the symbols are printed anyway, since they are
+// needed exactly when the reference resolution goes wrong.
+func runSymbolsCommand(args []string) error {
+	fs := flag.NewFlagSet("symbols", flag.ExitOnError)
+	filePath := fs.String("file", "", "File path to analyze")
+	format := fs.String("format", "text", "Output format: text or json")
+	root := fs.String("root", "", "Project root; the locations are relative to it")
+	fs.Parse(args)
+
+	if *filePath == "" {
+		return fmt.Errorf("File path is required.\nUse --file path/to/file.sol to print its symbols.")
+	}
+
+	a := analyzer.Analyzer{}
+	if err := a.Init(*filePath, analyzer.WithProjectRoot(*root)); err != nil {
+		return err
+	}
+	a.AnalyzeCurrentFile()
+
+	for _, e := range append(a.Errors(), a.Warnings()...) {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", e.Loc, e.Msg)
+	}
+
+	dump := symbols.Dump(a.GetCurrentFileEnv())
+
+	switch *format {
+	case "json":
+		out, err := json.MarshalIndent(dump, "", "  ")
+		if err != nil {
+			return fmt.Errorf("Could not encode the symbols: %w", err)
+		}
+		fmt.Println(string(out))
+	case "text":
+		return symbols.Fprint(os.Stdout, dump)
+	default:
+		return fmt.Errorf("Unknown format: `%s` Available formats: `text` or `json`.", *format)
+	}
+
+	return nil
+}
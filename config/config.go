@@ -0,0 +1,125 @@
+// Package config loads the solbot configuration file and resolves the
+// settings that can come from more than one place e.g. the project root.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// DefaultFileName is the name of the config file looked up in the current
+// working directory when no path is given explicitly.
+const DefaultFileName = "solbot.json"
+
+// Config is the content of the config file e.g.
+//
+//	{
+//	  "root": "packages/contracts",
+//	  "checkPublicUnderscore": false,
+//	  "unusedDeclarations": ["struct", "error"]
+//	}
+type Config struct {
+	// Root is the project root. The paths of the analyzed files are
+	// reported relative to it. A relative root is resolved against the
+	// directory of the config file, not the working directory.
+	Root string `json:"root"`
+
+	// CheckPublicUnderscore makes the privatefuncunderscore and
+	// privatevarunderscore detectors report the public and external names
+	// prefixed with an underscore too. It is on if not set.
+	CheckPublicUnderscore *bool `json:"checkPublicUnderscore"`
+
+	// UnusedDeclarations are the kinds of the unused declarations reported:
+	// "struct", "enum", "modifier" and "error". All of them are reported if
+	// not set; an empty list reports none.
+	UnusedDeclarations []string `json:"unusedDeclarations"`
+
+	dir string // directory of the config file
+}
+
+// Load reads the config file at the path. If the path is empty, the
+// DefaultFileName is read from the current working directory; in that case a
+// missing file is not an error and an empty config is returned.
+func Load(path string) (*Config, error) {
+	explicit := path != ""
+	if !explicit {
+		path = DefaultFileName
+	}
+
+	content, err := os.ReadFile(path)
+	if !explicit && errors.Is(err, fs.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the config file %s: %w", path, err)
+	}
+
+	cfg := &Config{}
+	if err := json.Unmarshal(content, cfg); err != nil {
+		return nil, fmt.Errorf("Could not decode the config file %s: %w", path, err)
+	}
+
+	cfg.dir, err = filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("Could not resolve the directory of the config file %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// ProjectRoot returns the absolute path to the project root. The first of the
+// following that is set wins:
+//
+//  1. The --root flag (flagRoot), relative to the working directory.
+//  2. The "root" option of the config file, relative to the config file.
+//  3. The directory of the config file.
+//  4. The current working directory.
+//
+// The cfg can be nil. The root is not guessed from markers such as
+// foundry.toml or .git, since in monorepos they are ambiguous.
+func ProjectRoot(flagRoot string, cfg *Config) (string, error) {
+	root := "."
+	switch {
+	case flagRoot != "":
+		root = flagRoot
+	case cfg != nil && cfg.Root != "" && !filepath.IsAbs(cfg.Root):
+		root = filepath.Join(cfg.dir, cfg.Root)
+	case cfg != nil && cfg.Root != "":
+		root = cfg.Root
+	case cfg != nil && cfg.dir != "":
+		root = cfg.dir
+	}
+
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return "", fmt.Errorf("Could not resolve the project root %s: %w", root, err)
+	}
+
+	info, err := os.Stat(abs)
+	if err != nil {
+		return "", fmt.Errorf("Could not access the project root %s: %w", abs, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("The project root %s is not a directory.", abs)
+	}
+
+	return abs, nil
+}
+
+// CheckPublicUnderscore returns whether the public and external names
+// prefixed with an underscore are reported. The --check-public-underscore
+// flag (flagValue, nil if not given) wins over the config option. Both are
+// optional and the cfg can be nil; it is on by default.
+func CheckPublicUnderscore(flagValue *bool, cfg *Config) bool {
+	switch {
+	case flagValue != nil:
+		return *flagValue
+	case cfg != nil && cfg.CheckPublicUnderscore != nil:
+		return *cfg.CheckPublicUnderscore
+	}
+	return true
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_ProjectRoot_Precedence(t *testing.T) {
+	// repo/
+	//   solbot.json
+	//   packages/contracts/
+	//   other/
+	repo := t.TempDir()
+	contracts := filepath.Join(repo, "packages", "contracts")
+	other := filepath.Join(repo, "other")
+	for _, dir := range []string{contracts, other} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	configPath := filepath.Join(repo, DefaultFileName)
+	if err := os.WriteFile(configPath, []byte(`{"root": "packages/contracts"}`), 0o644); err != nil {
+		t.Fatalf("Failed to write the config file: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load the config file: %v", err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get the working directory: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		flagRoot string
+		cfg      *Config
+		expected string
+	}{
+		{"The flag wins over the config", other, cfg, other},
+		{"The root option is relative to the config file", "", cfg, contracts},
+		{"The absolute root option", "", &Config{Root: other, dir: repo}, other},
+		{"The directory of the config file", "", &Config{dir: repo}, repo},
+		{"The working directory", "", nil, cwd},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := ProjectRoot(tt.flagRoot, tt.cfg)
+			if err != nil {
+				t.Fatalf("ProjectRoot failed: %v", err)
+			}
+			if root != tt.expected {
+				t.Errorf("Expected the root %s, got %s", tt.expected, root)
+			}
+		})
+	}
+}
+
+func Test_ProjectRoot_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "foundry.toml")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", file, err)
+	}
+
+	for _, root := range []string{filepath.Join(dir, "missing"), file} {
+		if _, err := ProjectRoot(root, nil); err == nil {
+			t.Errorf("Expected an error for the root %s", root)
+		}
+	}
+}
+
+func Test_Load(t *testing.T) {
+	// The default config file is optional.
+	chdir(t, t.TempDir())
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Expected no error for a missing default config file, got: %v", err)
+	}
+	if cfg.Root != "" {
+		t.Errorf("Expected an empty config, got the root %q", cfg.Root)
+	}
+
+	// An explicitly given one is not.
+	if _, err := Load("missing.json"); err == nil {
+		t.Errorf("Expected an error for a missing config file")
+	}
+
+	if err := os.WriteFile(DefaultFileName, []byte(`{"root": `), 0o644); err != nil {
+		t.Fatalf("Failed to write the config file: %v", err)
+	}
+	if _, err := Load(""); err == nil {
+		t.Errorf("Expected an error for a malformed config file")
+	}
+}
+
+func Test_CheckPublicUnderscore_Precedence(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), DefaultFileName)
+	if err := os.WriteFile(configPath, []byte(`{"checkPublicUnderscore": false}`), 0o644); err != nil {
+		t.Fatalf("Failed to write the config file: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load the config file: %v", err)
+	}
+
+	on := true
+
+	tests := []struct {
+		name      string
+		flagValue *bool
+		cfg       *Config
+		expected  bool
+	}{
+		{"The flag wins over the config", &on, cfg, true},
+		{"The config option", nil, cfg, false},
+		{"On by default", nil, &Config{}, true},
+		{"On without a config", nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckPublicUnderscore(tt.flagValue, tt.cfg); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// chdir changes the working directory for the duration of the test.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get the working directory: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Failed to change the working directory: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(cwd) })
+}
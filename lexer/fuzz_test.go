@@ -0,0 +1,75 @@
+package lexer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+// FuzzLex checks that the lexer terminates with EOF on any input and that the
+// emitted tokens are slices of the input in the source order.
+func FuzzLex(f *testing.F) {
+	addSeedContracts(f)
+
+	f.Fuzz(func(t *testing.T, src string) {
+		file, err := token.NewSourceFile("fuzz.sol", src)
+		if err != nil {
+			t.Skip()
+		}
+
+		l := Lex(file)
+		prev := token.Pos(0)
+
+		// Every token consumes at least one byte of the input, so there can't
+		// be more tokens than bytes (plus the final EOF).
+		for i := 0; i <= len(src)+1; i++ {
+			tkn := l.NextToken()
+
+			if tkn.Pos < prev || int(tkn.Pos) > len(src) {
+				t.Fatalf("Token %s at %d is out of order, previous token at %d", tkn.Type, tkn.Pos, prev)
+			}
+			prev = tkn.Pos
+
+			if tkn.Type == token.EOF {
+				return
+			}
+
+			if tkn.Type != token.ILLEGAL && !strings.HasPrefix(src[tkn.Pos:], tkn.Literal) {
+				t.Fatalf("Token %s literal %q does not match the input at %d", tkn.Type, tkn.Literal, tkn.Pos)
+			}
+		}
+
+		t.Fatalf("The lexer did not emit EOF after %d tokens", len(src)+2)
+	})
+}
+
+// addSeedContracts adds the Solidity files from the testdata directories of
+// the repository to the seed corpus.
+func addSeedContracts(f *testing.F) {
+	f.Helper()
+
+	for _, dir := range []string{
+		"../analyzer/testdata",
+		"../parser/testdata",
+	} {
+		err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() || filepath.Ext(path) != ".sol" {
+				return err
+			}
+
+			src, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			f.Add(string(src))
+
+			return nil
+		})
+		if err != nil {
+			f.Fatalf("Failed to read the seed contracts from %s: %v", dir, err)
+		}
+	}
+}
@@ -56,13 +56,14 @@ func Lex(file *token.SourceFile) *Lexer {
 	return l
 }
 
+// NextToken returns the next token from the input. After the input is
+// exhausted, or the lexer stopped on an ILLEGAL token, it keeps returning EOF.
 func (l *Lexer) NextToken() token.Token {
-	for {
-		select {
-		case tkn := <-l.tokens:
-			return tkn
-		}
+	tkn, ok := <-l.tokens
+	if !ok {
+		return token.Token{Type: token.EOF, Pos: l.file.Pos(len(l.input))}
 	}
+	return tkn
 }
 
 // The `emit` function passes an token.Token back to the client.
@@ -71,7 +72,7 @@ func (l *Lexer) emit(typ token.TokenType) {
 	l.tokens <- token.Token{
 		Type:    typ,
 		Literal: l.input[l.start:l.pos],
-		Pos:     token.Pos(l.start),
+		Pos:     l.file.Pos(l.start),
 	}
 	// Move ahead in the input after sending it to the caller.
 	l.start = l.pos
@@ -81,7 +82,7 @@ func (l *Lexer) errorf(format string, args ...interface{}) stateFn {
 	l.tokens <- token.Token{
 		Type:    token.ILLEGAL,
 		Literal: fmt.Sprintf(format, args...),
-		Pos:     token.Pos(l.start),
+		Pos:     l.file.Pos(l.start),
 	}
 	return nil
 }
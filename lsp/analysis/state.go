@@ -2,7 +2,12 @@ package analysis
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/ast/astutil"
 	"github.com/ChmielewskiKamil/solbot/lsp"
+	"github.com/ChmielewskiKamil/solbot/parser"
 )
 
 type State struct {
@@ -26,12 +31,39 @@ func (s *State) UpdateDocument(uri, text string) {
 func (s *State) Hover(id int, uri string, position lsp.Position) lsp.HoverResponse {
 	// TODO: This should look up the type etc.
 
-	_, ok := s.Documents[uri]
+	text, ok := s.Documents[uri]
 	if !ok {
 		return lsp.NewHoverResponse(id, "")
 	}
 
-	content := fmt.Sprintf("Hover in file: %s, line: %d, character: %d", uri, position.Line, position.Character)
+	// The documents are often half-written, so the parsing errors are
+	// ignored on purpose. Whatever was parsed is good enough for hovering.
+	file, _ := parser.ParseFile(uri, strings.NewReader(text))
+	if file == nil {
+		return lsp.NewHoverResponse(id, "")
+	}
+
+	// LSP positions are 0-based and count the characters in UTF-16 code
+	// units, the source file positions are 1-based.
+	offset := file.SourceFile.OffsetFromUTF16(int(position.Line)+1, int(position.Character)+1)
+	if offset < 0 {
+		return lsp.NewHoverResponse(id, "")
+	}
+
+	path, _ := astutil.PathEnclosingInterval(file, offset, offset)
+	if len(path) == 0 {
+		return lsp.NewHoverResponse(id, "")
+	}
+
+	content := fmt.Sprintf("%T: %s", path[0], path[0].String())
+
+	if fn, ok := astutil.Enclosing[*ast.FunctionDeclaration](path); ok {
+		content += fmt.Sprintf("\nInside function: %s", fn.Name.Value)
+	}
+
+	if contract, ok := astutil.Enclosing[*ast.ContractDeclaration](path); ok {
+		content += fmt.Sprintf("\nInside contract: %s", contract.Name.Value)
+	}
 
 	return lsp.NewHoverResponse(id, content)
 }
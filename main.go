@@ -10,6 +10,8 @@ import (
 	"os"
 
 	"github.com/ChmielewskiKamil/solbot/analyzer"
+	"github.com/ChmielewskiKamil/solbot/analyzer/unuseddecl"
+	"github.com/ChmielewskiKamil/solbot/config"
 	"github.com/ChmielewskiKamil/solbot/lsp"
 	"github.com/ChmielewskiKamil/solbot/lsp/analysis"
 	"github.com/ChmielewskiKamil/solbot/lsp/rpc"
@@ -17,10 +19,40 @@ import (
 )
 
 func main() {
+	// Subcommands e.g. `solbot ast --file X.sol` are handled before the
+	// flags of the default analyzer mode are parsed.
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "ast":
+			if err := runAstCommand(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "There was an error printing the AST: %s\n", err)
+				os.Exit(1)
+			}
+			return
+		case "symbols":
+			if err := runSymbolsCommand(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "There was an error printing the symbols: %s\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	mode := flag.String("mode", "analyzer", "Operation mode: lsp or analyzer")
 	filePath := flag.String("file", "", "File path to analyze")
+	root := flag.String("root", "", "Project root; the paths in the report are relative to it")
+	configPath := flag.String("config", "", "Path to the config file (default \""+config.DefaultFileName+"\" if it exists)")
+	checkPublic := flag.Bool("check-public-underscore", true, "Report the public and external names prefixed with an underscore")
 	flag.Parse()
 
+	// The flags that are not given don't override the config file.
+	var checkPublicFlag *bool
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "check-public-underscore" {
+			checkPublicFlag = checkPublic
+		}
+	})
+
 	switch *mode {
 	case "lsp":
 		startLanguageServer()
@@ -28,7 +60,7 @@ func main() {
 		if *filePath == "" {
 			log.Fatalf("File path is required in analyzer mode.\nUse --file path/to/file.sol to analyze a file.")
 		}
-		err := startAnalyzer(*filePath)
+		err := startAnalyzer(*filePath, *root, *configPath, checkPublicFlag)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "There was an error running the analyzer: %s\n", err)
 			os.Exit(1)
@@ -61,11 +93,38 @@ func startLanguageServer() {
 	}
 }
 
-func startAnalyzer(filePath string) error {
+func startAnalyzer(filePath, root, configPath string, checkPublic *bool) error {
 	println("Solbot starts")
 
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+
+	projectRoot, err := config.ProjectRoot(root, cfg)
+	if err != nil {
+		return err
+	}
+
 	a := analyzer.Analyzer{}
-	a.Init(filePath)
+	opts := []analyzer.Option{
+		analyzer.WithProjectRoot(projectRoot),
+		analyzer.WithCheckPublicUnderscore(config.CheckPublicUnderscore(checkPublic, cfg)),
+	}
+	if cfg.UnusedDeclarations != nil {
+		kinds := []unuseddecl.Kind{}
+		for _, name := range cfg.UnusedDeclarations {
+			kind, err := unuseddecl.ParseKind(name)
+			if err != nil {
+				return err
+			}
+			kinds = append(kinds, kind)
+		}
+		opts = append(opts, analyzer.WithUnusedDeclarationKinds(kinds))
+	}
+	if err := a.Init(filePath, opts...); err != nil {
+		return err
+	}
 	a.AnalyzeCurrentFile()
 
 	println("Solbot is analyzing your file...")
@@ -0,0 +1,331 @@
+package parser_test
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ChmielewskiKamil/solbot/ast/astutil"
+	"github.com/ChmielewskiKamil/solbot/parser"
+)
+
+var update = flag.Bool("update", false, "update the golden AST dumps of the conformance tests")
+
+const (
+	conformanceDir = "testdata/conformance"
+	// The list of the cases the parser does not handle yet.
+	unimplementedFile = "testdata/conformance/unimplemented.txt"
+	// Parsing that takes longer than that is considered an infinite loop.
+	parseTimeout = 5 * time.Second
+)
+
+// grammarRules are the elements of the 'rule source-unit' and the 'rule
+// contract-body-element' from the Solidity grammar. They match the cases of
+// parseSourceUnitDeclaration and parseContractBody. Each rule is a directory
+// in the conformance corpus.
+var grammarRules = []string{
+	"source-unit/pragma-directive",
+	"source-unit/import-directive",
+	"source-unit/using-directive",
+	"source-unit/contract-definition",
+	"source-unit/interface-definition",
+	"source-unit/library-definition",
+	"source-unit/function-definition",
+	"source-unit/constant-variable-declaration",
+	"source-unit/struct-definition",
+	"source-unit/enum-definition",
+	"source-unit/user-defined-value-type-definition",
+	"source-unit/error-definition",
+	"source-unit/event-definition",
+	"contract-body-element/constructor-definition",
+	"contract-body-element/function-definition",
+	"contract-body-element/modifier-definition",
+	"contract-body-element/fallback-function-definition",
+	"contract-body-element/receive-function-definition",
+	"contract-body-element/struct-definition",
+	"contract-body-element/enum-definition",
+	"contract-body-element/user-defined-value-type-definition",
+	"contract-body-element/state-variable-declaration",
+	"contract-body-element/event-definition",
+	"contract-body-element/error-definition",
+	"contract-body-element/using-directive",
+}
+
+type conformanceCase struct {
+	path string // relative to the corpus directory e.g. "source-unit/enum-definition/enum.sol"
+	rule string
+	src  string
+	// A case is negative if its expectations list a ParserError. Positive
+	// cases must parse without errors and match the golden AST dump.
+	negative bool
+}
+
+type conformanceResult int
+
+const (
+	passed conformanceResult = iota
+	failed
+	panicked
+	hanged
+)
+
+func (r conformanceResult) String() string {
+	return [...]string{"pass", "fail", "panic", "hang"}[r]
+}
+
+// Test_Conformance runs the corpus of Solidity syntax tests. The cases use
+// the format of the syntax tests from the Solidity compiler repository
+// (test/libsolidity/syntaxTests): the expected errors are listed as comments
+// after the "// ----" line.
+//
+// The cases the parser does not handle yet are listed in unimplemented.txt
+// together with the way they fail. The test fails if any other case fails, or
+// if a listed case starts to pass, so the list is always up to date. The cases
+// listed as "hang" are not run at all.
+//
+// Run with -v to print the coverage of the grammar rules and with -update to
+// rewrite the golden AST dumps.
+func Test_Conformance(t *testing.T) {
+	cases := loadConformanceCases(t)
+	unimplemented := loadUnimplemented(t)
+
+	type coverage struct{ positive, positiveTotal, negative, negativeTotal int }
+	coverageByRule := make(map[string]*coverage)
+	for _, rule := range grammarRules {
+		coverageByRule[rule] = &coverage{}
+	}
+
+	for _, tc := range cases {
+		cov, ok := coverageByRule[tc.rule]
+		if !ok {
+			t.Errorf("%s: %s is not a grammar rule", tc.path, tc.rule)
+			continue
+		}
+
+		expected, isUnimplemented := unimplemented[tc.path]
+
+		result := hanged
+		if expected != hanged {
+			result = runConformanceCase(t, tc)
+		}
+
+		switch {
+		case isUnimplemented && result == passed:
+			t.Errorf("%s: the case passes now, remove it from %s", tc.path, unimplementedFile)
+		case isUnimplemented && result != expected:
+			t.Errorf("%s: expected the case to %s, got: %s", tc.path, expected, result)
+		case !isUnimplemented && result != passed:
+			t.Errorf("%s: %s", tc.path, result)
+		}
+
+		if tc.negative {
+			cov.negativeTotal++
+		} else {
+			cov.positiveTotal++
+		}
+
+		if result == passed {
+			if tc.negative {
+				cov.negative++
+			} else {
+				cov.positive++
+			}
+		}
+	}
+
+	for path := range unimplemented {
+		if _, err := os.Stat(filepath.Join(conformanceDir, path)); err != nil {
+			t.Errorf("%s lists %s, which is not in the corpus", unimplementedFile, path)
+		}
+	}
+
+	var report strings.Builder
+	fmt.Fprintf(&report, "%-60s %9s %9s\n", "RULE", "POSITIVE", "NEGATIVE")
+	for _, rule := range grammarRules {
+		cov := coverageByRule[rule]
+		status := "partial"
+		switch {
+		case cov.positiveTotal+cov.negativeTotal == 0:
+			status = "no cases"
+		case cov.positive == cov.positiveTotal && cov.negative == cov.negativeTotal:
+			status = "ok"
+		case cov.positive == 0:
+			status = "unimplemented"
+		}
+		fmt.Fprintf(&report, "%-60s %9s %9s  %s\n", rule,
+			fmt.Sprintf("%d/%d", cov.positive, cov.positiveTotal),
+			fmt.Sprintf("%d/%d", cov.negative, cov.negativeTotal),
+			status)
+	}
+	t.Logf("Grammar rule coverage:\n%s", report.String())
+}
+
+// runConformanceCase parses the case and checks the outcome. Parsing is done
+// in a separate goroutine, so that panics and infinite loops are reported
+// instead of crashing the test binary.
+func runConformanceCase(t *testing.T, tc conformanceCase) conformanceResult {
+	t.Helper()
+
+	type outcome struct {
+		dump     []byte
+		err      error
+		panicked bool
+	}
+
+	done := make(chan outcome, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- outcome{panicked: true}
+			}
+		}()
+
+		file, err := parser.ParseFile(tc.path, strings.NewReader(tc.src))
+		if err != nil || tc.negative {
+			done <- outcome{err: err}
+			return
+		}
+
+		dump, err := astutil.MarshalJSON(file)
+		if err != nil {
+			t.Errorf("%s: failed to dump the AST: %v", tc.path, err)
+		}
+		done <- outcome{dump: dump}
+	}()
+
+	var out outcome
+	select {
+	case out = <-done:
+	case <-time.After(parseTimeout):
+		return hanged
+	}
+
+	switch {
+	case out.panicked:
+		return panicked
+	case tc.negative && out.err == nil:
+		return failed
+	case tc.negative:
+		return passed
+	case out.err != nil:
+		return failed
+	}
+
+	golden := filepath.Join(conformanceDir, strings.TrimSuffix(tc.path, ".sol")+".ast.json")
+	if *update {
+		if err := os.WriteFile(golden, append(out.dump, '\n'), 0o644); err != nil {
+			t.Fatalf("Failed to write the golden file: %v", err)
+		}
+		return passed
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Errorf("%s: failed to read the golden file (run with -update to create it): %v", tc.path, err)
+		return failed
+	}
+
+	if !bytes.Equal(bytes.TrimSpace(expected), bytes.TrimSpace(out.dump)) {
+		t.Errorf("%s: the AST does not match %s (run with -update to accept the changes)", tc.path, golden)
+		return failed
+	}
+
+	return passed
+}
+
+func loadConformanceCases(t *testing.T) []conformanceCase {
+	t.Helper()
+
+	var cases []conformanceCase
+	err := filepath.WalkDir(conformanceDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".sol" {
+			return err
+		}
+
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(conformanceDir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		cases = append(cases, conformanceCase{
+			path:     rel,
+			rule:     filepath.ToSlash(filepath.Dir(rel)),
+			src:      string(src),
+			negative: expectsParserError(string(src)),
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to load the conformance corpus: %v", err)
+	}
+
+	sort.Slice(cases, func(i, j int) bool { return cases[i].path < cases[j].path })
+	return cases
+}
+
+// expectsParserError reports whether the expectations section of a syntax
+// test lists a ParserError. Other kinds of errors (e.g. TypeError) are
+// reported by the later compiler stages and do not concern the parser.
+func expectsParserError(src string) bool {
+	_, expectations, found := strings.Cut(src, "\n// ----\n")
+	if !found {
+		return false
+	}
+
+	for _, line := range strings.Split(expectations, "\n") {
+		if strings.HasPrefix(line, "// ParserError") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// loadUnimplemented reads the lines in the form of "<result> <case path>".
+// Empty lines and lines starting with '#' are ignored.
+func loadUnimplemented(t *testing.T) map[string]conformanceResult {
+	t.Helper()
+
+	f, err := os.Open(unimplementedFile)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", unimplementedFile, err)
+	}
+	defer f.Close()
+
+	results := map[string]conformanceResult{"fail": failed, "panic": panicked, "hang": hanged}
+	unimplemented := make(map[string]conformanceResult)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		result, ok := results[fields[0]]
+		if len(fields) != 2 || !ok {
+			t.Fatalf("%s: invalid line: %q", unimplementedFile, line)
+		}
+		unimplemented[fields[1]] = result
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Failed to read %s: %v", unimplementedFile, err)
+	}
+
+	return unimplemented
+}
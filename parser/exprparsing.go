@@ -114,6 +114,7 @@ var precedences = map[token.TokenType]int{
 	// TODO: The function calls, array subscripting, member access etc.
 	// is harder to implement.
 	token.LPAREN: HIGHEST,
+	token.LBRACE: HIGHEST, // call options e.g. `to.call{value: 1}("")`
 	token.PERIOD: HIGHEST,
 	token.INC:    HIGHEST,
 	token.DEC:    HIGHEST,
@@ -155,7 +156,18 @@ func (p *parser) parseExpression(precedence int) ast.Expression {
 	}
 
 	leftExp := prefix()
+	if leftExp == nil {
+		// The error has been reported by the prefix parse function. The infix
+		// parse functions below expect a non-nil left expression.
+		return nil
+	}
+
+	return p.parseInfixExpressions(leftExp, precedence)
+}
 
+// parseInfixExpressions parses the operators following the already parsed
+// left expression as long as they bind tighter than the precedence.
+func (p *parser) parseInfixExpressions(leftExp ast.Expression, precedence int) ast.Expression {
 	for p.peekTkn.Type != token.SEMICOLON && precedence < p.peekPrecedence() {
 		infix := p.infixParseFns[p.peekTkn.Type]
 		if infix == nil {
@@ -165,6 +177,9 @@ func (p *parser) parseExpression(precedence int) ast.Expression {
 		p.nextToken()
 
 		leftExp = infix(leftExp)
+		if leftExp == nil {
+			return nil
+		}
 	}
 
 	return leftExp
@@ -218,6 +233,21 @@ func (p *parser) parseBooleanLiteral() ast.Expression {
 	return bl
 }
 
+func (p *parser) parseStringLiteral() ast.Expression {
+	if p.trace {
+		defer un(trace("parseStringLiteral"))
+	}
+
+	// The literal contains the quotes, either single or double.
+	literal := p.currTkn.Literal
+
+	return &ast.StringLiteral{
+		Pos:   p.currTkn.Pos,
+		Kind:  p.currTkn,
+		Value: literal[1 : len(literal)-1],
+	}
+}
+
 func (p *parser) parsePrefixExpression() ast.Expression {
 	if p.trace {
 		defer un(trace("parsePrefixExpression"))
@@ -272,22 +302,56 @@ func (p *parser) parsePostfixExpression(left ast.Expression) ast.Expression {
 	}
 }
 
+// parseGroupedExpression parses the parenthesized expression. If there is
+// more than one component e.g. `(a, b)` or `(, b)`, it is a tuple.
 func (p *parser) parseGroupedExpression() ast.Expression {
 	if p.trace {
 		defer un(trace("parseGroupedExpression"))
 	}
 
-	p.nextToken()
+	opening := p.currTkn.Pos
+	p.nextToken() // Consume '('
+
+	return p.parseTupleComponents(opening, nil)
+}
 
-	// Parse the thing inside parentheses.
-	exp := p.parseExpression(LOWEST)
+// parseTupleComponents parses the components of the parenthesized expression
+// starting at the current token, appending them to the already parsed ones.
+// The omitted components of a tuple are nil. It returns the inner expression
+// if there is only one component.
+func (p *parser) parseTupleComponents(opening token.Pos, components []ast.Expression) ast.Expression {
+	for {
+		if p.currTknIs(token.COMMA) || p.currTknIs(token.RPAREN) {
+			components = append(components, nil)
+		} else {
+			components = append(components, p.parseExpression(LOWEST))
+			p.nextToken()
+		}
+
+		if !p.currTknIs(token.COMMA) {
+			break
+		}
+		p.nextToken() // Consume ','
+	}
 
 	// There should be a closing parenthesis.
-	if !p.expectPeek(token.RPAREN) {
+	if !p.currTknIs(token.RPAREN) {
+		p.addError(p.currTkn.Pos, "expected ',' or ')' in parenthesized expression")
 		return nil
 	}
 
-	return exp
+	if len(components) == 1 {
+		if components[0] == nil {
+			p.addError(p.currTkn.Pos, "expected an expression in parentheses")
+		}
+		return components[0]
+	}
+
+	return &ast.TupleExpression{
+		Opening:    opening,
+		Components: components,
+		Closing:    p.currTkn.Pos,
+	}
 }
 
 func (p *parser) parseElementaryTypeExpression() ast.Expression {
@@ -315,11 +379,6 @@ func (p *parser) parseElementaryTypeExpression() ast.Expression {
 		return nil
 	}
 
-	// Semi-colon is optional.
-	if p.peekTkn.Type == token.SEMICOLON {
-		p.nextToken()
-	}
-
 	return et
 }
 
@@ -369,6 +428,45 @@ func (p *parser) parseCallArguments() []ast.Expression {
 	return args
 }
 
+// parseCallOptionsExpression parses the options of an external call e.g.
+// `{value: amount, gas: 5000}`. The current token is the left brace. It ends
+// on the right brace, so the call arguments are parsed next.
+func (p *parser) parseCallOptionsExpression(left ast.Expression) ast.Expression {
+	if p.trace {
+		defer un(trace("parseCallOptionsExpression"))
+	}
+
+	expr := &ast.CallOptionsExpression{
+		Expression: left,
+		LeftBrace:  p.currTkn.Pos,
+	}
+
+	for {
+		if !p.expectPeek(token.IDENTIFIER) {
+			return nil
+		}
+		expr.Names = append(expr.Names, &ast.Identifier{Pos: p.currTkn.Pos, Value: p.currTkn.Literal})
+
+		if !p.expectPeek(token.COLON) {
+			return nil
+		}
+		p.nextToken() // Move to the value
+		expr.Values = append(expr.Values, p.parseExpression(LOWEST))
+
+		if !p.peekTknIs(token.COMMA) {
+			break
+		}
+		p.nextToken() // Sitting on comma
+	}
+
+	if !p.expectPeek(token.RBRACE) {
+		return nil
+	}
+	expr.RightBrace = p.currTkn.Pos
+
+	return expr
+}
+
 func (p *parser) parseMemberAccessExpression(left ast.Expression) ast.Expression {
 	if p.trace {
 		defer un(trace("parseMemberAccessExpression"))
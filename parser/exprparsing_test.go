@@ -316,6 +316,52 @@ func Test_ParseCallExpression(t *testing.T) {
 	test_LiteralExpression(t, callExpr.Args[2], "bar")
 }
 
+func Test_ParseCallOptionsExpression(t *testing.T) {
+	src := `function test() public {
+        to.call{value: amount, gas: 5000}("");
+    }`
+
+	file := test_helper_parseSource(t, src, false)
+
+	fnBody := test_helper_parseFnBody(t, file)
+
+	if len(fnBody.Statements) != 1 {
+		t.Fatalf("Expected 1 statement, got %d", len(fnBody.Statements))
+	}
+
+	exprStmt, ok := fnBody.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("Expected ExpressionStatement, got %T", fnBody.Statements[0])
+	}
+
+	callExpr, ok := exprStmt.Expression.(*ast.CallExpression)
+	if !ok {
+		t.Fatalf("Expected CallExpression, got %T", exprStmt.Expression)
+	}
+
+	options, ok := callExpr.Ident.(*ast.CallOptionsExpression)
+	if !ok {
+		t.Fatalf("Expected CallOptionsExpression, got %T", callExpr.Ident)
+	}
+
+	if options.Expression.String() != "(to.call)" {
+		t.Errorf("Expected the options of (to.call), got %s", options.Expression)
+	}
+
+	if len(options.Names) != 2 || len(options.Values) != 2 {
+		t.Fatalf("Expected 2 options, got %d names and %d values", len(options.Names), len(options.Values))
+	}
+
+	test_Identifier(t, options.Names[0], "value")
+	test_LiteralExpression(t, options.Values[0], "amount")
+	test_Identifier(t, options.Names[1], "gas")
+	test_LiteralExpression(t, options.Values[1], big.NewInt(5000))
+
+	if got := callExpr.String(); got != `(to.call){value: amount, gas: 5000}("")` {
+		t.Errorf("Unexpected call expression: %s", got)
+	}
+}
+
 func TestParseExpressions(t *testing.T) {
 	// This helper function simplifies parsing expressions by wrapping them in a function
 	// and extracting the statements, similar to the original test setup.
@@ -380,6 +426,32 @@ func TestParseExpressions(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:   "string literals",
+			source: `"Hello, World!"; 'single';`,
+			validate: func(t *testing.T, stmts []ast.Statement) {
+				if len(stmts) != 2 {
+					t.Fatalf("Expected 2 statements, got %d", len(stmts))
+				}
+				expectedValues := []string{"Hello, World!", "single"}
+				for i, stmt := range stmts {
+					exprStmt, ok := stmt.(*ast.ExpressionStatement)
+					if !ok {
+						t.Fatalf("Statement %d: Expected ExpressionStatement, got %T", i, stmt)
+					}
+					strLit, ok := exprStmt.Expression.(*ast.StringLiteral)
+					if !ok {
+						t.Fatalf("Statement %d: Expected StringLiteral, got %T", i, exprStmt.Expression)
+					}
+					if strLit.Value != expectedValues[i] {
+						t.Errorf("Statement %d: Expected value %q, got %q", i, expectedValues[i], strLit.Value)
+					}
+					if int(strLit.End()-strLit.Start()) != len(expectedValues[i])+2 {
+						t.Errorf("Statement %d: Expected the literal to span the quotes", i)
+					}
+				}
+			},
+		},
 		{
 			name:   "prefix expressions",
 			source: `-1337; !a; delete foo;`,
@@ -440,6 +512,39 @@ func TestParseExpressions(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:   "tuple expressions",
+			source: `(a, b) = (b, a); (, , c) = foo(); (x + y) * 2;`,
+			validate: func(t *testing.T, stmts []ast.Statement) {
+				if len(stmts) != 3 {
+					t.Fatalf("Expected 3 statements, got %d", len(stmts))
+				}
+				expectedStrings := []string{
+					"((a, b) = (b, a))",
+					"((, , c) = foo())",
+					"((x + y) * 2)",
+				}
+				for i, expected := range expectedStrings {
+					exprStmt, ok := stmts[i].(*ast.ExpressionStatement)
+					if !ok {
+						t.Fatalf("Statement %d: Expected ExpressionStatement, got %T", i, stmts[i])
+					}
+					if actual := exprStmt.Expression.String(); actual != expected {
+						t.Errorf("Statement %d: expected '%s', got '%s'", i, expected, actual)
+					}
+				}
+
+				assignment := stmts[1].(*ast.ExpressionStatement).Expression.(*ast.InfixExpression)
+				tuple, ok := assignment.Left.(*ast.TupleExpression)
+				if !ok {
+					t.Fatalf("Expected TupleExpression, got %T", assignment.Left)
+				}
+				if len(tuple.Components) != 3 || tuple.Components[0] != nil || tuple.Components[1] != nil {
+					t.Fatalf("Expected 2 omitted components followed by c, got %s", tuple)
+				}
+				test_Identifier(t, tuple.Components[2], "c")
+			},
+		},
 		{
 			name:   "call expression with complex arguments",
 			source: `foo(a + b, 3 * 5, bar);`,
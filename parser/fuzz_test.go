@@ -0,0 +1,61 @@
+package parser_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ChmielewskiKamil/solbot/parser"
+)
+
+// FuzzParseFile checks that the parser returns on any input without
+// panicking. The LSP server parses half-written files on every keystroke,
+// so the parser must never crash nor hang.
+func FuzzParseFile(f *testing.F) {
+	for _, dir := range []string{"../analyzer/testdata", "testdata"} {
+		err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() || filepath.Ext(path) != ".sol" {
+				return err
+			}
+
+			src, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			f.Add(string(src))
+
+			return nil
+		})
+		if err != nil {
+			f.Fatalf("Failed to read the seed contracts from %s: %v", dir, err)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, src string) {
+		if src == "" {
+			// An empty source makes the token.SourceFile read the file from
+			// the disk instead.
+			t.Skip()
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			file, err := parser.ParseFile("fuzz.sol", strings.NewReader(src))
+			if file == nil {
+				t.Errorf("Expected a (partial) file, got nil and error: %v", err)
+			}
+			done <- err
+		}()
+
+		select {
+		case err := <-done:
+			if _, ok := err.(parser.ErrorList); err != nil && !ok {
+				t.Fatalf("Expected an error of type ErrorList, got %T: %v", err, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("The parser did not return within 5 seconds")
+		}
+	})
+}
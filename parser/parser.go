@@ -30,11 +30,7 @@ func ParseFile(filename string, src io.Reader, opts ...Option) (*ast.File, error
 		return nil, fmt.Errorf("Failed to create new source file for %s: %w", filename, err)
 	}
 
-	p := newParser(sourceFile)
-
-	for _, opt := range opts {
-		opt(p)
-	}
+	p := newParser(sourceFile, opts...)
 
 	file := p.parseFile()
 
@@ -53,12 +49,21 @@ func WithTracing() Option {
 	}
 }
 
+// WithFileSet adds the parsed file to the fset. The positions in the returned
+// AST are then unique across all the files of the set.
+func WithFileSet(fset *token.FileSet) Option {
+	return func(p *parser) {
+		p.fset = fset
+	}
+}
+
 ////////////////////////////////////////////////////
 //////////////////// INTERNALS /////////////////////
 ////////////////////////////////////////////////////
 
 type parser struct {
 	file   *token.SourceFile
+	fset   *token.FileSet // Optional; the set the file is added to.
 	l      lexer.Lexer
 	errors ErrorList
 
@@ -74,14 +79,22 @@ type parser struct {
 	infixParseFns  map[token.TokenType]infixParseFn
 }
 
-func newParser(file *token.SourceFile) *parser {
+func newParser(file *token.SourceFile, opts ...Option) *parser {
 	p := &parser{
 		file:   file,
-		l:      *lexer.Lex(file),
 		errors: ErrorList{},
 	}
 
-	p.trace = false
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	// The file must be in the set before the lexer computes the positions.
+	if p.fset != nil {
+		p.fset.AddFile(file)
+	}
+
+	p.l = *lexer.Lex(file)
 
 	// Read two tokens, so currTkn and peekTkn are both set
 	p.nextToken()
@@ -93,8 +106,11 @@ func newParser(file *token.SourceFile) *parser {
 	p.registerPrefix(token.HEX_NUMBER, p.parseNumberLiteral)
 	p.registerPrefix(token.TRUE_LITERAL, p.parseBooleanLiteral)
 	p.registerPrefix(token.FALSE_LITERAL, p.parseBooleanLiteral)
+	p.registerPrefix(token.STRING_LITERAL, p.parseStringLiteral)
 
 	registerPrefixElementaryTypes(p)
+	// The conversion to address payable e.g. `payable(msg.sender)`.
+	p.registerPrefix(token.PAYABLE, p.parseElementaryTypeExpression)
 
 	// Prefix Expressions
 	p.registerPrefix(token.NOT, p.parsePrefixExpression)
@@ -141,6 +157,7 @@ func newParser(file *token.SourceFile) *parser {
 	p.registerInfix(token.ASSIGN_DIV, p.parseInfixExpression)
 	p.registerInfix(token.ASSIGN_MOD, p.parseInfixExpression)
 	p.registerInfix(token.LPAREN, p.parseCallExpression)
+	p.registerInfix(token.LBRACE, p.parseCallOptionsExpression)
 	p.registerInfix(token.PERIOD, p.parseMemberAccessExpression)
 	p.registerInfix(token.INC, p.parsePostfixExpression)
 	p.registerInfix(token.DEC, p.parsePostfixExpression)
@@ -169,6 +186,7 @@ func (p *parser) parseFile() *ast.File {
 	}
 
 	file := &ast.File{}
+	file.Name = p.file.Name()
 	file.SourceFile = p.file
 	file.Declarations = []ast.Declaration{}
 
@@ -187,45 +205,83 @@ func (p *parser) parseSourceUnitDeclaration() ast.Declaration {
 	// Cases below should match elements outlined in the
 	// 'rule source-unit' in Solidity Grammar.
 	// TODO: Implement remaining SourceUnit elements.
-	switch tk := p.currTkn.Type; tk {
+	switch tk := p.currTkn.Type; {
 	default:
 		// TODO: Once this function is fully implemented, the default case
 		// should only be hit on errors. Add the parses error then.
 		p.addError(p.currTkn.Pos, "Unhandled declaration type in the SourceUnit: "+p.currTkn.Literal)
 		return nil
 
-	case token.COMMENT_LITERAL:
+	case tk == token.COMMENT_LITERAL:
 		// TODO Parse comments; skip for now
 		return nil
 
-	case token.PRAGMA:
+	case tk == token.PRAGMA:
 		// TODO parse pragma; skip for now
 		for !p.currTknIs(token.SEMICOLON) {
+			if p.currTknIs(token.EOF) {
+				p.addError(p.currTkn.Pos, "expected ';' to terminate pragma directive")
+				return nil
+			}
 			p.nextToken()
 		}
 		return nil
 
-	// import-directive
+	case tk == token.IMPORT: // import-directive
+		if dir := p.parseImportDirective(); dir != nil {
+			return dir
+		}
+		return nil
 
-	case token.USING: // TODO: finish using-directive
-		return p.parseUsingForDirective()
+	case tk == token.USING: // TODO: finish using-directive
+		if dir := p.parseUsingForDirective(); dir != nil {
+			return dir
+		}
+		return nil
 
-	case token.CONTRACT, token.ABSTRACT: // contract-definition
+	case tk == token.CONTRACT || tk == token.ABSTRACT: // contract-definition
 		return p.parseContractDeclaration()
 
 		// interface-definition
 		// library-definition
 
-	case token.FUNCTION: // function-definition
-		return p.parseFunctionDeclaration()
+	case tk == token.FUNCTION: // function-definition
+		if fn := p.parseFunctionDeclaration(); fn != nil {
+			return fn
+		}
+		return nil
+
+	case token.IsElementaryType(tk): // constant-variable-declaration
+		if decl := p.parseConstantVariableDeclaration(); decl != nil {
+			return decl
+		}
+		return nil
+
+	case tk == token.STRUCT: // struct-definition
+		if decl := p.parseStructDeclaration(); decl != nil {
+			return decl
+		}
+		return nil
+
+	case tk == token.ENUM: // enum-definition
+		if decl := p.parseEnumDeclaration(); decl != nil {
+			return decl
+		}
+		return nil
 
-		// constant-variable-declaration
-		// struct-definition
-		// enum-definition
 		// user-defined-value-type-definition
-		// error-definition
-	case token.EVENT: // event-definition
-		return p.parseEventDeclaration()
+
+	case p.currTknIsErrorKeyword(): // error-definition
+		if decl := p.parseErrorDeclaration(); decl != nil {
+			return decl
+		}
+		return nil
+
+	case tk == token.EVENT: // event-definition
+		if event := p.parseEventDeclaration(); event != nil {
+			return event
+		}
+		return nil
 	}
 }
 
@@ -327,36 +383,65 @@ func (p *parser) parseContractBody() *ast.ContractBody {
 			p.nextToken()
 
 		case tk == token.CONSTRUCTOR: // Constructor definition
-			for !p.currTknIs(token.RBRACE) {
-				p.nextToken()
+			if constructor := p.parseConstructorDeclaration(); constructor != nil {
+				decls = append(decls, constructor)
 			}
 			p.nextToken() // Move past RBRACE
 
 		case tk == token.FUNCTION: // Function definition
-			decls = append(decls, p.parseFunctionDeclaration())
+			if fn := p.parseFunctionDeclaration(); fn != nil {
+				decls = append(decls, fn)
+			}
 			p.nextToken() // Move past RBRACE
 
 		case tk == token.MODIFIER: // Modifier definition
-			decls = append(decls, p.parseModifierDeclaration())
+			if modifier := p.parseModifierDeclaration(); modifier != nil {
+				decls = append(decls, modifier)
+			}
+			p.nextToken() // Move past RBRACE or semicolon
+
+		case tk == token.FALLBACK || tk == token.RECEIVE: // fallback-function-definition, receive-function-definition
+			if fn := p.parseSpecialFunctionDeclaration(); fn != nil {
+				decls = append(decls, fn)
+			}
 			p.nextToken() // Move past RBRACE or semicolon
-			// fallback-function-definition
-			// receive-function-definition
-			// struct-definition
-			// enum-definition
+
+		case tk == token.STRUCT: // struct-definition
+			if decl := p.parseStructDeclaration(); decl != nil {
+				decls = append(decls, decl)
+			}
+			p.nextToken() // Move past RBRACE
+
+		case tk == token.ENUM: // enum-definition
+			if decl := p.parseEnumDeclaration(); decl != nil {
+				decls = append(decls, decl)
+			}
+			p.nextToken() // Move past RBRACE
+
 			// user-defined-value-type-definition
 
-		case token.IsElementaryType(tk): // state-variable-declaration
-			decls = append(decls, p.parseStateVariableDeclaration())
+		case p.currTknIsErrorKeyword(): // error-definition
+			if decl := p.parseErrorDeclaration(); decl != nil {
+				decls = append(decls, decl)
+			}
 			p.nextToken() // Move past semicolon
 
-		case tk == token.EVENT: // event-definition
-			decls = append(decls, p.parseEventDeclaration())
+		case token.IsElementaryType(tk) || tk == token.IDENTIFIER: // state-variable-declaration
+			if stateVar := p.parseStateVariableDeclaration(); stateVar != nil {
+				decls = append(decls, stateVar)
+			}
 			p.nextToken() // Move past semicolon
 
-			// error-definition
+		case tk == token.EVENT: // event-definition
+			if event := p.parseEventDeclaration(); event != nil {
+				decls = append(decls, event)
+			}
+			p.nextToken() // Move past semicolon
 
 		case tk == token.USING: // using-directive
-			decls = append(decls, p.parseUsingForDirective())
+			if dir := p.parseUsingForDirective(); dir != nil {
+				decls = append(decls, dir)
+			}
 			p.nextToken() // Move past semicolon
 
 		case tk == token.RBRACE: // End of contract's body
@@ -364,8 +449,154 @@ func (p *parser) parseContractBody() *ast.ContractBody {
 			body.RightBrace = p.currTkn.Pos
 
 			return body
+
+		case tk == token.EOF:
+			p.addError(p.currTkn.Pos, "expected '}' to close the contract's body")
+			body.Declarations = decls
+
+			return body
+		}
+	}
+}
+
+func (p *parser) parseImportDirective() *ast.ImportDirective {
+	if p.trace {
+		defer un(trace("parseImportDirective"))
+	}
+
+	// The parser is sitting on the 'import' keyword.
+	dir := &ast.ImportDirective{
+		Pos: p.currTkn.Pos,
+	}
+
+	p.nextToken() // Consume 'import'
+
+	switch {
+	case p.currTknIs(token.STRING_LITERAL):
+		// import "./A.sol"; or import "./A.sol" as A;
+		dir.Path = p.parseStringLiteral().(*ast.StringLiteral)
+		p.nextToken() // Consume path
+
+		if p.currTknIs(token.AS) {
+			p.nextToken() // Consume 'as'
+			if dir.UnitAlias = p.parseImportAlias(); dir.UnitAlias == nil {
+				return nil
+			}
+		}
+
+	case p.currTknIs(token.MUL):
+		// import * as A from "./A.sol";
+		p.nextToken() // Consume '*'
+		if !p.currTknIs(token.AS) {
+			p.addError(p.currTkn.Pos, "expected 'as' after '*' in import directive")
+			return nil
+		}
+		p.nextToken() // Consume 'as'
+		if dir.UnitAlias = p.parseImportAlias(); dir.UnitAlias == nil {
+			return nil
+		}
+		if dir.Path = p.parseImportFrom(); dir.Path == nil {
+			return nil
+		}
+
+	case p.currTknIs(token.LBRACE):
+		// import {A, B as C} from "./A.sol";
+		if dir.Symbols = p.parseImportSymbols(); dir.Symbols == nil {
+			return nil
+		}
+		if dir.Path = p.parseImportFrom(); dir.Path == nil {
+			return nil
+		}
+
+	default:
+		p.addError(p.currTkn.Pos, "expected import path, '*' or '{' after 'import'")
+		return nil
+	}
+
+	if !p.currTknIs(token.SEMICOLON) {
+		p.addError(p.currTkn.Pos, "expected ';' to terminate import directive")
+		return nil
+	}
+
+	dir.Semicolon = p.currTkn.Pos
+
+	return dir
+}
+
+// parseImportAlias parses the identifier following 'as' in an import
+// directive and moves past it. It returns nil on error.
+func (p *parser) parseImportAlias() *ast.Identifier {
+	if !p.currTknIs(token.IDENTIFIER) {
+		p.addError(p.currTkn.Pos, "expected identifier after 'as', got: "+p.currTkn.Literal)
+		return nil
+	}
+	alias := &ast.Identifier{Pos: p.currTkn.Pos, Value: p.currTkn.Literal}
+	p.nextToken() // Consume alias
+	return alias
+}
+
+// parseImportFrom parses the `from "path"` part of an import directive and
+// moves past it. It returns nil on error.
+func (p *parser) parseImportFrom() *ast.StringLiteral {
+	// 'from' is not a keyword, so it is lexed as an identifier.
+	if !p.currTknIs(token.IDENTIFIER) || p.currTkn.Literal != "from" {
+		p.addError(p.currTkn.Pos, "expected 'from' but got: "+p.currTkn.Literal)
+		return nil
+	}
+	p.nextToken() // Consume 'from'
+
+	if !p.currTknIs(token.STRING_LITERAL) {
+		p.addError(p.currTkn.Pos, "expected import path after 'from', got: "+p.currTkn.Literal)
+		return nil
+	}
+	path := p.parseStringLiteral().(*ast.StringLiteral)
+	p.nextToken() // Consume path
+	return path
+}
+
+func (p *parser) parseImportSymbols() []*ast.ImportSymbol {
+	if p.trace {
+		defer un(trace("parseImportSymbols"))
+	}
+
+	var symbols []*ast.ImportSymbol
+	p.nextToken() // Consume '{'
+
+	for !p.currTknIs(token.RBRACE) {
+		if !p.currTknIs(token.IDENTIFIER) {
+			p.addError(p.currTkn.Pos, "expected identifier in import list")
+			return nil
+		}
+		sym := &ast.ImportSymbol{
+			Name: &ast.Identifier{Pos: p.currTkn.Pos, Value: p.currTkn.Literal},
+		}
+		p.nextToken() // Consume name
+
+		if p.currTknIs(token.AS) {
+			p.nextToken() // Consume 'as'
+			if sym.Alias = p.parseImportAlias(); sym.Alias == nil {
+				return nil
+			}
 		}
+
+		symbols = append(symbols, sym)
+
+		if p.currTknIs(token.COMMA) {
+			p.nextToken() // Consume ','
+		} else if !p.currTknIs(token.RBRACE) {
+			p.addError(p.currTkn.Pos, "expected ',' or '}' in import list")
+			return nil
+		}
+	}
+
+	if len(symbols) == 0 {
+		p.addError(p.currTkn.Pos, "expected at least one symbol in import list")
+		return nil
 	}
+
+	p.nextToken() // Consume '}'
+
+	return symbols
 }
 
 func (p *parser) parseUsingForDirective() *ast.UsingForDirective {
@@ -469,10 +700,7 @@ func (p *parser) parseUsingForList() []*ast.UsingForObject {
 
 func (p *parser) parseTypeName() ast.Type {
 	if token.IsElementaryType(p.currTkn.Type) {
-		t := &ast.ElementaryType{
-			Pos:  p.currTkn.Pos,
-			Kind: p.currTkn,
-		}
+		t := p.parseElementaryType()
 		p.nextToken() // Consume the type token
 		return t
 	}
@@ -489,6 +717,40 @@ func (p *parser) parseTypeName() ast.Type {
 	return nil
 }
 
+// parseVariableType parses the type of a variable the parser is sitting on:
+// an elementary type or the name of a user-defined type e.g. a struct, an
+// enum or a contract. Like parseElementaryType, it leaves the parser on the
+// last token of the type.
+func (p *parser) parseVariableType() ast.Type {
+	if p.currTknIs(token.IDENTIFIER) {
+		return &ast.UserDefinedType{
+			Name: &ast.Identifier{Pos: p.currTkn.Pos, Value: p.currTkn.Literal},
+		}
+	}
+	return p.parseElementaryType()
+}
+
+// parseElementaryType parses the elementary type the parser is sitting on.
+// The parser is left on the last token of the type, which is the "payable"
+// for `address payable`.
+func (p *parser) parseElementaryType() *ast.ElementaryType {
+	t := &ast.ElementaryType{
+		Pos: p.currTkn.Pos,
+		Kind: token.Token{
+			Type:    p.currTkn.Type,
+			Literal: p.currTkn.Literal,
+			Pos:     p.currTkn.Pos,
+		},
+	}
+
+	if p.currTknIs(token.ADDRESS) && p.peekTknIs(token.PAYABLE) {
+		p.nextToken()
+		t.Payable = p.currTkn.Pos
+	}
+
+	return t
+}
+
 func (p *parser) parseFunctionDeclaration() *ast.FunctionDeclaration {
 	if p.trace {
 		defer un(trace("parseFunctionDeclaration"))
@@ -517,24 +779,238 @@ func (p *parser) parseFunctionDeclaration() *ast.FunctionDeclaration {
 	decl.Params = p.parseParameterList()
 
 	// 4. Visibility, State Mutability, Modifier Invocation, Override, Virtual
+	// in any order.
+attributes:
+	for {
+		switch tkType := p.peekTkn.Type; {
+		case token.IsFunctionVisibility(tkType):
+			p.nextToken()
+			if decl.Visibility != 0 {
+				p.addError(p.currTkn.Pos, "visibility already specified as \""+decl.Visibility.String()+"\"")
+			}
+			switch tkType {
+			case token.PUBLIC:
+				decl.Visibility = ast.Public
+			case token.PRIVATE:
+				decl.Visibility = ast.Private
+			case token.INTERNAL:
+				decl.Visibility = ast.Internal
+			case token.EXTERNAL:
+				decl.Visibility = ast.External
+			}
+		case token.IsFunctionMutability(tkType):
+			p.nextToken()
+			if decl.Mutability != 0 {
+				p.addError(p.currTkn.Pos, "state mutability already specified as \""+decl.Mutability.String()+"\"")
+			}
+			switch tkType {
+			case token.PURE:
+				decl.Mutability = ast.Pure
+			case token.VIEW:
+				decl.Mutability = ast.View
+			case token.PAYABLE:
+				decl.Mutability = ast.Payable
+			}
+		case tkType == token.VIRTUAL:
+			p.nextToken()
+			decl.Virtual = true
+		case tkType == token.OVERRIDE:
+			p.nextToken()
+			decl.Override = p.parseOverrideSpecifier()
+		case tkType == token.IDENTIFIER:
+			p.nextToken()
+			decl.Modifiers = append(decl.Modifiers, p.parseModifierInvocation())
+		default:
+			break attributes
+		}
+	}
 
-	// TODO: Parse stuff after params before body block.
+	// The free functions are internal, and the functions in contracts must
+	// specify the visibility, so internal is the only sensible default.
+	if decl.Visibility == 0 {
+		decl.Visibility = ast.Internal
+	}
 
 	// 5. Returns ( Param List )
+	if p.peekTknIs(token.RETURNS) {
+		p.nextToken()
+		if !p.expectPeek(token.LPAREN) {
+			return nil
+		}
+		decl.Results = p.parseParameterList()
+	}
+
+	// 6. Body block or a semicolon for functions without implementation.
+	if p.peekTknIs(token.LBRACE) {
+		p.nextToken()
+		decl.Body = p.parseBlockStatement()
+	} else if p.peekTknIs(token.SEMICOLON) {
+		p.nextToken()
+		decl.Semicolon = p.currTkn.Pos
+	} else {
+		p.addError(p.peekTkn.Pos, "expected '{' or ';' after function declaration")
+	}
+
+	return decl
+}
+
+// parseSpecialFunctionDeclaration parses the receive and the fallback
+// functions. The attributes are parsed like the attributes of the functions;
+// whether they are valid for the kind e.g. the receive function must be
+// external payable, is left to the compiler.
+func (p *parser) parseSpecialFunctionDeclaration() *ast.SpecialFunctionDeclaration {
+	if p.trace {
+		defer un(trace("parseSpecialFunctionDeclaration"))
+	}
+
+	decl := &ast.SpecialFunctionDeclaration{Kind: p.currTkn}
+
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+
+	decl.Params = p.parseParameterList()
 
-	// TODO: Parse returned results.
+attributes:
+	for {
+		switch tkType := p.peekTkn.Type; {
+		case token.IsFunctionVisibility(tkType):
+			p.nextToken()
+			if decl.Visibility != 0 {
+				p.addError(p.currTkn.Pos, "visibility already specified as \""+decl.Visibility.String()+"\"")
+			}
+			switch tkType {
+			case token.PUBLIC:
+				decl.Visibility = ast.Public
+			case token.PRIVATE:
+				decl.Visibility = ast.Private
+			case token.INTERNAL:
+				decl.Visibility = ast.Internal
+			case token.EXTERNAL:
+				decl.Visibility = ast.External
+			}
+		case token.IsFunctionMutability(tkType):
+			p.nextToken()
+			if decl.Mutability != 0 {
+				p.addError(p.currTkn.Pos, "state mutability already specified as \""+decl.Mutability.String()+"\"")
+			}
+			switch tkType {
+			case token.PURE:
+				decl.Mutability = ast.Pure
+			case token.VIEW:
+				decl.Mutability = ast.View
+			case token.PAYABLE:
+				decl.Mutability = ast.Payable
+			}
+		case tkType == token.VIRTUAL:
+			p.nextToken()
+			decl.Virtual = true
+		case tkType == token.OVERRIDE:
+			p.nextToken()
+			decl.Override = p.parseOverrideSpecifier()
+		case tkType == token.IDENTIFIER:
+			p.nextToken()
+			decl.Modifiers = append(decl.Modifiers, p.parseModifierInvocation())
+		default:
+			break attributes
+		}
+	}
+
+	if p.peekTknIs(token.RETURNS) {
+		p.nextToken()
+		if !p.expectPeek(token.LPAREN) {
+			return nil
+		}
+		decl.Results = p.parseParameterList()
+	}
 
-	// 6. Body block
-	for !p.currTknIs(token.LBRACE) {
+	if p.peekTknIs(token.LBRACE) {
 		p.nextToken()
+		decl.Body = p.parseBlockStatement()
+	} else if p.peekTknIs(token.SEMICOLON) {
+		p.nextToken()
+		decl.Semicolon = p.currTkn.Pos
+	} else {
+		p.addError(p.peekTkn.Pos, "expected '{' or ';' after "+decl.Kind.Literal+" function declaration")
+	}
+
+	return decl
+}
+
+// parseConstructorDeclaration parses the constructor of a contract. Like
+// the function attributes, the attributes of the constructor can be in any
+// order. The calls to the base constructors e.g. `Ownable(owner)`, are
+// parsed as the modifier invocations.
+func (p *parser) parseConstructorDeclaration() *ast.ConstructorDeclaration {
+	if p.trace {
+		defer un(trace("parseConstructorDeclaration"))
+	}
+
+	decl := &ast.ConstructorDeclaration{Pos: p.currTkn.Pos}
+
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+
+	decl.Params = p.parseParameterList()
+
+attributes:
+	for {
+		switch tkType := p.peekTkn.Type; tkType {
+		case token.PUBLIC, token.INTERNAL:
+			p.nextToken()
+			if decl.Visibility != 0 {
+				p.addError(p.currTkn.Pos, "visibility already specified as \""+decl.Visibility.String()+"\"")
+			}
+			if tkType == token.PUBLIC {
+				decl.Visibility = ast.Public
+			} else {
+				decl.Visibility = ast.Internal
+			}
+		case token.PAYABLE:
+			p.nextToken()
+			if decl.Mutability != 0 {
+				p.addError(p.currTkn.Pos, "state mutability already specified as \""+decl.Mutability.String()+"\"")
+			}
+			decl.Mutability = ast.Payable
+		case token.IDENTIFIER:
+			p.nextToken()
+			decl.Modifiers = append(decl.Modifiers, p.parseModifierInvocation())
+		default:
+			break attributes
+		}
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
 	}
 
-	fnBody := p.parseBlockStatement()
+	decl.Body = p.parseBlockStatement()
 
-	decl.Body = fnBody
 	return decl
 }
 
+// parseModifierInvocation parses the invocation of a modifier in the function
+// header e.g. `onlyOwner` or `nonReentrant(1)`. It starts on the identifier.
+func (p *parser) parseModifierInvocation() *ast.ModifierInvocation {
+	if p.trace {
+		defer un(trace("parseModifierInvocation"))
+	}
+
+	invocation := &ast.ModifierInvocation{
+		Name: &ast.Identifier{Pos: p.currTkn.Pos, Value: p.currTkn.Literal},
+	}
+
+	if p.peekTknIs(token.LPAREN) {
+		p.nextToken()
+		invocation.Opening = p.currTkn.Pos
+		invocation.Args = p.parseCallArguments()
+		invocation.Closing = p.currTkn.Pos
+	}
+
+	return invocation
+}
+
 func (p *parser) parseModifierDeclaration() *ast.ModifierDeclaration {
 	if p.trace {
 		defer un(trace("parseModifierDeclaration"))
@@ -697,15 +1173,8 @@ func (p *parser) parseStateVariableDeclaration() *ast.StateVariableDeclaration {
 	// decl.Mutability does not need to be set since all variables are mutable
 	// by default, and here we can only set Constant, Immutable, or Transient.
 
-	// We are sitting on the variable type e.g. address or uint256
-	decl.Type = &ast.ElementaryType{
-		Pos: p.currTkn.Pos,
-		Kind: token.Token{
-			Type:    p.currTkn.Type,
-			Literal: p.currTkn.Literal,
-			Pos:     p.currTkn.Pos,
-		},
-	}
+	// We are sitting on the variable type e.g. address, uint256 or a struct
+	decl.Type = p.parseVariableType()
 
 	p.nextToken()
 
@@ -719,6 +1188,7 @@ func (p *parser) parseStateVariableDeclaration() *ast.StateVariableDeclaration {
 		switch tkType := p.currTkn.Type; {
 		default:
 			p.addError(p.currTkn.Pos, "Unexpected token: "+p.currTkn.Literal)
+			return nil
 		case tkType == token.IDENTIFIER:
 			decl.Name = &ast.Identifier{
 				Pos:   p.currTkn.Pos,
@@ -758,6 +1228,42 @@ func (p *parser) parseStateVariableDeclaration() *ast.StateVariableDeclaration {
 	}
 }
 
+// parseConstantVariableDeclaration parses the file-level constant e.g.
+// `uint256 constant MAX = 100;`. It starts on the type.
+func (p *parser) parseConstantVariableDeclaration() *ast.ConstantVariableDeclaration {
+	if p.trace {
+		defer un(trace("parseConstantVariableDeclaration"))
+	}
+
+	decl := &ast.ConstantVariableDeclaration{}
+	decl.Type = p.parseElementaryType()
+
+	if !p.peekTknIs(token.CONSTANT) {
+		p.addError(p.peekTkn.Pos, "Only constant variables are allowed at file level.")
+		return nil
+	}
+	p.nextToken()
+
+	if !p.expectPeek(token.IDENTIFIER) {
+		return nil
+	}
+	decl.Name = &ast.Identifier{Pos: p.currTkn.Pos, Value: p.currTkn.Literal}
+
+	if !p.peekTknIs(token.ASSIGN) {
+		p.addError(p.peekTkn.Pos, "expected '=' to initialize the constant variable")
+		return nil
+	}
+	p.nextToken()
+	p.nextToken() // Move past '='
+	decl.Value = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.SEMICOLON) {
+		return nil
+	}
+
+	return decl
+}
+
 func (p *parser) parseEventDeclaration() *ast.EventDeclaration {
 	if p.trace {
 		defer un(trace("parseEventDeclaration"))
@@ -786,19 +1292,12 @@ func (p *parser) parseEventDeclaration() *ast.EventDeclaration {
 		eventParam := &ast.EventParam{}
 
 		p.nextToken() // move past opening parenthesis
-		if !token.IsElementaryType(p.currTkn.Type) {
-			p.addError(p.currTkn.Pos, "Event param: expected elementary type after opening parenthesis, got: "+p.currTkn.Literal)
+		if !token.IsElementaryType(p.currTkn.Type) && !p.currTknIs(token.IDENTIFIER) {
+			p.addError(p.currTkn.Pos, "Event param: expected type name after opening parenthesis, got: "+p.currTkn.Literal)
 			return nil
 		}
 
-		eventParam.Type = &ast.ElementaryType{
-			Pos: p.currTkn.Pos,
-			Kind: token.Token{
-				Type:    p.currTkn.Type,
-				Literal: p.currTkn.Literal,
-				Pos:     p.currTkn.Pos,
-			},
-		}
+		eventParam.Type = p.parseVariableType()
 
 		// Indexed is an optional keyword
 		if p.peekTknIs(token.INDEXED) {
@@ -839,25 +1338,208 @@ func (p *parser) parseEventDeclaration() *ast.EventDeclaration {
 	return eventDecl
 }
 
+// parseStructDeclaration parses the struct definition e.g.
+// `struct S { uint256 a; address b; }`. It ends on the right brace.
+func (p *parser) parseStructDeclaration() *ast.StructDeclaration {
+	if p.trace {
+		defer un(trace("parseStructDeclaration"))
+	}
+
+	decl := &ast.StructDeclaration{Pos: p.currTkn.Pos}
+
+	if !p.expectPeek(token.IDENTIFIER) {
+		return nil
+	}
+	decl.Name = &ast.Identifier{Pos: p.currTkn.Pos, Value: p.currTkn.Literal}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	for !p.peekTknIs(token.RBRACE) {
+		if p.peekTknIs(token.EOF) {
+			p.addError(p.peekTkn.Pos, "expected '}' to close the struct definition")
+			return nil
+		}
+		p.nextToken() // Move to the member type
+
+		member := &ast.Param{Type: p.parseVariableType()}
+		if !p.expectPeek(token.IDENTIFIER) {
+			return nil
+		}
+		member.Name = &ast.Identifier{Pos: p.currTkn.Pos, Value: p.currTkn.Literal}
+
+		if !p.expectPeek(token.SEMICOLON) {
+			return nil
+		}
+		decl.Members = append(decl.Members, member)
+	}
+
+	p.nextToken() // Move to the right brace
+	decl.RightBrace = p.currTkn.Pos
+
+	return decl
+}
+
+// parseEnumDeclaration parses the enum definition e.g. `enum E { A, B }`.
+// It ends on the right brace.
+func (p *parser) parseEnumDeclaration() *ast.EnumDeclaration {
+	if p.trace {
+		defer un(trace("parseEnumDeclaration"))
+	}
+
+	decl := &ast.EnumDeclaration{Pos: p.currTkn.Pos}
+
+	if !p.expectPeek(token.IDENTIFIER) {
+		return nil
+	}
+	decl.Name = &ast.Identifier{Pos: p.currTkn.Pos, Value: p.currTkn.Literal}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	// The enums have at least one member and no trailing comma.
+	for {
+		if !p.expectPeek(token.IDENTIFIER) {
+			return nil
+		}
+		decl.Members = append(decl.Members, &ast.Identifier{Pos: p.currTkn.Pos, Value: p.currTkn.Literal})
+
+		if !p.peekTknIs(token.COMMA) {
+			break
+		}
+		p.nextToken() // Move to the comma
+	}
+
+	if !p.expectPeek(token.RBRACE) {
+		return nil
+	}
+	decl.RightBrace = p.currTkn.Pos
+
+	return decl
+}
+
+// parseErrorDeclaration parses the custom error definition e.g.
+// `error Unauthorized(address caller);`. It ends on the semicolon.
+func (p *parser) parseErrorDeclaration() *ast.ErrorDeclaration {
+	if p.trace {
+		defer un(trace("parseErrorDeclaration"))
+	}
+
+	decl := &ast.ErrorDeclaration{Pos: p.currTkn.Pos}
+
+	if !p.expectPeek(token.IDENTIFIER) {
+		return nil
+	}
+	decl.Name = &ast.Identifier{Pos: p.currTkn.Pos, Value: p.currTkn.Literal}
+
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+	if decl.Params = p.parseParameterList(); decl.Params == nil {
+		return nil
+	}
+
+	if !p.expectPeek(token.SEMICOLON) {
+		return nil
+	}
+	decl.Semicolon = p.currTkn.Pos
+
+	return decl
+}
+
 func (p *parser) parseStatement() ast.Statement {
 	switch tkType := p.currTkn.Type; {
 	default:
 		return p.parseExpressionStatement()
-	case token.IsElementaryType(tkType):
+	case tkType == token.IDENTIFIER && p.currTkn.Literal == "revert" && p.peekTknIs(token.IDENTIFIER):
+		// The `revert("reason")` is a call and is parsed as an expression.
+		return p.parseRevertStatement()
+	case token.IsElementaryType(tkType) || p.currTknIsUserDefinedType():
 		// TODO: Implement other types that variables can have.
 		// TODO: return address(0) and similar should be handled here
-		return p.parseVariableDeclarationStatement()
+		if stmt := p.parseVariableDeclarationStatement(); stmt != nil {
+			return stmt
+		}
+		return nil
 	case tkType == token.LPAREN:
-		return p.parseVariableDeclarationTupleStatement()
+		return p.parseTupleStatement()
 	case tkType == token.LBRACE:
 		return p.parseBlockStatement()
 	case tkType == token.RETURN:
 		return p.parseReturnStatement()
 	case tkType == token.IF:
-		return p.parseIfStatement()
+		if stmt := p.parseIfStatement(); stmt != nil {
+			return stmt
+		}
+		return nil
 	case tkType == token.EMIT:
 		return p.parseEmitStatement()
+	case tkType == token.ASSEMBLY:
+		return p.parseAssemblyStatement()
+	}
+}
+
+// parseAssemblyStatement parses the inline assembly block. The optional
+// dialect string and flags are skipped. The Yul code is not parsed yet; its
+// tokens are skipped up to the matching right brace, collecting the
+// identifiers that can refer to the Solidity declarations. It ends on the
+// right brace.
+func (p *parser) parseAssemblyStatement() *ast.AssemblyStatement {
+	if p.trace {
+		defer un(trace("parseAssemblyStatement"))
+	}
+
+	stmt := &ast.AssemblyStatement{Pos: p.currTkn.Pos}
+
+	// assembly "evmasm" ("memory-safe") { ... }
+	if p.peekTknIs(token.STRING_LITERAL) {
+		p.nextToken()
+	}
+	if p.peekTknIs(token.LPAREN) {
+		p.nextToken()
+		for !p.peekTknIs(token.RPAREN) && !p.peekTknIs(token.EOF) {
+			p.nextToken()
+		}
+		if !p.expectPeek(token.RPAREN) {
+			return nil
+		}
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+	stmt.LeftBrace = p.currTkn.Pos
+
+	depth := 1
+	for depth > 0 {
+		p.nextToken()
+		switch p.currTkn.Type {
+		case token.LBRACE:
+			depth++
+		case token.RBRACE:
+			depth--
+		case token.EOF:
+			p.addError(p.currTkn.Pos, "expected '}' to close the assembly block")
+			return nil
+		case token.IDENTIFIER:
+			// The Yul keywords that are not Solidity keywords.
+			if p.currTkn.Literal == "let" || p.currTkn.Literal == "leave" {
+				continue
+			}
+			stmt.Identifiers = append(stmt.Identifiers,
+				&ast.Identifier{Pos: p.currTkn.Pos, Value: p.currTkn.Literal})
+			// Skip the members e.g. `slot` of `x.slot`.
+			for p.peekTknIs(token.PERIOD) {
+				p.nextToken()
+				p.nextToken()
+			}
+		}
 	}
+	stmt.RightBrace = p.currTkn.Pos
+
+	return stmt
 }
 
 func (p *parser) parseBlockStatement() *ast.BlockStatement {
@@ -898,6 +1580,9 @@ func (p *parser) parseBlockStatement() *ast.BlockStatement {
 			// We have reached the end of the block.
 			blockStmt.RightBrace = p.currTkn.Pos
 			return blockStmt
+		case token.EOF:
+			p.addError(p.currTkn.Pos, "expected '}' to close the block")
+			return blockStmt
 		}
 	}
 }
@@ -915,7 +1600,9 @@ func (p *parser) parseUncheckedBlockStatement() *ast.UncheckedBlockStatement {
 		switch tkType := p.currTkn.Type; {
 		default:
 			stmt := p.parseStatement()
-			blockStmt.Statements = append(blockStmt.Statements, stmt)
+			if stmt != nil {
+				blockStmt.Statements = append(blockStmt.Statements, stmt)
+			}
 			// When we parse a statement e.g. if statement, at the end we will
 			// land at the RBRACE ending the if statement. To ensure that we
 			// handle the scope of the current block correctly, we advance
@@ -930,6 +1617,9 @@ func (p *parser) parseUncheckedBlockStatement() *ast.UncheckedBlockStatement {
 			// We have reached the end of the block.
 			blockStmt.RightBrace = p.currTkn.Pos
 			return blockStmt
+		case tkType == token.EOF:
+			p.addError(p.currTkn.Pos, "expected '}' to close the unchecked block")
+			return blockStmt
 		}
 	}
 }
@@ -942,14 +1632,7 @@ func (p *parser) parseVariableDeclarationStatement() *ast.VariableDeclarationSta
 	vdStmt := &ast.VariableDeclarationStatement{}
 	vdStmt.DataLocation = ast.NO_DATA_LOCATION // assign default value
 
-	vdStmt.Type = &ast.ElementaryType{
-		Pos: p.currTkn.Pos,
-		Kind: token.Token{
-			Type:    p.currTkn.Type,
-			Literal: p.currTkn.Literal,
-			Pos:     p.currTkn.Pos,
-		},
-	}
+	vdStmt.Type = p.parseVariableType()
 
 	if token.IsDataLocation(p.peekTkn.Type) {
 		p.nextToken()
@@ -991,19 +1674,60 @@ func (p *parser) parseVariableDeclarationStatement() *ast.VariableDeclarationSta
 	return vdStmt
 }
 
-func (p *parser) parseVariableDeclarationTupleStatement() *ast.VariableDeclarationTupleStatement {
+// parseTupleStatement parses the statements starting with '(': the tuple
+// declarations e.g. `(uint256 a, , bool b) = f();` and the expression
+// statements e.g. `(a, , b) = f();`. They are told apart by the first
+// component that is not omitted.
+func (p *parser) parseTupleStatement() ast.Statement {
+	if p.trace {
+		defer un(trace("parseTupleStatement"))
+	}
+
+	opening := p.currTkn.Pos
+	p.nextToken() // Consume '('
+
+	omitted := 0
+	for p.currTknIs(token.COMMA) {
+		omitted++
+		p.nextToken()
+	}
+
+	if token.IsElementaryType(p.currTkn.Type) || p.currTknIsUserDefinedType() || p.currTknIs(token.RPAREN) {
+		if stmt := p.parseVariableDeclarationTupleStatement(opening, omitted); stmt != nil {
+			return stmt
+		}
+		return nil
+	}
+
+	exprStmt := &ast.ExpressionStatement{Pos: opening}
+
+	tuple := p.parseTupleComponents(opening, make([]ast.Expression, omitted))
+	if tuple == nil {
+		return nil
+	}
+	exprStmt.Expression = p.parseInfixExpressions(tuple, LOWEST)
+
+	if p.peekTknIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return exprStmt
+}
+
+// parseVariableDeclarationTupleStatement parses the tuple declaration starting
+// at the first component after the omitted ones.
+func (p *parser) parseVariableDeclarationTupleStatement(opening token.Pos, omitted int) *ast.VariableDeclarationTupleStatement {
 	if p.trace {
 		defer un(trace("parseVariableDeclarationTupleStatement"))
 	}
 
 	vdTupleStmt := &ast.VariableDeclarationTupleStatement{
-		Opening: p.currTkn.Pos,
+		Opening:      opening,
+		Declarations: make([]*ast.VariableDeclarationStatement, omitted),
 	}
 
-	p.nextToken() // Consume '('
-
-	if !p.currTknIs(token.RPAREN) {
-		if token.IsElementaryType(p.currTkn.Type) {
+	if omitted > 0 || !p.currTknIs(token.RPAREN) {
+		if token.IsElementaryType(p.currTkn.Type) || p.currTknIsUserDefinedType() {
 			vdTupleStmt.Declarations = append(vdTupleStmt.Declarations, p.parseVariableDeclarationPart())
 		} else {
 			vdTupleStmt.Declarations = append(vdTupleStmt.Declarations, nil)
@@ -1019,7 +1743,7 @@ func (p *parser) parseVariableDeclarationTupleStatement() *ast.VariableDeclarati
 			}
 
 			// Parse the next element (or an empty slot for cases like ",,")
-			if token.IsElementaryType(p.currTkn.Type) {
+			if token.IsElementaryType(p.currTkn.Type) || p.currTknIsUserDefinedType() {
 				vdTupleStmt.Declarations = append(vdTupleStmt.Declarations, p.parseVariableDeclarationPart())
 			} else {
 				vdTupleStmt.Declarations = append(vdTupleStmt.Declarations, nil)
@@ -1062,7 +1786,7 @@ func (p *parser) parseVariableDeclarationPart() *ast.VariableDeclarationStatemen
 		DataLocation: ast.NO_DATA_LOCATION,
 	}
 
-	part.Type = &ast.ElementaryType{Pos: p.currTkn.Pos, Kind: p.currTkn}
+	part.Type = p.parseVariableType()
 
 	if token.IsDataLocation(p.peekTkn.Type) {
 		p.nextToken()
@@ -1095,6 +1819,13 @@ func (p *parser) parseReturnStatement() *ast.ReturnStatement {
 	retStmt := &ast.ReturnStatement{}
 	retStmt.Pos = p.currTkn.Pos
 
+	// The returned expression is optional e.g. `return;` in the functions
+	// with the named returns.
+	if p.peekTknIs(token.SEMICOLON) {
+		p.nextToken()
+		return retStmt
+	}
+
 	// Advance to the next token; parse the expression.
 	p.nextToken()
 	retStmt.Result = p.parseExpression(LOWEST)
@@ -1165,6 +1896,27 @@ func (p *parser) parseIfStatement() *ast.IfStatement {
 	return ifStmt
 }
 
+// parseRevertStatement parses the revert with a custom error e.g.
+// `revert Unauthorized(msg.sender);`. It ends on the semicolon.
+func (p *parser) parseRevertStatement() *ast.RevertStatement {
+	if p.trace {
+		defer un(trace("parseRevertStatement"))
+	}
+
+	revertStmt := &ast.RevertStatement{
+		Pos: p.currTkn.Pos, // parser is sitting on the revert keyword
+	}
+
+	p.nextToken() // Move past the revert keyword
+	revertStmt.Expression = p.parseExpression(LOWEST)
+
+	if p.peekTknIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return revertStmt
+}
+
 func (p *parser) parseEmitStatement() *ast.EmitStatement {
 	if p.trace {
 		defer un(trace("parseEmitStatement"))
@@ -1209,6 +1961,20 @@ func (p *parser) peekError(t token.TokenType) {
 }
 
 // currTknIs checks if the current token is of the expected type.
+// currTknIsErrorKeyword reports whether the parser is sitting on the start
+// of an error definition. The "error" is not a keyword, so it is lexed as an
+// identifier.
+func (p *parser) currTknIsErrorKeyword() bool {
+	return p.currTknIs(token.IDENTIFIER) && p.currTkn.Literal == "error" && p.peekTknIs(token.IDENTIFIER)
+}
+
+// currTknIsUserDefinedType reports whether the parser is sitting on the
+// user-defined type of a variable declaration e.g. `S` in `S memory s` or
+// `E e`, as opposed to an identifier starting an expression.
+func (p *parser) currTknIsUserDefinedType() bool {
+	return p.currTknIs(token.IDENTIFIER) && (p.peekTknIs(token.IDENTIFIER) || token.IsDataLocation(p.peekTkn.Type))
+}
+
 func (p *parser) currTknIs(t token.TokenType) bool {
 	return p.currTkn.Type == t
 }
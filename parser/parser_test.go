@@ -184,6 +184,159 @@ func Test_ParseFunctionDeclaration(t *testing.T) {
 	test_Identifier(t, rtStmt.Result, "tester")
 }
 
+func Test_ParseFunctionHeader(t *testing.T) {
+	src := `contract C {
+    function deposit(uint256 amount) external payable virtual override(A, B) onlyOwner atLeast(amount, 1) returns (bool ok) { }
+    function withdraw() public;
+    function helper() pure { }
+}`
+
+	file := test_helper_parseSource(t, src, false)
+	contract := file.Declarations[0].(*ast.ContractDeclaration)
+
+	deposit := contract.Body.Declarations[0].(*ast.FunctionDeclaration)
+	if deposit.Visibility != ast.External {
+		t.Errorf("Expected external visibility, got %s", deposit.Visibility)
+	}
+	if deposit.Mutability != ast.Payable {
+		t.Errorf("Expected payable mutability, got %s", deposit.Mutability)
+	}
+	if !deposit.Virtual {
+		t.Errorf("Expected the function to be virtual")
+	}
+	if deposit.Override == nil || len(deposit.Override.Overrides) != 2 {
+		t.Fatalf("Expected override(A, B), got %v", deposit.Override)
+	}
+	if len(deposit.Modifiers) != 2 {
+		t.Fatalf("Expected 2 modifiers, got %d", len(deposit.Modifiers))
+	}
+	if got := deposit.Modifiers[0].String(); got != "onlyOwner" {
+		t.Errorf("Expected onlyOwner, got %s", got)
+	}
+	if got := deposit.Modifiers[1].String(); got != "atLeast(amount, 1)" {
+		t.Errorf("Expected atLeast(amount, 1), got %s", got)
+	}
+	if deposit.Results == nil || deposit.Results.String() != "(bool ok)" {
+		t.Errorf("Expected the results (bool ok), got %v", deposit.Results)
+	}
+	if deposit.Body == nil {
+		t.Errorf("Expected the function to have a body")
+	}
+
+	withdraw := contract.Body.Declarations[1].(*ast.FunctionDeclaration)
+	if withdraw.Body != nil || withdraw.Semicolon == 0 {
+		t.Errorf("Expected the function without the body to end with a semicolon")
+	}
+
+	helper := contract.Body.Declarations[2].(*ast.FunctionDeclaration)
+	if helper.Visibility != ast.Internal {
+		t.Errorf("Expected the default internal visibility, got %s", helper.Visibility)
+	}
+	if helper.Mutability != ast.Pure {
+		t.Errorf("Expected pure mutability, got %s", helper.Mutability)
+	}
+}
+
+func Test_ParseConstructorAndAssembly(t *testing.T) {
+	src := `contract C is A {
+    address zero = address(0);
+    constructor(uint256 x) A(x) payable onlyOwner {
+        assembly ("memory-safe") {
+            let v := add(x, 1)
+            if gt(v, 0) { sstore(total.slot, v) }
+        }
+    }
+}`
+
+	file := test_helper_parseSource(t, src, false)
+	contract := file.Declarations[0].(*ast.ContractDeclaration)
+
+	if len(contract.Body.Declarations) != 2 {
+		t.Fatalf("Expected 2 declarations, got %d", len(contract.Body.Declarations))
+	}
+
+	constructor := contract.Body.Declarations[1].(*ast.ConstructorDeclaration)
+	if constructor.Params.String() != "(uint256 x)" {
+		t.Errorf("Expected the params (uint256 x), got %s", constructor.Params)
+	}
+	if constructor.Mutability != ast.Payable {
+		t.Errorf("Expected payable mutability, got %s", constructor.Mutability)
+	}
+	if len(constructor.Modifiers) != 2 || constructor.Modifiers[0].String() != "A(x)" {
+		t.Fatalf("Expected the modifiers A(x) and onlyOwner, got %v", constructor.Modifiers)
+	}
+
+	assembly := constructor.Body.Statements[0].(*ast.AssemblyStatement)
+	var idents []string
+	for _, ident := range assembly.Identifiers {
+		idents = append(idents, ident.Value)
+	}
+	if got := strings.Join(idents, " "); got != "v add x gt v sstore total v" {
+		t.Errorf("Expected the identifiers v add x gt v sstore total v, got %s", got)
+	}
+}
+
+func Test_ParseUserDefinedTypesAndRevert(t *testing.T) {
+	src := `contract C {
+    struct Position { uint256 amount; address owner; }
+    enum Status { Active, Paused }
+    error Unauthorized(address caller);
+    Position position;
+    Status public status = Status.Active;
+    function f(Position memory p, address caller) public {
+        Position storage current = position;
+        (Status s, Position memory q) = g();
+        if (caller != p.owner) revert Unauthorized(caller);
+        revert("reason");
+    }
+}`
+
+	file := test_helper_parseSource(t, src, false)
+	contract := file.Declarations[0].(*ast.ContractDeclaration)
+
+	if len(contract.Body.Declarations) != 6 {
+		t.Fatalf("Expected 6 declarations, got %d", len(contract.Body.Declarations))
+	}
+
+	tests := []string{
+		"struct Position { uint256 amount; address owner; }",
+		"enum Status { Active, Paused }",
+		"error Unauthorized(address caller);",
+	}
+	for i, expected := range tests {
+		if got := contract.Body.Declarations[i].String(); got != expected {
+			t.Errorf("Expected the declaration %q, got %q", expected, got)
+		}
+	}
+
+	stateVar := contract.Body.Declarations[4].(*ast.StateVariableDeclaration)
+	if stateVar.Type.String() != "Status" || stateVar.Visibility != ast.Public {
+		t.Errorf("Expected the public state variable of type Status, got %s", stateVar.Type)
+	}
+
+	fn := contract.Body.Declarations[5].(*ast.FunctionDeclaration)
+	local := fn.Body.Statements[0].(*ast.VariableDeclarationStatement)
+	if local.Type.String() != "Position" || local.DataLocation != ast.Storage {
+		t.Errorf("Expected the storage local of type Position, got %s", local.Type)
+	}
+	tuple := fn.Body.Statements[1].(*ast.VariableDeclarationTupleStatement)
+	if len(tuple.Declarations) != 2 || tuple.Declarations[1].Type.String() != "Position" {
+		t.Errorf("Expected the tuple declaration of Status and Position, got %s", tuple)
+	}
+
+	ifStmt := fn.Body.Statements[2].(*ast.IfStatement)
+	revert, ok := ifStmt.Consequence.(*ast.RevertStatement)
+	if !ok {
+		t.Fatalf("Expected RevertStatement, got %T", ifStmt.Consequence)
+	}
+	if revert.String() != "revert Unauthorized(caller);" {
+		t.Errorf("Expected the revert with Unauthorized, got %s", revert)
+	}
+	if _, ok := fn.Body.Statements[3].(*ast.ExpressionStatement); !ok {
+		t.Errorf("Expected the revert with a reason to be an expression, got %T", fn.Body.Statements[3])
+	}
+}
+
 func Test_ParseContractDeclaration(t *testing.T) {
 	src := `
     contract MyContract is BaseContract {
@@ -268,10 +421,11 @@ func Test_ParseReturnStatement(t *testing.T) {
     return staked;
     return address(0);
     return uint256(a + b);
+    return;
     }
     `
 
-	numReturns := 6
+	numReturns := 7
 
 	file := test_helper_parseSource(t, src, false)
 
@@ -305,6 +459,11 @@ func Test_ParseReturnStatement(t *testing.T) {
 
 		test_LiteralExpression(t, retStmt.Result, tt.expectedValue)
 	}
+
+	// The returned expression is optional.
+	if retStmt, ok := fnBody.Statements[6].(*ast.ReturnStatement); !ok || retStmt.Result != nil {
+		t.Errorf("Expected a ReturnStatement without the result, got %s", fnBody.Statements[6])
+	}
 }
 
 func Test_ParseBlocks(t *testing.T) {
@@ -1238,3 +1397,68 @@ func TestParseDeclarations(t *testing.T) {
 		})
 	}
 }
+
+func Test_ParseFile_WithFileSet(t *testing.T) {
+	fset := token.NewFileSet()
+
+	srcA := "contract A {}"
+	srcB := "contract B {\n    uint256 x = 1;\n}"
+
+	fileA, err := parser.ParseFile("A.sol", strings.NewReader(srcA), parser.WithFileSet(fset))
+	if err != nil {
+		t.Fatalf("ParseFile failed for A.sol: %v", err)
+	}
+	fileB, err := parser.ParseFile("B.sol", strings.NewReader(srcB), parser.WithFileSet(fset))
+	if err != nil {
+		t.Fatalf("ParseFile failed for B.sol: %v", err)
+	}
+
+	if files := fset.Files(); len(files) != 2 || files[0] != fileA.SourceFile || files[1] != fileB.SourceFile {
+		t.Fatalf("Expected the file set to hold A.sol and B.sol, got %v", files)
+	}
+
+	// The positions of the second file start after the first file.
+	contractB := fileB.Declarations[0].(*ast.ContractDeclaration)
+	if int(contractB.Start()) != len(srcA)+1 {
+		t.Errorf("Expected contract B to start at %d, got %d", len(srcA)+1, contractB.Start())
+	}
+
+	stateVar := contractB.Body.Declarations[0].(*ast.StateVariableDeclaration)
+	if position := fset.Position(stateVar.Name.Pos); position.String() != "B.sol:2:13" {
+		t.Errorf("Expected the state variable at B.sol:2:13, got %s", position)
+	}
+}
+
+func Test_ParseAddressPayable(t *testing.T) {
+	src := `contract C {
+    address payable owner;
+    function f() {
+        address payable recipient = payable(msg.sender);
+    }
+}`
+	file := test_helper_parseSource(t, src, false)
+	contract := file.Declarations[0].(*ast.ContractDeclaration)
+
+	stateVar := contract.Body.Declarations[0].(*ast.StateVariableDeclaration)
+	stateVarType, ok := stateVar.Type.(*ast.ElementaryType)
+	if !ok || stateVarType.Payable == 0 || stateVarType.String() != "address payable" {
+		t.Fatalf("Expected the state variable of type address payable, got %s", stateVar.Type)
+	}
+
+	fn := contract.Body.Declarations[1].(*ast.FunctionDeclaration)
+	decl, ok := fn.Body.Statements[0].(*ast.VariableDeclarationStatement)
+	if !ok {
+		t.Fatalf("Expected VariableDeclarationStatement, got %T", fn.Body.Statements[0])
+	}
+	if declType, ok := decl.Type.(*ast.ElementaryType); !ok || declType.Payable == 0 {
+		t.Errorf("Expected the variable of type address payable, got %s", decl.Type)
+	}
+
+	conversion, ok := decl.Value.(*ast.ElementaryTypeExpression)
+	if !ok || conversion.Kind.Type != token.PAYABLE {
+		t.Fatalf("Expected the payable(...) conversion, got %T", decl.Value)
+	}
+	if conversion.String() != "payable((msg.sender))" {
+		t.Errorf("Expected payable((msg.sender)), got %s", conversion)
+	}
+}
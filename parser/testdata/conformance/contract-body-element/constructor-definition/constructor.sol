@@ -0,0 +1,5 @@
+contract C {
+    constructor(uint256 a) payable {
+        a;
+    }
+}
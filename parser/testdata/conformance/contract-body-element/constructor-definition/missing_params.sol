@@ -0,0 +1,5 @@
+contract C {
+    constructor {}
+}
+// ----
+// ParserError: (29-30): Expected '(' but got '{'
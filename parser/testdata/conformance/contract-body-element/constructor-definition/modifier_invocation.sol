@@ -0,0 +1,3 @@
+contract C is A {
+    constructor() A(1) internal {}
+}
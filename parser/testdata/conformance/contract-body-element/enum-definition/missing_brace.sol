@@ -0,0 +1,5 @@
+contract C {
+    enum E A, B }
+}
+// ----
+// ParserError: (24-25): Expected '{' but got identifier
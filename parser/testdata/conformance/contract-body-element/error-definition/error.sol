@@ -0,0 +1,3 @@
+contract C {
+    error Unauthorized(address caller);
+}
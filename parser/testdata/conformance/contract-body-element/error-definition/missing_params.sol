@@ -0,0 +1,5 @@
+contract C {
+    error Unauthorized;
+}
+// ----
+// ParserError: (35-36): Expected '(' but got ';'
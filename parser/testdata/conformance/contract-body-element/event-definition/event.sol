@@ -0,0 +1,3 @@
+contract C {
+    event Deposit(address indexed from, uint256 amount);
+}
@@ -0,0 +1,5 @@
+contract C {
+    event (uint256 a);
+}
+// ----
+// ParserError: (23-24): Expected identifier but got '('
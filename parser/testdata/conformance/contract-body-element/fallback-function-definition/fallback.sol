@@ -0,0 +1,3 @@
+contract C {
+    fallback() external payable {}
+}
@@ -0,0 +1,5 @@
+contract C {
+    fallback external {}
+}
+// ----
+// ParserError: (26-34): Expected '(' but got 'external'
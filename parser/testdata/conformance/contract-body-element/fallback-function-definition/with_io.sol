@@ -0,0 +1,3 @@
+contract C {
+    fallback(bytes calldata input) external returns (bytes memory output) {}
+}
@@ -0,0 +1,5 @@
+contract C {
+    function f(uint256 a) external view virtual override returns (uint256 b) {
+        return a;
+    }
+}
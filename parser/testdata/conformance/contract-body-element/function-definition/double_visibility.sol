@@ -0,0 +1,5 @@
+contract C {
+    function f() public public {}
+}
+// ----
+// ParserError: (37-43): Visibility already specified as "public".
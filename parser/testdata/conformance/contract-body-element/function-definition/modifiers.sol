@@ -0,0 +1,3 @@
+contract C {
+    function f() public onlyOwner nonReentrant(1) {}
+}
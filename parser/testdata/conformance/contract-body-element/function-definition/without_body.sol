@@ -0,0 +1,3 @@
+abstract contract C {
+    function f() public virtual;
+}
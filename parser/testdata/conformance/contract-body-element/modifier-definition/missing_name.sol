@@ -0,0 +1,7 @@
+contract C {
+    modifier () {
+        _;
+    }
+}
+// ----
+// ParserError: (26-27): Expected identifier but got '('
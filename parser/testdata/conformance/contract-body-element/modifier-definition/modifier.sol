@@ -0,0 +1,5 @@
+contract C {
+    modifier onlyOwner() {
+        _;
+    }
+}
@@ -0,0 +1,3 @@
+abstract contract C {
+    modifier m(uint256 a) virtual;
+}
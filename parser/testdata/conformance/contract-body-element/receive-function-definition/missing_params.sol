@@ -0,0 +1,5 @@
+contract C {
+    receive external payable {}
+}
+// ----
+// ParserError: (25-33): Expected '(' but got 'external'
@@ -0,0 +1,3 @@
+contract C {
+    receive() external payable {}
+}
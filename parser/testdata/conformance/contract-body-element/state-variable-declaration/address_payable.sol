@@ -0,0 +1,3 @@
+contract C {
+    address payable owner;
+}
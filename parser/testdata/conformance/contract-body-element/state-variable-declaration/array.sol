@@ -0,0 +1,3 @@
+contract C {
+    uint256[] values;
+}
@@ -0,0 +1,5 @@
+contract C {
+    uint256 public public x;
+}
+// ----
+// ParserError: (32-38): Visibility already specified as "public".
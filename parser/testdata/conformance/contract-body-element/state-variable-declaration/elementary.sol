@@ -0,0 +1,5 @@
+contract C {
+    uint256 public constant X = 1;
+    address owner;
+    bool private flag = true;
+}
@@ -0,0 +1,3 @@
+contract C {
+    IERC20 token;
+}
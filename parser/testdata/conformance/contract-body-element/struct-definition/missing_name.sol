@@ -0,0 +1,7 @@
+contract C {
+    struct {
+        uint256 a;
+    }
+}
+// ----
+// ParserError: (24-25): Expected identifier but got '{'
@@ -0,0 +1,6 @@
+contract C {
+    struct S {
+        uint256 a;
+        address b;
+    }
+}
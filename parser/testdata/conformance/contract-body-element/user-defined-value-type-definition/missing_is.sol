@@ -0,0 +1,5 @@
+contract C {
+    type Price uint128;
+}
+// ----
+// ParserError: (28-35): Expected 'is' but got 'uint128'
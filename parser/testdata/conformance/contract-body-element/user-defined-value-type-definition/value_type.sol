@@ -0,0 +1,3 @@
+contract C {
+    type Price is uint128;
+}
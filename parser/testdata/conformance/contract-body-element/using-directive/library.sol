@@ -0,0 +1,3 @@
+contract C {
+    using SafeERC20 for IERC20;
+}
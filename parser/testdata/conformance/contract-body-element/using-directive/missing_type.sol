@@ -0,0 +1,5 @@
+contract C {
+    using L for;
+}
+// ----
+// ParserError: (28-29): Expected type name
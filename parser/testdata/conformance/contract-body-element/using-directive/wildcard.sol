@@ -0,0 +1,3 @@
+contract C {
+    using L for *;
+}
@@ -0,0 +1 @@
+uint256 constant X = 1;
@@ -0,0 +1,3 @@
+uint256 x = 1;
+// ----
+// ParserError: (0-13): Only constant variables are allowed at file level.
@@ -0,0 +1 @@
+Price constant ONE = Price.wrap(1);
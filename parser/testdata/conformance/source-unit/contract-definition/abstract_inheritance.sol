@@ -0,0 +1 @@
+abstract contract C is A, B {}
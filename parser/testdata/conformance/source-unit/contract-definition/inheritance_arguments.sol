@@ -0,0 +1 @@
+contract C is A(1), B {}
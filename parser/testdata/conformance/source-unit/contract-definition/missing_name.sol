@@ -0,0 +1,3 @@
+contract {}
+// ----
+// ParserError: (9-10): Expected identifier but got '{'
@@ -0,0 +1,3 @@
+enum E { A, }
+// ----
+// ParserError: (12-13): Expected identifier after ','
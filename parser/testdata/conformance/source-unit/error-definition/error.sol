@@ -0,0 +1 @@
+error Unauthorized(address caller, uint256 amount);
@@ -0,0 +1,3 @@
+error Unauthorized;
+// ----
+// ParserError: (18-19): Expected '(' but got ';'
@@ -0,0 +1 @@
+event Log(uint256) anonymous;
@@ -0,0 +1 @@
+event Transfer(address indexed from, address indexed to, uint256 value);
@@ -0,0 +1,4 @@
+event Log(uint256)
+contract C {}
+// ----
+// ParserError: (19-27): Expected ';' but got 'contract'
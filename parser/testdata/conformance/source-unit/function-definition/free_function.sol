@@ -0,0 +1,3 @@
+function f(uint256 a, uint256 b) {
+    a + b;
+}
@@ -0,0 +1,3 @@
+function (uint256 a) {}
+// ----
+// ParserError: (9-10): Expected identifier but got '('
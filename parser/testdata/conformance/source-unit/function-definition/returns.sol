@@ -0,0 +1,3 @@
+function f(uint256 a) pure returns (uint256) {
+    return a;
+}
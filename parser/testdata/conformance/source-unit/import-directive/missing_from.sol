@@ -0,0 +1,3 @@
+import {A} "./A.sol";
+// ----
+// ParserError: (11-20): Expected 'from' but got 'StringLiteral'
@@ -0,0 +1 @@
+import "./A.sol";
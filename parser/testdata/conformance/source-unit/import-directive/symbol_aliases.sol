@@ -0,0 +1 @@
+import {A, B as C} from "./A.sol";
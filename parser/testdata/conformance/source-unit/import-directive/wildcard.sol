@@ -0,0 +1 @@
+import * as M from "./A.sol";
@@ -0,0 +1 @@
+interface I {}
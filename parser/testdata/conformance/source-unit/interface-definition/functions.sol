@@ -0,0 +1,3 @@
+interface I is J {
+    function f(uint256 a) external returns (uint256);
+}
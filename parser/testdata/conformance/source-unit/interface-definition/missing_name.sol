@@ -0,0 +1,3 @@
+interface {}
+// ----
+// ParserError: (10-11): Expected identifier but got '{'
@@ -0,0 +1 @@
+library L {}
@@ -0,0 +1,5 @@
+library L {
+    function f(uint256 a) internal pure returns (uint256) {
+        return a;
+    }
+}
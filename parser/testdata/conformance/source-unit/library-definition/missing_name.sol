@@ -0,0 +1,3 @@
+library {}
+// ----
+// ParserError: (8-9): Expected identifier but got '{'
@@ -0,0 +1 @@
+pragma abicoder v2;
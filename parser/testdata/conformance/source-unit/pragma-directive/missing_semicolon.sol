@@ -0,0 +1,3 @@
+pragma solidity ^0.8.0
+// ----
+// ParserError: (23-23): Expected ';' but got end of source
@@ -0,0 +1,5 @@
+struct S {
+    uint256 a
+}
+// ----
+// ParserError: (27-28): Expected ';' but got '}'
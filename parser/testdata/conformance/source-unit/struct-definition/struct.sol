@@ -0,0 +1,4 @@
+struct S {
+    uint256 a;
+    address b;
+}
@@ -0,0 +1,3 @@
+type Price uint128;
+// ----
+// ParserError: (11-18): Expected 'is' but got 'uint128'
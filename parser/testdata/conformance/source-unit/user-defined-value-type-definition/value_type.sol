@@ -0,0 +1 @@
+type Price is uint128;
@@ -0,0 +1 @@
+using {add, sub} for uint256 global;
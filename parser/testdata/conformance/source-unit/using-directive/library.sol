@@ -0,0 +1 @@
+using L for uint256;
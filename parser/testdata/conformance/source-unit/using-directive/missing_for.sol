@@ -0,0 +1,3 @@
+using L uint256;
+// ----
+// ParserError: (8-15): Expected 'for' but got 'uint256'
@@ -0,0 +1 @@
+using {add as +} for Fixed global;
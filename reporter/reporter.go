@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"github.com/ChmielewskiKamil/solbot/token"
 	"os"
-	"strings"
+	"sort"
 	"text/template"
 )
 
@@ -18,17 +18,72 @@ type Finding struct {
 }
 
 type Location struct {
-	Position token.Position // Position data of the finding e.g. file, line, column.
-	Context  string         // The line with the issue itself or with its surroundings.
+	Position   token.Position // Position data of the finding e.g. file, line, column.
+	Context    string         // The line with the issue itself or with its surroundings.
+	Suggestion string         // Suggested replacement of the Context or empty.
+	Edits      []Edit         // Suggested changes of the source fixing the issue; or nil.
 }
 
-func (f *Finding) CalculatePositions(file *token.SourceFile) {
+// Edit is a change of the source: the text in the range [Pos, End) is
+// replaced with the NewText. An empty NewText deletes the text.
+type Edit struct {
+	Pos     token.Pos
+	End     token.Pos
+	NewText string
+}
+
+// ReplaceAll returns the edits replacing the text of the given length at
+// each of the positions with the new text e.g. renaming an identifier.
+func ReplaceAll(positions []token.Pos, length int, newText string) []Edit {
+	edits := make([]Edit, 0, len(positions))
+	for _, pos := range positions {
+		edits = append(edits, Edit{
+			Pos:     pos,
+			End:     pos + token.Pos(length),
+			NewText: newText,
+		})
+	}
+	return edits
+}
+
+// ApplyEdits returns the content of the file with the edits applied. The
+// edits can be given in any order, but they must be within the file and
+// must not overlap, since the result of the overlapping ones is ambiguous.
+func ApplyEdits(file *token.SourceFile, edits []Edit) (string, error) {
+	sorted := make([]Edit, len(edits))
+	copy(sorted, edits)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Pos < sorted[j].Pos })
+
+	content := file.Content()
+	var out bytes.Buffer
+	last := 0
+	for _, edit := range sorted {
+		start, end := file.Offset(edit.Pos), file.Offset(edit.End)
+		if start < 0 || end > len(content) || start > end {
+			return "", fmt.Errorf("edit [%d, %d) is outside of the file %s", edit.Pos, edit.End, file.Name())
+		}
+		if start < last {
+			return "", fmt.Errorf("edit [%d, %d) overlaps the previous edit", edit.Pos, edit.End)
+		}
+		out.WriteString(content[last:start])
+		out.WriteString(edit.NewText)
+		last = end
+	}
+	out.WriteString(content[last:])
+	return out.String(), nil
+}
+
+// PositionResolver converts a Pos into a Position. It is implemented by both
+// *token.SourceFile and *token.FileSet.
+type PositionResolver interface {
+	Position(pos token.Pos) token.Position
+}
+
+// CalculatePositions fills in the file name, line and column of the
+// locations based on their offsets.
+func (f *Finding) CalculatePositions(resolver PositionResolver) {
 	for i := range f.Locations {
-		// TODO: This resets reader's state, but it is inefficient.
-		reader := strings.NewReader(file.Content())
-		token.OffsetToPosition(reader, &f.Locations[i].Position)
-		// TODO: Add file name to the position.
-		f.Locations[i].Position.Filename = file.Name()
+		f.Locations[i].Position = resolver.Position(f.Locations[i].Position.Offset)
 	}
 }
 
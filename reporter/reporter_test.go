@@ -0,0 +1,70 @@
+package reporter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+func Test_ApplyEdits(t *testing.T) {
+	src := `uint256 total = fees + total;`
+	file, err := token.NewSourceFile("test.sol", src)
+	if err != nil {
+		t.Fatalf("NewSourceFile failed: %v", err)
+	}
+
+	// The edits are not sorted; the last one deletes the text.
+	edits := []Edit{
+		{Pos: 23, End: 28, NewText: "_total"},
+		{Pos: 8, End: 13, NewText: "_total"},
+		{Pos: 16, End: 23, NewText: ""},
+	}
+
+	got, err := ApplyEdits(file, edits)
+	if err != nil {
+		t.Fatalf("ApplyEdits failed: %v", err)
+	}
+	if expected := `uint256 _total = _total;`; got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func Test_ApplyEdits_Errors(t *testing.T) {
+	src := `uint256 total;`
+	file, err := token.NewSourceFile("test.sol", src)
+	if err != nil {
+		t.Fatalf("NewSourceFile failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		edits    []Edit
+		expected string
+	}{
+		{
+			"duplicate",
+			[]Edit{{Pos: 8, End: 13, NewText: "_total"}, {Pos: 8, End: 13, NewText: "_total"}},
+			"overlaps the previous edit",
+		},
+		{
+			"overlapping",
+			[]Edit{{Pos: 0, End: 10, NewText: ""}, {Pos: 8, End: 13, NewText: "_total"}},
+			"overlaps the previous edit",
+		},
+		{
+			"outside",
+			[]Edit{{Pos: 8, End: 20, NewText: "_total"}},
+			"is outside of the file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ApplyEdits(file, tt.edits)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected an error containing %q, got: %v", tt.expected, err)
+			}
+		})
+	}
+}
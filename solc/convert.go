@@ -0,0 +1,1077 @@
+package solc
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+type converter struct {
+	file    *token.SourceFile
+	content string
+	errors  ErrorList
+}
+
+// pos converts the byte offset from the compiler's "src" into a Pos.
+func (c *converter) pos(offset int) token.Pos { return c.file.Pos(offset) }
+
+// offset converts the Pos of a converted node back into a byte offset.
+func (c *converter) offset(pos token.Pos) int { return c.file.Offset(pos) }
+
+// rangeOf returns the location of the node. Nodes without a valid location
+// get an empty range at the start of the file.
+func (c *converter) rangeOf(n node) srcRange {
+	r, _ := parseSrc(n.str("src"))
+	return r
+}
+
+// text returns the source code covered by the range.
+func (c *converter) text(r srcRange) string {
+	if r.end() > len(c.content) {
+		return ""
+	}
+	return c.content[r.start:r.end()]
+}
+
+// find returns the offset of the first occurrence of s in the source in
+// the [from, to) range or -1 if it is not there.
+func (c *converter) find(s string, from, to int) int {
+	if from < 0 || to > len(c.content) || from > to {
+		return -1
+	}
+	if i := strings.Index(c.content[from:to], s); i >= 0 {
+		return from + i
+	}
+	return -1
+}
+
+// findLast is like find, but returns the last occurrence.
+func (c *converter) findLast(s string, from, to int) int {
+	if from < 0 || to > len(c.content) || from > to {
+		return -1
+	}
+	if i := strings.LastIndex(c.content[from:to], s); i >= 0 {
+		return from + i
+	}
+	return -1
+}
+
+// name converts the name of a declaration into an identifier. The position
+// comes from "nameLocation" (solc >= 0.8.2) or, for older compilers, from
+// the first occurrence of the name in the declaration's source.
+func (c *converter) name(n node) *ast.Identifier {
+	name := n.str("name")
+	if name == "" {
+		return nil
+	}
+
+	if r, ok := parseSrc(n.str("nameLocation")); ok {
+		return &ast.Identifier{Pos: c.pos(r.start), Value: name}
+	}
+
+	r := c.rangeOf(n)
+	offset := r.start
+	if i := c.find(name, r.start, r.end()); i >= 0 {
+		offset = i
+	}
+
+	return &ast.Identifier{Pos: c.pos(offset), Value: name}
+}
+
+// identifier converts Identifier and IdentifierPath nodes.
+func (c *converter) identifier(n node) *ast.Identifier {
+	if n == nil {
+		return nil
+	}
+
+	return &ast.Identifier{Pos: c.pos(c.rangeOf(n).start), Value: n.str("name")}
+}
+
+/*~*~*~*~*~*~*~*~*~*~*~*~ Declarations ~*~*~*~*~*~*~*~*~*~*~*~*~*/
+
+func (c *converter) convertSourceUnit(n node) *ast.File {
+	file := &ast.File{
+		Name:         c.file.Name(),
+		SourceFile:   c.file,
+		Declarations: []ast.Declaration{},
+	}
+
+	for _, child := range n.children("nodes") {
+		if decl := c.convertSourceUnitDeclaration(child); decl != nil {
+			file.Declarations = append(file.Declarations, decl)
+		}
+	}
+
+	return file
+}
+
+func (c *converter) convertSourceUnitDeclaration(n node) ast.Declaration {
+	switch n.nodeType() {
+	case "PragmaDirective":
+		// Pragmas are skipped by the parser as well.
+		return nil
+	case "ContractDefinition":
+		return c.convertContractDefinition(n)
+	case "FunctionDefinition":
+		return c.convertFunctionDefinition(n)
+	case "EventDefinition":
+		return c.convertEventDefinition(n)
+	case "StructDefinition":
+		return c.convertStructDefinition(n)
+	case "EnumDefinition":
+		return c.convertEnumDefinition(n)
+	case "ErrorDefinition":
+		return c.convertErrorDefinition(n)
+	case "ImportDirective":
+		return c.convertImportDirective(n)
+	case "UsingForDirective":
+		return c.convertUsingForDirective(n)
+	case "VariableDeclaration":
+		return c.convertConstantVariableDeclaration(n)
+	default:
+		c.unsupported(n)
+		return nil
+	}
+}
+
+func (c *converter) convertContractDefinition(n node) ast.Declaration {
+	if kind := n.str("contractKind"); kind != "contract" {
+		c.addError(n, "unsupported contract kind: "+kind)
+		return nil
+	}
+
+	r := c.rangeOf(n)
+	decl := &ast.ContractDeclaration{
+		ContractBase: ast.ContractBase{
+			Pos:  c.pos(r.start),
+			Name: c.name(n),
+		},
+		Abstract: n.boolean("abstract"),
+	}
+
+	for _, base := range n.children("baseContracts") {
+		decl.Parents = append(decl.Parents, c.identifier(base.child("baseName")))
+	}
+
+	body := &ast.ContractBody{
+		LeftBrace:    c.pos(c.find("{", c.offset(decl.Name.End()), r.end())),
+		Declarations: []ast.Declaration{},
+		RightBrace:   c.pos(r.end() - 1),
+	}
+
+	for _, child := range n.children("nodes") {
+		if d := c.convertContractBodyDeclaration(child); d != nil {
+			body.Declarations = append(body.Declarations, d)
+		}
+	}
+
+	decl.Body = body
+
+	return decl
+}
+
+func (c *converter) convertContractBodyDeclaration(n node) ast.Declaration {
+	switch n.nodeType() {
+	case "FunctionDefinition":
+		return c.convertFunctionDefinition(n)
+	case "ModifierDefinition":
+		return c.convertModifierDefinition(n)
+	case "VariableDeclaration":
+		return c.convertStateVariableDeclaration(n)
+	case "EventDefinition":
+		return c.convertEventDefinition(n)
+	case "StructDefinition":
+		return c.convertStructDefinition(n)
+	case "EnumDefinition":
+		return c.convertEnumDefinition(n)
+	case "ErrorDefinition":
+		return c.convertErrorDefinition(n)
+	case "UsingForDirective":
+		return c.convertUsingForDirective(n)
+	default:
+		c.unsupported(n)
+		return nil
+	}
+}
+
+func (c *converter) convertFunctionDefinition(n node) ast.Declaration {
+	switch n.str("kind") {
+	case "constructor":
+		return c.convertConstructorDefinition(n)
+	case "receive", "fallback":
+		return c.convertSpecialFunctionDefinition(n)
+	}
+
+	if kind := n.str("kind"); kind != "function" && kind != "freeFunction" {
+		c.addError(n, "unsupported function kind: "+kind)
+		return nil
+	}
+
+	r := c.rangeOf(n)
+	decl := &ast.FunctionDeclaration{
+		Pos:        c.pos(r.start),
+		Name:       c.name(n),
+		Params:     c.convertParameterList(n.child("parameters")),
+		Visibility: visibility(n.str("visibility")),
+		Mutability: mutability(n.str("stateMutability")),
+		Virtual:    n.boolean("virtual"),
+	}
+
+	if n.has("overrides") {
+		decl.Override = c.convertOverrideSpecifier(n.child("overrides"))
+	}
+
+	for _, modifier := range n.children("modifiers") {
+		decl.Modifiers = append(decl.Modifiers, c.convertModifierInvocation(modifier))
+	}
+
+	if n.has("returnParameters") && len(n.child("returnParameters").children("parameters")) > 0 {
+		decl.Results = c.convertParameterList(n.child("returnParameters"))
+	}
+
+	if n.has("body") {
+		decl.Body = c.convertBlock(n.child("body"))
+	} else {
+		decl.Semicolon = c.pos(r.end() - 1)
+	}
+
+	return decl
+}
+
+// convertConstructorDefinition converts the function of the constructor
+// kind. The compiler reports the constructors without the visibility as
+// public, so the visibility is only kept if it is written in the source.
+func (c *converter) convertConstructorDefinition(n node) ast.Declaration {
+	r := c.rangeOf(n)
+	decl := &ast.ConstructorDeclaration{
+		Pos:    c.pos(r.start),
+		Params: c.convertParameterList(n.child("parameters")),
+		Body:   c.convertBlock(n.child("body")),
+	}
+
+	if n.str("stateMutability") == "payable" {
+		decl.Mutability = ast.Payable
+	}
+
+	header := c.text(srcRange{r.start, c.rangeOf(n.child("body")).start - r.start})
+	for _, word := range strings.Fields(header) {
+		switch word {
+		case "public":
+			decl.Visibility = ast.Public
+		case "internal":
+			decl.Visibility = ast.Internal
+		}
+	}
+
+	for _, modifier := range n.children("modifiers") {
+		decl.Modifiers = append(decl.Modifiers, c.convertModifierInvocation(modifier))
+	}
+
+	return decl
+}
+
+// convertSpecialFunctionDefinition converts the functions of the receive and
+// fallback kinds.
+func (c *converter) convertSpecialFunctionDefinition(n node) ast.Declaration {
+	r := c.rangeOf(n)
+	kind := n.str("kind")
+	decl := &ast.SpecialFunctionDeclaration{
+		Kind:       token.Token{Type: token.LookupIdent(kind), Literal: kind, Pos: c.pos(r.start)},
+		Params:     c.convertParameterList(n.child("parameters")),
+		Visibility: visibility(n.str("visibility")),
+		Mutability: mutability(n.str("stateMutability")),
+		Virtual:    n.boolean("virtual"),
+	}
+
+	if n.has("overrides") {
+		decl.Override = c.convertOverrideSpecifier(n.child("overrides"))
+	}
+
+	for _, modifier := range n.children("modifiers") {
+		decl.Modifiers = append(decl.Modifiers, c.convertModifierInvocation(modifier))
+	}
+
+	if n.has("returnParameters") && len(n.child("returnParameters").children("parameters")) > 0 {
+		decl.Results = c.convertParameterList(n.child("returnParameters"))
+	}
+
+	if n.has("body") {
+		decl.Body = c.convertBlock(n.child("body"))
+	} else {
+		decl.Semicolon = c.pos(r.end() - 1)
+	}
+
+	return decl
+}
+
+func (c *converter) convertModifierInvocation(n node) *ast.ModifierInvocation {
+	r := c.rangeOf(n)
+	invocation := &ast.ModifierInvocation{
+		Name: c.identifier(n.child("modifierName")),
+	}
+
+	// The arguments are null if the modifier is invoked without the
+	// parentheses and an empty list for `m()`.
+	if n.has("arguments") {
+		invocation.Opening = c.pos(c.find("(", r.start, r.end()))
+		invocation.Closing = c.pos(r.end() - 1)
+		for _, arg := range n.children("arguments") {
+			invocation.Args = append(invocation.Args, c.convertExpression(arg))
+		}
+	}
+
+	return invocation
+}
+
+func (c *converter) convertModifierDefinition(n node) ast.Declaration {
+	r := c.rangeOf(n)
+	decl := &ast.ModifierDeclaration{
+		Pos:     c.pos(r.start),
+		Name:    c.name(n),
+		Virtual: n.boolean("virtual"),
+	}
+
+	// The compiler always reports a parameter list, even if the modifier
+	// is declared without the parentheses.
+	if params := n.child("parameters"); strings.HasPrefix(c.text(c.rangeOf(params)), "(") {
+		decl.Params = c.convertParameterList(params)
+	}
+
+	if n.has("overrides") {
+		decl.Override = c.convertOverrideSpecifier(n.child("overrides"))
+	}
+
+	if n.has("body") {
+		decl.Body = c.convertBlock(n.child("body"))
+	} else {
+		decl.Semicolon = c.pos(r.end() - 1)
+	}
+
+	return decl
+}
+
+func (c *converter) convertOverrideSpecifier(n node) *ast.OverrideSpecifier {
+	r := c.rangeOf(n)
+	spec := &ast.OverrideSpecifier{Pos: c.pos(r.start)}
+
+	for _, override := range n.children("overrides") {
+		spec.Overrides = append(spec.Overrides, c.identifier(override))
+	}
+
+	if len(spec.Overrides) > 0 {
+		spec.Opening = c.pos(c.find("(", r.start, r.end()))
+		spec.Closing = c.pos(r.end() - 1)
+	}
+
+	return spec
+}
+
+func (c *converter) convertStateVariableDeclaration(n node) ast.Declaration {
+	decl := &ast.StateVariableDeclaration{
+		Name:       c.name(n),
+		Type:       c.convertTypeName(n.child("typeName")),
+		Visibility: visibility(n.str("visibility")),
+		Mutability: mutability(n.str("mutability")),
+	}
+
+	if decl.Type == nil {
+		return nil
+	}
+
+	if n.has("value") {
+		decl.Value = c.convertExpression(n.child("value"))
+	}
+
+	return decl
+}
+
+func (c *converter) convertConstantVariableDeclaration(n node) ast.Declaration {
+	decl := &ast.ConstantVariableDeclaration{
+		Name: c.name(n),
+		Type: c.convertTypeName(n.child("typeName")),
+	}
+
+	if decl.Type == nil {
+		return nil
+	}
+
+	if n.has("value") {
+		decl.Value = c.convertExpression(n.child("value"))
+	}
+
+	return decl
+}
+
+func (c *converter) convertEventDefinition(n node) ast.Declaration {
+	params := n.child("parameters")
+	r := c.rangeOf(params)
+
+	decl := &ast.EventDeclaration{
+		Pos:  c.pos(c.rangeOf(n).start),
+		Name: c.name(n),
+		Params: &ast.EventParamList{
+			Opening: c.pos(r.start),
+			Closing: c.pos(r.end() - 1),
+		},
+		IsAnonymous: n.boolean("anonymous"),
+	}
+
+	for _, param := range params.children("parameters") {
+		eventParam := &ast.EventParam{
+			Name:      c.name(param),
+			Type:      c.convertTypeName(param.child("typeName")),
+			IsIndexed: param.boolean("indexed"),
+		}
+		decl.Params.List = append(decl.Params.List, eventParam)
+	}
+
+	return decl
+}
+
+func (c *converter) convertStructDefinition(n node) ast.Declaration {
+	r := c.rangeOf(n)
+	decl := &ast.StructDeclaration{
+		Pos:        c.pos(r.start),
+		Name:       c.name(n),
+		RightBrace: c.pos(r.end() - 1),
+	}
+
+	for _, member := range n.children("members") {
+		decl.Members = append(decl.Members, &ast.Param{
+			Name: c.name(member),
+			Type: c.convertTypeName(member.child("typeName")),
+		})
+	}
+
+	return decl
+}
+
+func (c *converter) convertEnumDefinition(n node) ast.Declaration {
+	r := c.rangeOf(n)
+	decl := &ast.EnumDeclaration{
+		Pos:        c.pos(r.start),
+		Name:       c.name(n),
+		RightBrace: c.pos(r.end() - 1),
+	}
+
+	for _, member := range n.children("members") {
+		decl.Members = append(decl.Members, c.name(member))
+	}
+
+	return decl
+}
+
+func (c *converter) convertErrorDefinition(n node) ast.Declaration {
+	r := c.rangeOf(n)
+	decl := &ast.ErrorDeclaration{
+		Pos:       c.pos(r.start),
+		Name:      c.name(n),
+		Params:    c.convertParameterList(n.child("parameters")),
+		Semicolon: c.pos(r.end() - 1),
+	}
+
+	return decl
+}
+
+func (c *converter) convertImportDirective(n node) ast.Declaration {
+	r := c.rangeOf(n)
+	dir := &ast.ImportDirective{
+		Pos:       c.pos(r.start),
+		Semicolon: c.pos(r.end() - 1),
+	}
+
+	// The path has no location of its own; it is the last occurrence of the
+	// path in the directive, preceded by the opening quote.
+	file := n.str("file")
+	if i := c.findLast(file, r.start, r.end()); i > 0 {
+		quote := c.content[i-1 : i]
+		dir.Path = &ast.StringLiteral{
+			Pos:   c.pos(i - 1),
+			Kind:  token.Token{Type: token.STRING_LITERAL, Literal: quote + file + quote, Pos: c.pos(i - 1)},
+			Value: file,
+		}
+	}
+
+	for _, item := range n.children("symbolAliases") {
+		sym := &ast.ImportSymbol{Name: c.identifier(item.child("foreign"))}
+		if local := item.str("local"); local != "" {
+			if lr, ok := parseSrc(item.str("nameLocation")); ok {
+				sym.Alias = &ast.Identifier{Pos: c.pos(lr.start), Value: local}
+			} else if i := c.find(local, c.offset(sym.Name.End()), r.end()); i >= 0 {
+				sym.Alias = &ast.Identifier{Pos: c.pos(i), Value: local}
+			}
+		}
+		dir.Symbols = append(dir.Symbols, sym)
+	}
+
+	if alias := n.str("unitAlias"); alias != "" {
+		if ar, ok := parseSrc(n.str("nameLocation")); ok && ar.length > 0 {
+			dir.UnitAlias = &ast.Identifier{Pos: c.pos(ar.start), Value: alias}
+		} else if i := c.find(" "+alias, r.start, r.end()); i >= 0 {
+			dir.UnitAlias = &ast.Identifier{Pos: c.pos(i + 1), Value: alias}
+		}
+	}
+
+	return dir
+}
+
+func (c *converter) convertUsingForDirective(n node) ast.Declaration {
+	r := c.rangeOf(n)
+	dir := &ast.UsingForDirective{
+		Pos:       c.pos(r.start),
+		IsGlobal:  n.boolean("global"),
+		Semicolon: c.pos(r.end() - 1),
+	}
+
+	if n.has("libraryName") {
+		dir.LibraryName = c.identifier(n.child("libraryName"))
+	}
+
+	for _, item := range n.children("functionList") {
+		obj := &ast.UsingForObject{}
+		if item.has("definition") {
+			// User-defined operators: {add as +}
+			obj.Path = c.identifier(item.child("definition"))
+			op := item.str("operator")
+			if tkType, ok := lookupOperator(op); ok {
+				offset := c.find(op, c.offset(obj.Path.End()), r.end())
+				obj.Alias = token.Token{Type: tkType, Literal: op, Pos: c.pos(offset)}
+			}
+		} else {
+			obj.Path = c.identifier(item.child("function"))
+		}
+		dir.List = append(dir.List, obj)
+	}
+
+	if n.has("typeName") {
+		dir.ForType = c.convertTypeName(n.child("typeName"))
+	} else {
+		dir.IsWildcard = true
+	}
+
+	return dir
+}
+
+/*~*~*~*~*~*~*~*~*~*~*~*~*~* Types ~*~*~*~*~*~*~*~*~*~*~*~*~*~*~*/
+
+func (c *converter) convertParameterList(n node) *ast.ParamList {
+	r := c.rangeOf(n)
+	params := &ast.ParamList{
+		Opening: c.pos(r.start),
+		Closing: c.pos(r.end() - 1),
+	}
+
+	for _, param := range n.children("parameters") {
+		p := &ast.Param{
+			Name:         c.name(param),
+			Type:         c.convertTypeName(param.child("typeName")),
+			DataLocation: dataLocation(param.str("storageLocation")),
+		}
+		params.List = append(params.List, p)
+	}
+
+	return params
+}
+
+func (c *converter) convertTypeName(n node) ast.Type {
+	if n == nil {
+		return nil
+	}
+
+	r := c.rangeOf(n)
+
+	switch n.nodeType() {
+	case "ElementaryTypeName":
+		// The name of `address payable` is "address", the payable is in the
+		// stateMutability. The literal is taken from the source to keep the
+		// positions of the ElementaryType consistent with the parser.
+		name := n.str("name")
+		typ := &ast.ElementaryType{
+			Pos:  c.pos(r.start),
+			Kind: token.Token{Type: token.LookupIdent(name), Literal: name, Pos: c.pos(r.start)},
+		}
+		if n.str("stateMutability") == "payable" {
+			typ.Payable = c.pos(r.end() - len("payable"))
+		}
+		return typ
+	case "UserDefinedTypeName":
+		if n.has("pathNode") {
+			return &ast.UserDefinedType{Name: c.identifier(n.child("pathNode"))}
+		}
+		return &ast.UserDefinedType{Name: &ast.Identifier{Pos: c.pos(r.start), Value: n.str("name")}}
+	default:
+		c.unsupported(n)
+		return nil
+	}
+}
+
+/*~*~*~*~*~*~*~*~*~*~*~*~* Statements *~*~*~*~*~*~*~*~*~*~*~*~*~*/
+
+func (c *converter) convertBlock(n node) *ast.BlockStatement {
+	r := c.rangeOf(n)
+	block := &ast.BlockStatement{
+		LeftBrace:  c.pos(r.start),
+		RightBrace: c.pos(r.end() - 1),
+	}
+
+	for _, stmt := range n.children("statements") {
+		if s := c.convertStatement(stmt); s != nil {
+			block.Statements = append(block.Statements, s)
+		}
+	}
+
+	return block
+}
+
+func (c *converter) convertStatement(n node) ast.Statement {
+	r := c.rangeOf(n)
+
+	switch n.nodeType() {
+	case "Block":
+		return c.convertBlock(n)
+
+	case "UncheckedBlock":
+		block := &ast.UncheckedBlockStatement{
+			LeftBrace:  c.pos(c.find("{", r.start, r.end())),
+			RightBrace: c.pos(r.end() - 1),
+		}
+		for _, stmt := range n.children("statements") {
+			if s := c.convertStatement(stmt); s != nil {
+				block.Statements = append(block.Statements, s)
+			}
+		}
+		return block
+
+	case "VariableDeclarationStatement":
+		return c.convertVariableDeclarationStatement(n)
+
+	case "Return":
+		stmt := &ast.ReturnStatement{Pos: c.pos(r.start)}
+		if n.has("expression") {
+			stmt.Result = c.convertExpression(n.child("expression"))
+		}
+		return stmt
+
+	case "ExpressionStatement":
+		expr := c.convertExpression(n.child("expression"))
+		if expr == nil {
+			return nil
+		}
+		return &ast.ExpressionStatement{Pos: c.pos(r.start), Expression: expr}
+
+	case "PlaceholderStatement":
+		// The parser treats the modifier's placeholder as an identifier.
+		return &ast.ExpressionStatement{
+			Pos:        c.pos(r.start),
+			Expression: &ast.Identifier{Pos: c.pos(r.start), Value: "_"},
+		}
+
+	case "IfStatement":
+		stmt := &ast.IfStatement{
+			Pos:         c.pos(r.start),
+			Condition:   c.convertExpression(n.child("condition")),
+			Consequence: c.convertStatement(n.child("trueBody")),
+		}
+		if n.has("falseBody") {
+			stmt.Alternative = c.convertStatement(n.child("falseBody"))
+		}
+		return stmt
+
+	case "EmitStatement":
+		return &ast.EmitStatement{
+			Pos:        c.pos(r.start),
+			Expression: c.convertExpression(n.child("eventCall")),
+		}
+
+	case "RevertStatement":
+		return &ast.RevertStatement{
+			Pos:        c.pos(r.start),
+			Expression: c.convertExpression(n.child("errorCall")),
+		}
+
+	default:
+		c.unsupported(n)
+		return nil
+	}
+}
+
+func (c *converter) convertVariableDeclarationStatement(n node) ast.Statement {
+	r := c.rangeOf(n)
+	decls := n.children("declarations")
+
+	var value ast.Expression
+	if n.has("initialValue") {
+		value = c.convertExpression(n.child("initialValue"))
+	}
+
+	if !strings.HasPrefix(c.text(r), "(") {
+		if len(decls) != 1 || decls[0] == nil {
+			c.addError(n, "expected a single variable declaration")
+			return nil
+		}
+		stmt := c.convertVariableDeclaration(decls[0])
+		if stmt != nil {
+			stmt.Value = value
+		}
+		return stmt
+	}
+
+	tuple := &ast.VariableDeclarationTupleStatement{
+		Opening: c.pos(r.start),
+		Value:   value,
+	}
+
+	closingEnd := r.end()
+	if value != nil {
+		closingEnd = c.offset(value.Start())
+	}
+	tuple.Closing = c.pos(c.findLast(")", r.start, closingEnd))
+
+	for _, decl := range decls {
+		if decl == nil {
+			tuple.Declarations = append(tuple.Declarations, nil)
+			continue
+		}
+		tuple.Declarations = append(tuple.Declarations, c.convertVariableDeclaration(decl))
+	}
+
+	return tuple
+}
+
+func (c *converter) convertVariableDeclaration(n node) *ast.VariableDeclarationStatement {
+	typ := c.convertTypeName(n.child("typeName"))
+	if typ == nil {
+		return nil
+	}
+
+	return &ast.VariableDeclarationStatement{
+		Type:         typ,
+		Name:         c.name(n),
+		DataLocation: dataLocation(n.str("storageLocation")),
+	}
+}
+
+/*~*~*~*~*~*~*~*~*~*~ Expressions *~*~*~*~*~*~*~*~*~*~*/
+
+func (c *converter) convertExpression(n node) ast.Expression {
+	if n == nil {
+		return nil
+	}
+
+	r := c.rangeOf(n)
+
+	switch n.nodeType() {
+	case "Identifier":
+		return c.identifier(n)
+
+	case "Literal":
+		return c.convertLiteral(n)
+
+	case "UnaryOperation":
+		op := n.str("operator")
+		tkType, _ := lookupOperator(op)
+		sub := c.convertExpression(n.child("subExpression"))
+		if sub == nil {
+			return nil
+		}
+
+		if n.boolean("prefix") {
+			return &ast.PrefixExpression{
+				Pos:      c.pos(r.start),
+				Operator: token.Token{Type: tkType, Literal: op, Pos: c.pos(r.start)},
+				Right:    sub,
+			}
+		}
+
+		opPos := c.pos(r.end() - len(op))
+		return &ast.PostfixExpression{
+			Pos:      opPos,
+			Operator: token.Token{Type: tkType, Literal: op, Pos: opPos},
+			Left:     sub,
+		}
+
+	case "BinaryOperation":
+		return c.convertInfix(n.child("leftExpression"), n.str("operator"), n.child("rightExpression"))
+
+	case "Assignment":
+		return c.convertInfix(n.child("leftHandSide"), n.str("operator"), n.child("rightHandSide"))
+
+	case "FunctionCall":
+		return c.convertFunctionCall(n)
+
+	case "MemberAccess":
+		expr := c.convertExpression(n.child("expression"))
+		if expr == nil {
+			return nil
+		}
+
+		member := n.str("memberName")
+		memberPos := r.end() - len(member)
+		if loc, ok := parseSrc(n.str("memberLocation")); ok {
+			memberPos = loc.start
+		}
+
+		return &ast.MemberAccessExpression{
+			Pos:        expr.Start(),
+			Expression: expr,
+			Member:     &ast.Identifier{Pos: c.pos(memberPos), Value: member},
+		}
+
+	case "FunctionCallOptions":
+		callee := n.child("expression")
+		expr := c.convertExpression(callee)
+		if expr == nil {
+			return nil
+		}
+
+		leftBrace := c.find("{", c.rangeOf(callee).end(), r.end())
+		options := &ast.CallOptionsExpression{
+			Expression: expr,
+			LeftBrace:  c.pos(leftBrace),
+			RightBrace: c.pos(r.end() - 1),
+		}
+
+		// The compiler does not report the locations of the names, so each
+		// name is looked up in the source preceding its value.
+		names, values := n.strs("names"), n.children("options")
+		from := leftBrace + 1
+		for i, name := range names {
+			if i >= len(values) {
+				break
+			}
+			value := c.convertExpression(values[i])
+			if value == nil {
+				return nil
+			}
+			valueRange := c.rangeOf(values[i])
+			options.Names = append(options.Names, &ast.Identifier{
+				Pos:   c.pos(c.find(name, from, valueRange.start)),
+				Value: name,
+			})
+			options.Values = append(options.Values, value)
+			from = valueRange.end()
+		}
+		return options
+
+	case "ElementaryTypeNameExpression":
+		name := c.elementaryTypeName(n)
+		return &ast.ElementaryTypeExpression{
+			Pos:  c.pos(r.start),
+			Kind: token.Token{Type: token.LookupIdent(name), Literal: name, Pos: c.pos(r.start)},
+		}
+
+	case "TupleExpression":
+		if n.boolean("isInlineArray") {
+			c.unsupported(n)
+			return nil
+		}
+		// The parser represents parenthesized expressions by the inner
+		// expression.
+		components := n.children("components")
+		if len(components) == 1 && components[0] != nil {
+			return c.convertExpression(components[0])
+		}
+		tuple := &ast.TupleExpression{
+			Opening: c.pos(r.start),
+			Closing: c.pos(r.end() - 1),
+		}
+		for _, component := range components {
+			if component == nil {
+				tuple.Components = append(tuple.Components, nil)
+				continue
+			}
+			expr := c.convertExpression(component)
+			if expr == nil {
+				return nil
+			}
+			tuple.Components = append(tuple.Components, expr)
+		}
+		return tuple
+
+	default:
+		c.unsupported(n)
+		return nil
+	}
+}
+
+func (c *converter) convertInfix(left node, op string, right node) ast.Expression {
+	l := c.convertExpression(left)
+	rr := c.convertExpression(right)
+	if l == nil || rr == nil {
+		return nil
+	}
+
+	tkType, _ := lookupOperator(op)
+	opPos := c.find(op, c.offset(l.End()), c.offset(rr.Start()))
+
+	return &ast.InfixExpression{
+		Pos:      l.Start(),
+		Left:     l,
+		Operator: token.Token{Type: tkType, Literal: op, Pos: c.pos(opPos)},
+		Right:    rr,
+	}
+}
+
+func (c *converter) convertFunctionCall(n node) ast.Expression {
+	if len(n.children("names")) > 0 {
+		c.addError(n, "unsupported function call with named arguments")
+		return nil
+	}
+
+	callee := n.child("expression")
+	var args []ast.Expression
+	for _, arg := range n.children("arguments") {
+		expr := c.convertExpression(arg)
+		if expr == nil {
+			return nil
+		}
+		args = append(args, expr)
+	}
+
+	// Type conversions of elementary types e.g. `address(0)`.
+	if callee.nodeType() == "ElementaryTypeNameExpression" && len(args) == 1 {
+		r := c.rangeOf(callee)
+		name := c.elementaryTypeName(callee)
+		return &ast.ElementaryTypeExpression{
+			Pos:   c.pos(r.start),
+			Kind:  token.Token{Type: token.LookupIdent(name), Literal: name, Pos: c.pos(r.start)},
+			Value: args[0],
+		}
+	}
+
+	ident := c.convertExpression(callee)
+	if ident == nil {
+		return nil
+	}
+
+	if args == nil {
+		args = []ast.Expression{}
+	}
+
+	return &ast.CallExpression{
+		Pos:   ident.Start(),
+		Ident: ident,
+		Args:  args,
+	}
+}
+
+func (c *converter) elementaryTypeName(n node) string {
+	// Older compilers store the type name as a plain string.
+	if typeName := n.child("typeName"); typeName != nil {
+		return typeName.str("name")
+	}
+	return n.str("typeName")
+}
+
+func (c *converter) convertLiteral(n node) ast.Expression {
+	r := c.rangeOf(n)
+	literal := c.text(r)
+
+	if n.has("subdenomination") {
+		c.addError(n, "unsupported literal with a subdenomination")
+		return nil
+	}
+
+	switch n.str("kind") {
+	case "bool":
+		return &ast.BooleanLiteral{Pos: c.pos(r.start), Value: n.str("value") == "true"}
+
+	case "number":
+		tkType := token.DECIMAL_NUMBER
+		if strings.HasPrefix(literal, "0x") {
+			tkType = token.HEX_NUMBER
+		}
+
+		value, ok := new(big.Int).SetString(literal, 0)
+		if !ok {
+			c.addError(n, "could not parse number literal: "+literal)
+			return nil
+		}
+
+		return &ast.NumberLiteral{
+			Pos:   c.pos(r.start),
+			Kind:  token.Token{Type: tkType, Literal: literal, Pos: c.pos(r.start)},
+			Value: *value,
+		}
+
+	case "string":
+		return &ast.StringLiteral{
+			Pos:   c.pos(r.start),
+			Kind:  token.Token{Type: token.STRING_LITERAL, Literal: literal, Pos: c.pos(r.start)},
+			Value: n.str("value"),
+		}
+
+	default:
+		c.unsupported(n)
+		return nil
+	}
+}
+
+/*~*~*~*~*~*~*~*~*~*~*~*~*~* Helpers ~*~*~*~*~*~*~*~*~*~*~*~*~*~*/
+
+// lookupOperator returns the token type of an operator e.g. "+=".
+func lookupOperator(op string) (token.TokenType, bool) {
+	if op == "delete" {
+		return token.DELETE, true
+	}
+
+	for tt := token.LPAREN; tt <= token.ASSEMBLY_ASSIGN; tt++ {
+		if token.Tokens[tt] == op {
+			return tt, true
+		}
+	}
+
+	return token.ILLEGAL, false
+}
+
+func visibility(v string) ast.Visibility {
+	switch v {
+	case "internal":
+		return ast.Internal
+	case "external":
+		return ast.External
+	case "private":
+		return ast.Private
+	case "public":
+		return ast.Public
+	default:
+		return 0
+	}
+}
+
+// mutability converts both the state mutability of functions and the
+// mutability of variables.
+func mutability(m string) ast.Mutability {
+	switch m {
+	case "pure":
+		return ast.Pure
+	case "view":
+		return ast.View
+	case "payable":
+		return ast.Payable
+	case "constant":
+		return ast.Constant
+	case "immutable":
+		return ast.Immutable
+	case "transient":
+		return ast.Transient
+	default:
+		// "nonpayable" functions and "mutable" variables.
+		return 0
+	}
+}
+
+func dataLocation(l string) ast.DataLocation {
+	switch l {
+	case "storage":
+		return ast.Storage
+	case "memory":
+		return ast.Memory
+	case "calldata":
+		return ast.Calldata
+	default:
+		return ast.NO_DATA_LOCATION
+	}
+}
@@ -0,0 +1,52 @@
+package solc
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Error struct {
+	Filename string
+	Line     int
+	Column   int
+	Msg      string
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.Filename, e.Line, e.Column, e.Msg)
+}
+
+type ErrorList []Error
+
+func (e ErrorList) Error() string {
+	if len(e) == 0 {
+		return "no errors"
+	}
+
+	var b strings.Builder
+	for i, err := range e {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+func (c *converter) addError(n node, msg string) {
+	line, col := -1, -1
+	if r, ok := parseSrc(n.str("src")); ok {
+		line, col = c.file.GetLineAndColumn(c.pos(r.start))
+	}
+
+	c.errors = append(c.errors, Error{
+		Filename: c.file.Name(),
+		Line:     line,
+		Column:   col,
+		Msg:      msg,
+	})
+}
+
+func (c *converter) unsupported(n node) {
+	c.addError(n, "unsupported AST node: "+n.nodeType())
+}
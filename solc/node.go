@@ -0,0 +1,94 @@
+package solc
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// node is a generic compact JSON AST node. The compiler's AST has dozens of
+// node types with different fields, so instead of mirroring all of them as
+// Go structs, the fields are decoded lazily with the accessors below.
+type node map[string]json.RawMessage
+
+func (n node) nodeType() string { return n.str("nodeType") }
+
+func (n node) has(key string) bool {
+	raw, ok := n[key]
+	return ok && string(raw) != "null"
+}
+
+func (n node) str(key string) string {
+	var s string
+	if raw, ok := n[key]; ok {
+		json.Unmarshal(raw, &s)
+	}
+	return s
+}
+
+func (n node) boolean(key string) bool {
+	var b bool
+	if raw, ok := n[key]; ok {
+		json.Unmarshal(raw, &b)
+	}
+	return b
+}
+
+// child returns the node stored under the key or nil if it is absent.
+func (n node) child(key string) node {
+	var c node
+	if raw, ok := n[key]; ok {
+		json.Unmarshal(raw, &c)
+	}
+	return c
+}
+
+// children returns the list of nodes stored under the key. Some lists have
+// null elements (e.g. omitted components of a tuple declaration), these
+// are returned as nil nodes.
+func (n node) children(key string) []node {
+	var c []node
+	if raw, ok := n[key]; ok {
+		json.Unmarshal(raw, &c)
+	}
+	return c
+}
+
+// strs returns the list of strings stored under the key e.g. the names of
+// the call options.
+func (n node) strs(key string) []string {
+	var s []string
+	if raw, ok := n[key]; ok {
+		json.Unmarshal(raw, &s)
+	}
+	return s
+}
+
+// srcRange is the decoded "start:length:sourceIndex" location of a node.
+type srcRange struct {
+	start  int
+	length int
+}
+
+func (r srcRange) end() int { return r.start + r.length }
+
+// parseSrc decodes the "start:length:sourceIndex" location format. It returns
+// false if the location is missing or is not valid e.g. "-1:-1:-1".
+func parseSrc(src string) (srcRange, bool) {
+	parts := strings.Split(src, ":")
+	if len(parts) < 2 {
+		return srcRange{}, false
+	}
+
+	start, err := strconv.Atoi(parts[0])
+	if err != nil || start < 0 {
+		return srcRange{}, false
+	}
+
+	length, err := strconv.Atoi(parts[1])
+	if err != nil || length < 0 {
+		return srcRange{}, false
+	}
+
+	return srcRange{start: start, length: length}, true
+}
@@ -0,0 +1,171 @@
+// Package solc imports the compact JSON AST produced by the Solidity compiler
+// (`solc --standard-json`) and converts it into the nodes of the ast package.
+//
+// The handwritten parser lags behind the Solidity grammar, while most Foundry
+// and Hardhat projects already contain the compiler output e.g. in the
+// `out/build-info/*.json` files. The importer is an alternate frontend: the
+// returned *ast.File can be analyzed the same way as the parser's output.
+//
+// The compact AST uses "start:length:sourceIndex" byte ranges. The start of
+// the range is used as the token.Pos of the converted node, so the positions
+// are compatible with the ones produced by the parser for the same source.
+// Similar to the parser, the files can be added to a token.FileSet, in which
+// case the positions are offset by the base of the file in the set.
+//
+// Nodes that do not have a counterpart in the ast package yet (e.g. loops,
+// inline assembly, mappings) are skipped and reported as errors. Similar to
+// parser.ParseFile, the importer then returns a partially complete file
+// together with the errors.
+package solc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+// BuildInfo is the subset of a build-info file that the importer needs. The
+// build-info files contain both the standard-JSON input given to the
+// compiler (with the source code) and its output (with the ASTs).
+type BuildInfo struct {
+	Input struct {
+		Sources map[string]struct {
+			Content string `json:"content"`
+		} `json:"sources"`
+	} `json:"input"`
+	Output struct {
+		Sources map[string]struct {
+			ID  int             `json:"id"`
+			AST json.RawMessage `json:"ast"`
+		} `json:"sources"`
+	} `json:"output"`
+}
+
+// ImportBuildInfoDir imports all the build-info files (*.json) located in the
+// dir e.g. "out/build-info" in Foundry projects. The returned files are sorted
+// by their path. Errors of all the files are combined. The fset is optional.
+func ImportBuildInfoDir(fset *token.FileSet, dir string) ([]*ast.File, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list build-info files in %s: %w", dir, err)
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("No build-info files found in %s", dir)
+	}
+
+	var files []*ast.File
+	var errors ErrorList
+
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to open the build-info file %s: %w", path, err)
+		}
+
+		imported, err := ImportBuildInfo(fset, f)
+		f.Close()
+
+		if errList, ok := err.(ErrorList); ok {
+			errors = append(errors, errList...)
+		} else if err != nil {
+			return nil, fmt.Errorf("Failed to import the build-info file %s: %w", path, err)
+		}
+
+		files = append(files, imported...)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+
+	if len(errors) > 0 {
+		return files, errors
+	}
+
+	return files, nil
+}
+
+// ImportBuildInfo converts every source unit of a build-info file into an
+// *ast.File. The files are sorted by their path. If the fset is not nil, the
+// files are added to it.
+//
+// If some of the nodes could not be converted, the function returns the
+// (partially complete) files and an error of type ErrorList.
+func ImportBuildInfo(fset *token.FileSet, r io.Reader) ([]*ast.File, error) {
+	var info BuildInfo
+	if err := json.NewDecoder(r).Decode(&info); err != nil {
+		return nil, fmt.Errorf("Failed to decode the build-info file: %w", err)
+	}
+
+	paths := make([]string, 0, len(info.Output.Sources))
+	for path := range info.Output.Sources {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var files []*ast.File
+	var errors ErrorList
+
+	for _, path := range paths {
+		input, ok := info.Input.Sources[path]
+		if !ok {
+			return nil, fmt.Errorf("The build-info file has no source content for %s", path)
+		}
+
+		file, err := ImportSourceUnit(fset, path, input.Content, info.Output.Sources[path].AST)
+		if errList, ok := err.(ErrorList); ok {
+			errors = append(errors, errList...)
+		} else if err != nil {
+			return nil, err
+		}
+
+		files = append(files, file)
+	}
+
+	if len(errors) > 0 {
+		return files, errors
+	}
+
+	return files, nil
+}
+
+// ImportSourceUnit converts the compact JSON AST of a single source unit into
+// an *ast.File. The content is the source code that was compiled; it is used
+// for the positions and the literals. If the fset is not nil, the file is
+// added to it.
+//
+// If some of the nodes could not be converted, the function returns the
+// (partially complete) file and an error of type ErrorList.
+func ImportSourceUnit(fset *token.FileSet, path, content string, rawAST json.RawMessage) (*ast.File, error) {
+	var root node
+	if err := json.Unmarshal(rawAST, &root); err != nil {
+		return nil, fmt.Errorf("Failed to decode the AST of %s: %w", path, err)
+	}
+
+	if root.nodeType() != "SourceUnit" {
+		return nil, fmt.Errorf("Expected a SourceUnit AST node for %s, got: %s", path, root.nodeType())
+	}
+
+	sourceFile, err := token.NewSourceFile(path, content)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create new source file for %s: %w", path, err)
+	}
+
+	if fset != nil {
+		fset.AddFile(sourceFile)
+	}
+
+	c := &converter{file: sourceFile, content: content}
+	file := c.convertSourceUnit(root)
+
+	if len(c.errors) > 0 {
+		return file, c.errors
+	}
+
+	return file, nil
+}
@@ -0,0 +1,348 @@
+package solc_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/ast/astutil"
+	"github.com/ChmielewskiKamil/solbot/parser"
+	"github.com/ChmielewskiKamil/solbot/solc"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+func Test_ImportBuildInfoDir(t *testing.T) {
+	// The positions of the imported files are offset by the files already
+	// in the set.
+	fset := newFileSetWithDummyFile(t)
+
+	files, err := solc.ImportBuildInfoDir(fset, "testdata/build-info")
+	if err != nil {
+		t.Fatalf("Failed to import the build-info files: %v", err)
+	}
+
+	if len(files) != 1 {
+		t.Fatalf("Expected 1 file, got %d", len(files))
+	}
+
+	imported := files[0]
+	if imported.Name != "src/Vault.sol" {
+		t.Errorf("Expected file name src/Vault.sol, got %s", imported.Name)
+	}
+
+	deposit := imported.Declarations[1].(*ast.ContractDeclaration).Body.Declarations[4].(*ast.FunctionDeclaration)
+	if deposit.Results == nil || deposit.Results.String() != "(bool)" {
+		t.Fatalf("Expected the deposit function to return (bool), got %v", deposit.Results)
+	}
+
+	// The imported AST should be indistinguishable from the one produced by
+	// the parser for the same source, including the positions of the nodes.
+	parsed, err := parser.ParseFile(imported.Name, strings.NewReader(imported.SourceFile.Content()),
+		parser.WithFileSet(newFileSetWithDummyFile(t)))
+	if err != nil {
+		t.Fatalf("Failed to parse the source of the fixture: %v", err)
+	}
+
+	expected := dumpNodes(parsed)
+	got := dumpNodes(imported)
+
+	if len(got) != len(expected) {
+		t.Errorf("Expected %d nodes, got %d", len(expected), len(got))
+	}
+
+	for i := 0; i < len(expected) && i < len(got); i++ {
+		if got[i] != expected[i] {
+			t.Errorf("Node %d: expected %q, got %q", i, expected[i], got[i])
+		}
+	}
+}
+
+func Test_ImportSourceUnit_UnsupportedNodes(t *testing.T) {
+	content := "contract A { function f() public { while (true) {} } }"
+	rawAST := []byte(`{
+		"nodeType": "SourceUnit", "src": "0:55:0", "nodes": [{
+			"nodeType": "ContractDefinition", "src": "0:55:0", "name": "A",
+			"nameLocation": "9:1:0", "contractKind": "contract", "nodes": [{
+				"nodeType": "FunctionDefinition", "src": "13:40:0", "name": "f",
+				"nameLocation": "22:1:0", "kind": "function", "visibility": "public",
+				"stateMutability": "nonpayable",
+				"parameters": {"nodeType": "ParameterList", "src": "23:2:0", "parameters": []},
+				"returnParameters": {"nodeType": "ParameterList", "src": "33:0:0", "parameters": []},
+				"body": {"nodeType": "Block", "src": "33:20:0", "statements": [
+					{"nodeType": "WhileStatement", "src": "35:16:0"}
+				]}
+			}]
+		}]
+	}`)
+
+	file, err := solc.ImportSourceUnit(nil, "A.sol", content, rawAST)
+
+	errList, ok := err.(solc.ErrorList)
+	if !ok || len(errList) != 1 {
+		t.Fatalf("Expected 1 error, got: %v", err)
+	}
+
+	expectedErr := "A.sol:1:36: unsupported AST node: WhileStatement"
+	if errList[0].Error() != expectedErr {
+		t.Errorf("Expected error %q, got %q", expectedErr, errList[0].Error())
+	}
+
+	// The rest of the file is still converted.
+	contract, ok := file.Declarations[0].(*ast.ContractDeclaration)
+	if !ok {
+		t.Fatalf("Expected *ast.ContractDeclaration, got %T", file.Declarations[0])
+	}
+
+	fn, ok := contract.Body.Declarations[0].(*ast.FunctionDeclaration)
+	if !ok {
+		t.Fatalf("Expected *ast.FunctionDeclaration, got %T", contract.Body.Declarations[0])
+	}
+
+	if fn.Name.Value != "f" || fn.Visibility != ast.Public || len(fn.Body.Statements) != 0 {
+		t.Errorf("Unexpected function: %s %s with %d statements",
+			fn.Name.Value, fn.Visibility, len(fn.Body.Statements))
+	}
+}
+
+func Test_ImportSourceUnit_Constructor(t *testing.T) {
+	content := "contract A { constructor() payable {} }"
+	rawAST := []byte(`{
+		"nodeType": "SourceUnit", "src": "0:39:0", "nodes": [{
+			"nodeType": "ContractDefinition", "src": "0:39:0", "name": "A",
+			"nameLocation": "9:1:0", "contractKind": "contract", "nodes": [{
+				"nodeType": "FunctionDefinition", "src": "13:24:0", "name": "",
+				"kind": "constructor", "visibility": "public", "stateMutability": "payable",
+				"parameters": {"nodeType": "ParameterList", "src": "24:2:0", "parameters": []},
+				"returnParameters": {"nodeType": "ParameterList", "src": "35:0:0", "parameters": []},
+				"modifiers": [],
+				"body": {"nodeType": "Block", "src": "35:2:0", "statements": []}
+			}]
+		}]
+	}`)
+
+	imported, err := solc.ImportSourceUnit(nil, "A.sol", content, rawAST)
+	if err != nil {
+		t.Fatalf("Failed to import the source unit: %v", err)
+	}
+
+	parsed, err := parser.ParseFile("A.sol", strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Failed to parse the source: %v", err)
+	}
+
+	expected := dumpNodes(parsed)
+	got := dumpNodes(imported)
+
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected nodes:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+
+	constructor, ok := imported.Declarations[0].(*ast.ContractDeclaration).Body.Declarations[0].(*ast.ConstructorDeclaration)
+	if !ok {
+		t.Fatalf("Expected *ast.ConstructorDeclaration")
+	}
+	if constructor.Mutability != ast.Payable || constructor.Visibility != 0 {
+		t.Errorf("Unexpected constructor attributes: %s %s", constructor.Visibility, constructor.Mutability)
+	}
+}
+
+func Test_ImportSourceUnit_Receive(t *testing.T) {
+	content := "contract A { receive() external payable {} }"
+	rawAST := []byte(`{
+		"nodeType": "SourceUnit", "src": "0:44:0", "nodes": [{
+			"nodeType": "ContractDefinition", "src": "0:44:0", "name": "A",
+			"nameLocation": "9:1:0", "contractKind": "contract", "nodes": [{
+				"nodeType": "FunctionDefinition", "src": "13:29:0", "name": "",
+				"kind": "receive", "visibility": "external", "stateMutability": "payable",
+				"virtual": false,
+				"parameters": {"nodeType": "ParameterList", "src": "20:2:0", "parameters": []},
+				"returnParameters": {"nodeType": "ParameterList", "src": "40:0:0", "parameters": []},
+				"modifiers": [],
+				"body": {"nodeType": "Block", "src": "40:2:0", "statements": []}
+			}]
+		}]
+	}`)
+
+	imported, err := solc.ImportSourceUnit(nil, "A.sol", content, rawAST)
+	if err != nil {
+		t.Fatalf("Failed to import the source unit: %v", err)
+	}
+
+	parsed, err := parser.ParseFile("A.sol", strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Failed to parse the source: %v", err)
+	}
+
+	expected := dumpNodes(parsed)
+	got := dumpNodes(imported)
+
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected nodes:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+
+	receive, ok := imported.Declarations[0].(*ast.ContractDeclaration).Body.Declarations[0].(*ast.SpecialFunctionDeclaration)
+	if !ok {
+		t.Fatalf("Expected *ast.SpecialFunctionDeclaration")
+	}
+	if receive.Kind.Type != token.RECEIVE || receive.Visibility != ast.External || receive.Mutability != ast.Payable {
+		t.Errorf("Unexpected receive function: %s", receive)
+	}
+}
+
+func Test_ImportSourceUnit_CallOptions(t *testing.T) {
+	content := `contract A { function f(address to) public { to.call{value: 1, gas: 2}(""); } }`
+	rawAST := []byte(`{
+		"nodeType": "SourceUnit", "src": "0:79:0", "nodes": [{
+			"nodeType": "ContractDefinition", "src": "0:79:0", "name": "A",
+			"nameLocation": "9:1:0", "contractKind": "contract", "nodes": [{
+				"nodeType": "FunctionDefinition", "src": "13:64:0", "name": "f",
+				"nameLocation": "22:1:0", "kind": "function", "visibility": "public",
+				"stateMutability": "nonpayable",
+				"parameters": {"nodeType": "ParameterList", "src": "23:12:0", "parameters": [{
+					"nodeType": "VariableDeclaration", "src": "24:10:0", "name": "to", "nameLocation": "32:2:0",
+					"storageLocation": "default",
+					"typeName": {"nodeType": "ElementaryTypeName", "src": "24:7:0", "name": "address"}
+				}]},
+				"returnParameters": {"nodeType": "ParameterList", "src": "43:0:0", "parameters": []},
+				"body": {"nodeType": "Block", "src": "43:34:0", "statements": [{
+					"nodeType": "ExpressionStatement", "src": "45:30:0",
+					"expression": {
+						"nodeType": "FunctionCall", "src": "45:29:0", "names": [],
+						"arguments": [{"nodeType": "Literal", "src": "71:2:0", "kind": "string", "value": ""}],
+						"expression": {
+							"nodeType": "FunctionCallOptions", "src": "45:25:0", "names": ["value", "gas"],
+							"options": [
+								{"nodeType": "Literal", "src": "60:1:0", "kind": "number", "value": "1"},
+								{"nodeType": "Literal", "src": "68:1:0", "kind": "number", "value": "2"}
+							],
+							"expression": {
+								"nodeType": "MemberAccess", "src": "45:7:0", "memberName": "call",
+								"memberLocation": "48:4:0",
+								"expression": {"nodeType": "Identifier", "src": "45:2:0", "name": "to"}
+							}
+						}
+					}
+				}]}
+			}]
+		}]
+	}`)
+
+	imported, err := solc.ImportSourceUnit(nil, "A.sol", content, rawAST)
+	if err != nil {
+		t.Fatalf("Failed to import the source unit: %v", err)
+	}
+
+	parsed, err := parser.ParseFile("A.sol", strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Failed to parse the source: %v", err)
+	}
+
+	expected := dumpNodes(parsed)
+	got := dumpNodes(imported)
+
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected nodes:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func Test_ImportSourceUnit_ImportDirective(t *testing.T) {
+	content := "import {A, B as C} from \"./A.sol\";\nimport * as M from \"./B.sol\";"
+	rawAST := []byte(`{
+		"nodeType": "SourceUnit", "src": "0:64:0", "nodes": [{
+			"nodeType": "ImportDirective", "src": "0:34:0", "file": "./A.sol",
+			"unitAlias": "", "nameLocation": "-1:-1:-1", "symbolAliases": [
+				{"foreign": {"nodeType": "Identifier", "src": "8:1:0", "name": "A"}, "nameLocation": "-1:-1:-1"},
+				{"foreign": {"nodeType": "Identifier", "src": "11:1:0", "name": "B"}, "local": "C", "nameLocation": "16:1:0"}
+			]
+		}, {
+			"nodeType": "ImportDirective", "src": "35:29:0", "file": "./B.sol",
+			"unitAlias": "M", "nameLocation": "47:1:0", "symbolAliases": []
+		}]
+	}`)
+
+	imported, err := solc.ImportSourceUnit(nil, "A.sol", content, rawAST)
+	if err != nil {
+		t.Fatalf("Failed to import the source unit: %v", err)
+	}
+
+	parsed, err := parser.ParseFile("A.sol", strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Failed to parse the source: %v", err)
+	}
+
+	expected := dumpNodes(parsed)
+	got := dumpNodes(imported)
+
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected nodes:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func Test_ImportSourceUnit_UserDefinedTypes(t *testing.T) {
+	content := "error E(uint256 a);\nenum S { A, B }\nstruct P { uint256 x; }"
+	rawAST := []byte(`{
+		"nodeType": "SourceUnit", "src": "0:59:0", "nodes": [{
+			"nodeType": "ErrorDefinition", "src": "0:19:0", "name": "E", "nameLocation": "6:1:0",
+			"parameters": {"nodeType": "ParameterList", "src": "7:11:0", "parameters": [{
+				"nodeType": "VariableDeclaration", "src": "8:9:0", "name": "a", "nameLocation": "16:1:0",
+				"storageLocation": "default",
+				"typeName": {"nodeType": "ElementaryTypeName", "src": "8:7:0", "name": "uint256"}
+			}]}
+		}, {
+			"nodeType": "EnumDefinition", "src": "20:15:0", "name": "S", "nameLocation": "25:1:0", "members": [
+				{"nodeType": "EnumValue", "src": "29:1:0", "name": "A", "nameLocation": "29:1:0"},
+				{"nodeType": "EnumValue", "src": "32:1:0", "name": "B", "nameLocation": "32:1:0"}
+			]
+		}, {
+			"nodeType": "StructDefinition", "src": "36:23:0", "name": "P", "nameLocation": "43:1:0", "members": [{
+				"nodeType": "VariableDeclaration", "src": "47:9:0", "name": "x", "nameLocation": "55:1:0",
+				"storageLocation": "default",
+				"typeName": {"nodeType": "ElementaryTypeName", "src": "47:7:0", "name": "uint256"}
+			}]
+		}]
+	}`)
+
+	imported, err := solc.ImportSourceUnit(nil, "A.sol", content, rawAST)
+	if err != nil {
+		t.Fatalf("Failed to import the source unit: %v", err)
+	}
+
+	parsed, err := parser.ParseFile("A.sol", strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Failed to parse the source: %v", err)
+	}
+
+	expected := dumpNodes(parsed)
+	got := dumpNodes(imported)
+
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected nodes:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func newFileSetWithDummyFile(t *testing.T) *token.FileSet {
+	t.Helper()
+
+	dummy, err := token.NewSourceFile("Dummy.sol", "contract Dummy {}")
+	if err != nil {
+		t.Fatalf("Failed to create the dummy source file: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	fset.AddFile(dummy)
+
+	return fset
+}
+
+// dumpNodes lists all the nodes of the file in the depth-first order.
+func dumpNodes(file *ast.File) []string {
+	var nodes []string
+	astutil.Apply(file, func(c *astutil.Cursor) bool {
+		if n := c.Node(); n != nil {
+			nodes = append(nodes, fmt.Sprintf("%T [%d, %d) %s", n, n.Start(), n.End(), n))
+		}
+		return true
+	}, nil)
+	return nodes
+}
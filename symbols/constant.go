@@ -0,0 +1,69 @@
+package symbols
+
+import (
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/types"
+)
+
+// IsCompileTimeConstant reports whether the value of the expression is
+// known at the compile time, so it can initialize a constant e.g.
+// `10 ** 18`, `address(0)`, `keccak256("ADMIN")` or an expression of
+// other constants. The identifiers are looked up in the env.
+func IsCompileTimeConstant(expr ast.Expression, env *Environment) bool {
+	switch e := expr.(type) {
+	case *ast.NumberLiteral, *ast.BooleanLiteral, *ast.StringLiteral:
+		return true
+	case *ast.Identifier:
+		symbols, _ := env.Get(e.Value)
+		if len(symbols) != 1 {
+			return false
+		}
+		switch s := symbols[0].(type) {
+		case *ConstantVariable:
+			return true
+		case *StateVariable:
+			return s.Mutability == ast.Constant
+		}
+		return false
+	case *ast.PrefixExpression:
+		return IsCompileTimeConstant(e.Right, env)
+	case *ast.InfixExpression:
+		return IsCompileTimeConstant(e.Left, env) && IsCompileTimeConstant(e.Right, env)
+	case *ast.ElementaryTypeExpression:
+		// Conversions e.g. `address(0)` or `uint256(1)`.
+		return e.Value != nil && IsCompileTimeConstant(e.Value, env)
+	case *ast.CallExpression:
+		// The hash functions are evaluated at the compile time if their
+		// arguments are constants.
+		ident, ok := e.Ident.(*ast.Identifier)
+		if !ok || (ident.Value != "keccak256" && ident.Value != "sha256" && ident.Value != "ripemd160") {
+			return false
+		}
+		if _, declared := env.Get(ident.Value); declared {
+			return false
+		}
+		for _, arg := range e.Args {
+			if !IsCompileTimeConstant(arg, env) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// IsValueType reports whether the values of the type are copied rather than
+// referenced e.g. the integers, addresses and enums. Only the variables of
+// the value types can be immutable.
+func IsValueType(t types.Type) bool {
+	switch t := t.(type) {
+	case *types.Basic:
+		switch t.Kind() {
+		case types.Bool, types.Address, types.AddressPayable:
+			return true
+		}
+	case *types.Int, *types.FixedBytes, *types.Enum, *types.Contract:
+		return true
+	}
+	return false
+}
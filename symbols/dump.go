@@ -0,0 +1,198 @@
+package symbols
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ScopeDump is a snapshot of an env and of the envs nested in it. It is meant
+// for debugging the symbol discovery and the reference resolution, see the
+// `solbot symbols` command.
+type ScopeDump struct {
+	Kind    string        `json:"kind"` // FILE, CONTRACT, FUNCTION, CONSTRUCTOR, MODIFIER or BLOCK
+	Name    string        `json:"name"`
+	Symbols []*SymbolDump `json:"symbols,omitempty"`
+	Blocks  []*ScopeDump  `json:"blocks,omitempty"` // Nested blocks e.g. the bodies of if statements.
+}
+
+type SymbolDump struct {
+	Kind       string           `json:"kind"` // e.g. "contract", "state variable"
+	Name       string           `json:"name"`
+	Location   string           `json:"location"`
+	Visibility string           `json:"visibility,omitempty"`
+	Mutability string           `json:"mutability,omitempty"`
+	References []*ReferenceDump `json:"references,omitempty"`
+	Params     []*SymbolDump    `json:"params,omitempty"` // Params of the events; they are not declared in any env.
+	Scope      *ScopeDump       `json:"scope,omitempty"`  // Inner env of the symbol or nil.
+}
+
+type ReferenceDump struct {
+	Usage     string `json:"usage"`
+	ScopeType string `json:"scopeType"`
+	ScopeName string `json:"scopeName"`
+	Location  string `json:"location"`
+}
+
+// Dump returns the snapshot of the env. The envs of the symbols are dumped
+// with the symbols; the remaining inner envs are the nested blocks.
+func Dump(env *Environment) *ScopeDump {
+	return dumpScope(env, env.scopeType.String())
+}
+
+func dumpScope(env *Environment, kind string) *ScopeDump {
+	scope := &ScopeDump{Kind: kind, Name: env.scopeName}
+
+	owned := make(map[*Environment]bool)
+	for _, symbol := range GetAllSymbolsByType[Symbol](env) {
+		if inner := symbol.GetInnerEnv(); inner != nil {
+			owned[inner] = true
+		}
+		scope.Symbols = append(scope.Symbols, dumpSymbol(symbol))
+	}
+
+	for _, child := range env.children {
+		if !owned[child] {
+			scope.Blocks = append(scope.Blocks, dumpScope(child, "BLOCK"))
+		}
+	}
+
+	return scope
+}
+
+func dumpSymbol(symbol Symbol) *SymbolDump {
+	dump := &SymbolDump{}
+
+	var base *BaseSymbol
+	switch s := symbol.(type) {
+	case *Contract:
+		dump.Kind = "contract"
+		base = &s.BaseSymbol
+	case *Function:
+		dump.Kind = "function"
+		dump.Visibility = s.Visibility.String()
+		dump.Mutability = s.Mutability.String()
+		base = &s.BaseSymbol
+	case *Constructor:
+		dump.Kind = "constructor"
+		base = &s.BaseSymbol
+	case *Modifier:
+		dump.Kind = "modifier"
+		base = &s.BaseSymbol
+	case *Param:
+		dump.Kind = "param"
+		base = &s.BaseSymbol
+	case *StateVariable:
+		dump.Kind = "state variable"
+		dump.Visibility = s.Visibility.String()
+		dump.Mutability = s.Mutability.String()
+		base = &s.BaseSymbol
+	case *ConstantVariable:
+		dump.Kind = "constant variable"
+		dump.Mutability = "constant"
+		base = &s.BaseSymbol
+	case *LocalVariable:
+		dump.Kind = "local variable"
+		base = &s.BaseSymbol
+	case *Event:
+		dump.Kind = "event"
+		for _, param := range s.Parameters {
+			dump.Params = append(dump.Params, dumpSymbol(param))
+		}
+		base = &s.BaseSymbol
+	case *EventParam:
+		dump.Kind = "event param"
+		base = &s.BaseSymbol
+	case *Struct:
+		dump.Kind = "struct"
+		base = &s.BaseSymbol
+	case *Enum:
+		dump.Kind = "enum"
+		base = &s.BaseSymbol
+	case *CustomError:
+		dump.Kind = "error"
+		base = &s.BaseSymbol
+	case *ImportedSymbol:
+		dump.Kind = "import"
+		base = &s.BaseSymbol
+	default:
+		dump.Kind = fmt.Sprintf("%T", symbol)
+		dump.Location = symbol.Location()
+		return dump
+	}
+
+	dump.Name = base.Name
+	dump.Location = base.Location()
+	for _, ref := range base.References {
+		dump.References = append(dump.References, &ReferenceDump{
+			Usage:     ref.Context.Usage.String(),
+			ScopeType: ref.Context.ScopeType.String(),
+			ScopeName: ref.Context.ScopeName,
+			Location:  ref.Location(),
+		})
+	}
+
+	if inner := symbol.GetInnerEnv(); inner != nil {
+		dump.Scope = dumpScope(inner, inner.scopeType.String())
+	}
+
+	return dump
+}
+
+// Fprint writes the snapshot of the env as an indented tree e.g.
+//
+//	FILE Vault.sol
+//	  contract Vault Vault.sol:4:10
+//	    state variable owner Vault.sol:5:13 internal
+//	      ref READ FUNCTION withdraw Vault.sol:9:17
+//	    function withdraw Vault.sol:8:14 public
+//	      block
+//	        local variable amount Vault.sol:10:21
+func Fprint(w io.Writer, scope *ScopeDump) error {
+	p := &dumpPrinter{w: w}
+	p.printf(0, "%s %s", scope.Kind, scope.Name)
+	p.scope(1, scope)
+	return p.err
+}
+
+type dumpPrinter struct {
+	w   io.Writer
+	err error
+}
+
+func (p *dumpPrinter) printf(depth int, format string, args ...any) {
+	if p.err != nil {
+		return
+	}
+	_, p.err = fmt.Fprintf(p.w, strings.Repeat("  ", depth)+format+"\n", args...)
+}
+
+func (p *dumpPrinter) scope(depth int, scope *ScopeDump) {
+	for _, symbol := range scope.Symbols {
+		p.symbol(depth, symbol)
+	}
+	for _, block := range scope.Blocks {
+		p.printf(depth, "block")
+		p.scope(depth+1, block)
+	}
+}
+
+func (p *dumpPrinter) symbol(depth int, symbol *SymbolDump) {
+	header := symbol.Kind + " " + symbol.Name + " " + symbol.Location
+	for _, attr := range []string{symbol.Visibility, symbol.Mutability} {
+		if attr != "" {
+			header += " " + attr
+		}
+	}
+	p.printf(depth, "%s", header)
+
+	for _, ref := range symbol.References {
+		p.printf(depth+1, "ref %s %s %s %s", ref.Usage, ref.ScopeType, ref.ScopeName, ref.Location)
+	}
+	for _, param := range symbol.Params {
+		p.symbol(depth+1, param)
+	}
+	if symbol.Scope != nil {
+		p.scope(depth+1, symbol.Scope)
+	}
+}
@@ -4,8 +4,10 @@ import "fmt"
 
 type Environment struct {
 	store     map[string][]Symbol // Mapping between symbol's name and a struct holding all info about that symbol.
+	names     []string            // Symbol names in the order of declaration; keeps iteration over the store deterministic.
 	outer     *Environment        // Access to outer env for symbol lookups. Can be nil.
-	inner     *Environment        // Access to inner envs such as contract env which contains functions which themselves have inner envs. Can be nil.
+	children  []*Environment      // Inner envs such as the contract envs which contain the function envs which themselves have inner envs.
+	bases     []*Environment      // Envs of the linearized base contracts; searched before the outer env. Only for contract envs.
 	scopeName string              // Name of the env scope; FileName.sol for files; contract name for contracts etc.
 	scopeType ReferenceScopeType  // Type of the scope to be used for references
 }
@@ -21,33 +23,36 @@ func NewEnclosedEnvironment(outer *Environment,
 	env := NewEnvironment(scopeName, scopeType)
 	// Outer of the inner scope
 	env.outer = outer
-	// Set the new env as inner of the old one
-	outer.inner = env
+	// Add the new env to the inner envs of the old one
+	outer.children = append(outer.children, env)
 
 	return env
 }
 
 func (env *Environment) Set(ident string, symbol Symbol) {
+	if _, ok := env.store[ident]; !ok {
+		env.names = append(env.names, ident)
+	}
 	env.store[ident] = append(env.store[ident], symbol)
 	symbol.SetOuterEnv(env)
 }
 
-// TODO: The comment below might no longer be correct. There is no resolution
-// phase except for reference resolution. Each lookup must check that the returned
-// symbol from the array matches the expected type + param types in case of functions
-// with the same name and different accepted param types.
-//
 // Get looks up a symbol in the env provided the identifier. It returns an array
-// of symbols since during the symbol discovery (phase 1) there could have been
-// many symbols with the same name/signature (e.g. fn overrides) which must be
-// resolved in the resolution phase (phase 2). Get returns false if the symbol
-// is not found.
+// of symbols since there can be many symbols with the same name e.g. the
+// overloaded functions and events. The caller must pick the one it needs,
+// see Analyzer.resolveEvent. Get returns false if the symbol is not found.
 func (env *Environment) Get(ident string) ([]Symbol, bool) {
 	// check current env
 	if symbols, ok := env.store[ident]; ok {
 		return symbols, true
 	}
 
+	// check inherited contracts; their outer envs are not searched, since
+	// they can be declared in other files
+	if symbols, ok := env.getFromBases(ident); ok {
+		return symbols, true
+	}
+
 	// check outer scope
 	if env.outer != nil {
 		if symbols, ok := env.outer.Get(ident); ok {
@@ -58,6 +63,67 @@ func (env *Environment) Get(ident string) ([]Symbol, bool) {
 	return nil, false
 }
 
+// GetAll is like Get, but it returns the symbols with the name from all the
+// scopes visible in the env: the env itself, the inherited contracts and the
+// outer envs. The symbols of the inner scopes come first. It is useful when
+// the declaration is already known e.g. from the type checker, and only its
+// symbol must be found, even if it is hidden by another declaration.
+func (env *Environment) GetAll(ident string) []Symbol {
+	var results []Symbol
+	for ; env != nil; env = env.outer {
+		results = append(results, env.store[ident]...)
+		for _, base := range env.bases {
+			results = append(results, base.store[ident]...)
+		}
+	}
+	return results
+}
+
+// GetSuper looks up a symbol the way `super.ident` does: in the base
+// contracts of the enclosing contract, skipping the contract itself. It
+// returns false if the symbol is not found or if the env is not in a
+// contract.
+func (env *Environment) GetSuper(ident string) ([]Symbol, bool) {
+	for ; env != nil; env = env.outer {
+		if env.scopeType == CONTRACT {
+			return env.getFromBases(ident)
+		}
+	}
+	return nil, false
+}
+
+func (env *Environment) getFromBases(ident string) ([]Symbol, bool) {
+	for _, base := range env.bases {
+		if symbols, ok := base.store[ident]; ok {
+			return symbols, true
+		}
+	}
+	return nil, false
+}
+
+// SetBases sets the envs of the base contracts of the contract env. They
+// must be in the order of the C3 linearization, without the contract
+// itself, so the most derived declaration of a symbol is found first.
+func (env *Environment) SetBases(bases []*Environment) {
+	env.bases = bases
+}
+
+// Bases returns the envs of the base contracts set with SetBases.
+func (env *Environment) Bases() []*Environment {
+	return env.bases
+}
+
+// Outer returns the env enclosing the env or nil for the file envs.
+func (env *Environment) Outer() *Environment {
+	return env.outer
+}
+
+// Children returns the envs enclosed by the env in the order they were
+// created e.g. the envs of the contracts of a file env.
+func (env *Environment) Children() []*Environment {
+	return env.children
+}
+
 func (env *Environment) GetCurrentScopeName() string {
 	return env.scopeName
 }
@@ -85,12 +151,13 @@ func GetOuterEnv(s Symbol) (*Environment, error) {
 }
 
 // Returns an array of all the symbols with a specific symbol type from an environment.
+// The symbols are returned in the order in which they were declared.
 // This can return 0 elements if nothing was found. WARNING: check returned array's length.
 func GetAllSymbolsByType[T any](env *Environment) []T {
 	var results []T
 
-	for _, symbols := range env.store {
-		for _, symbol := range symbols {
+	for _, name := range env.names {
+		for _, symbol := range env.store[name] {
 			if typedSymbol, ok := symbol.(T); ok {
 				results = append(results, typedSymbol)
 			}
@@ -98,3 +165,31 @@ func GetAllSymbolsByType[T any](env *Environment) []T {
 	}
 	return results
 }
+
+// GetAllSymbolsByTypeInFile is like GetAllSymbolsByType, but it returns the
+// symbols declared in the contracts of the file env as well. The file level
+// symbols come first, followed by the symbols of each contract in the order
+// of the contracts' declaration.
+func GetAllSymbolsByTypeInFile[T any](fileEnv *Environment) []T {
+	results := GetAllSymbolsByType[T](fileEnv)
+
+	for _, contract := range GetAllSymbolsByType[*Contract](fileEnv) {
+		if inner := contract.GetInnerEnv(); inner != nil {
+			results = append(results, GetAllSymbolsByType[T](inner)...)
+		}
+	}
+	return results
+}
+
+// GetAllSymbolsByTypeInTree is like GetAllSymbolsByType, but it returns the
+// symbols declared in all the envs enclosed by the env as well e.g. the
+// local variables of the nested blocks of a function. The symbols of an env
+// come before the symbols of its inner envs.
+func GetAllSymbolsByTypeInTree[T any](env *Environment) []T {
+	results := GetAllSymbolsByType[T](env)
+
+	for _, child := range env.children {
+		results = append(results, GetAllSymbolsByTypeInTree[T](child)...)
+	}
+	return results
+}
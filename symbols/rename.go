@@ -0,0 +1,70 @@
+package symbols
+
+import "github.com/ChmielewskiKamil/solbot/token"
+
+// Occurrences returns the positions of the name of the symbol at its
+// declaration and at all of its references e.g. to rename the symbol. An
+// identifier can be referenced more than once e.g. as both read and written
+// in the inline assembly, but its position is returned once.
+func Occurrences(symbol *BaseSymbol) []token.Pos {
+	positions := []token.Pos{symbol.Offset}
+	seen := map[token.Pos]bool{symbol.Offset: true}
+	for _, ref := range symbol.References {
+		if !seen[ref.Offset] {
+			seen[ref.Offset] = true
+			positions = append(positions, ref.Offset)
+		}
+	}
+	return positions
+}
+
+// IsDeclared reports whether the name is declared in a scope the members of
+// the contract are visible in: the contract and the contracts inheriting it,
+// including their functions e.g. as a parameter or a local variable. A member
+// renamed to such a name could be shadowed by it, or shadow it. The members
+// referenced anywhere else are accessed by the member access e.g. `v.f()`,
+// so they can't be shadowed there.
+func IsDeclared(contract *Contract, name string) bool {
+	for _, c := range inheriting(contract) {
+		if env := c.GetInnerEnv(); env != nil && isDeclaredIn(env, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// inheriting returns the contract and the contracts of its file inheriting
+// it, directly or not.
+func inheriting(contract *Contract) []*Contract {
+	results := []*Contract{contract}
+	fileEnv := contract.GetOuterEnv()
+	if fileEnv == nil {
+		return results
+	}
+	for _, c := range GetAllSymbolsByType[*Contract](fileEnv) {
+		for _, base := range c.LinearizedBases {
+			if base == contract && c != contract {
+				results = append(results, c)
+				break
+			}
+		}
+	}
+	return results
+}
+
+func isDeclaredIn(contractEnv *Environment, name string) bool {
+	if _, ok := contractEnv.Get(name); ok {
+		return true
+	}
+	for _, param := range GetAllSymbolsByTypeInTree[*Param](contractEnv) {
+		if param.Name == name {
+			return true
+		}
+	}
+	for _, local := range GetAllSymbolsByTypeInTree[*LocalVariable](contractEnv) {
+		if local.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/ChmielewskiKamil/solbot/ast"
 	"github.com/ChmielewskiKamil/solbot/token"
+	"github.com/ChmielewskiKamil/solbot/types"
 )
 
 // Symbol represents any identifiable entity in the Solidity source code, such as contracts,
@@ -16,6 +17,8 @@ type Symbol interface {
 	GetOuterEnv() *Environment // Gets the outer env of symbol. This is the env where symbol is declared.
 	SetInnerEnv(*Environment)  // Sets inner env of symbol.
 	SetOuterEnv(*Environment)  // Sets outer env of symbol.
+	GetAstNode() ast.Node      // Gets the declaration of the symbol.
+	AddReference(*Reference)   // Records a place where the symbol is used.
 }
 
 type BaseSymbol struct {
@@ -59,9 +62,22 @@ func (bs *BaseSymbol) GetOuterEnv() *Environment {
 	return bs.outerEnv
 }
 
+func (bs *BaseSymbol) GetAstNode() ast.Node {
+	return bs.AstNode
+}
+
+func (bs *BaseSymbol) AddReference(ref *Reference) {
+	bs.References = append(bs.References, ref)
+}
+
 type (
 	Contract struct {
 		BaseSymbol
+		// The C3 linearization of the inheritance graph, starting with the
+		// contract itself; or nil if the linearization is impossible. The
+		// bases that can't be resolved e.g. declared in other files, are
+		// skipped.
+		LinearizedBases []*Contract
 	}
 
 	Function struct {
@@ -74,14 +90,46 @@ type (
 		Body       *ast.BlockStatement
 	}
 
+	// Constructor is declared in the contract env as "constructor". Its
+	// inner env has the CONSTRUCTOR scope type.
+	Constructor struct {
+		BaseSymbol
+		Parameters []*Param
+		Body       *ast.BlockStatement
+	}
+
+	Modifier struct {
+		BaseSymbol
+		Parameters []*Param
+		Virtual    bool
+		Body       *ast.BlockStatement
+	}
+
 	Param struct {
 		BaseSymbol
-		// TODO: What about the type?
+		Type         types.Type // or nil if the file was not type checked
 		DataLocation ast.DataLocation
 	}
 
 	StateVariable struct {
 		BaseSymbol
+		Type       types.Type // or nil if the file was not type checked
+		Visibility ast.Visibility
+		Mutability ast.Mutability // constant, immutable, transient or none
+	}
+
+	// ConstantVariable is a constant declared at the file level. The
+	// constants declared in the contracts are the state variables.
+	ConstantVariable struct {
+		BaseSymbol
+	}
+
+	// LocalVariable is a variable declared in a function body, including
+	// the components of the tuple declarations.
+	LocalVariable struct {
+		BaseSymbol
+		Type         types.Type // or nil if the file was not type checked
+		DataLocation ast.DataLocation
 	}
 
 	Event struct {
@@ -92,9 +140,41 @@ type (
 
 	EventParam struct {
 		BaseSymbol
-		// TODO: What about the type?
+		Type      types.Type // or nil if the file was not type checked
 		IsIndexed bool
 	}
+
+	// Struct is a struct declared in a contract or at the file level. It is
+	// referenced by the type names e.g. of the variables, and by the struct
+	// constructor calls.
+	Struct struct {
+		BaseSymbol
+	}
+
+	// Enum is an enum declared in a contract or at the file level. It is
+	// referenced by the type names and by the member accesses e.g.
+	// `Status.Active`.
+	Enum struct {
+		BaseSymbol
+		Members []string
+	}
+
+	// CustomError is an error declared in a contract or at the file level
+	// e.g. `error Unauthorized(address caller);`. The reverts with the error
+	// are the CALL references.
+	CustomError struct {
+		BaseSymbol
+	}
+
+	// ImportedSymbol is a name imported into the file by the directive: a
+	// symbol of `import {A, B as C} from "./A.sol";`, the alias of
+	// `import * as M from "./A.sol";` or one of the names exported by the
+	// file of `import "./A.sol";`. The AstNode is the *ast.ImportSymbol for
+	// the symbols and the directive otherwise.
+	ImportedSymbol struct {
+		BaseSymbol
+		Directive *ast.ImportDirective
+	}
 )
 
 ////////////////////////////////////////////////////////////////////
@@ -110,6 +190,15 @@ type Reference struct {
 	AstNode    ast.Node          // Pointer to ast node.
 }
 
+func (r *Reference) Location() string {
+	if r.SourceFile != nil {
+		line, column := r.SourceFile.GetLineAndColumn(r.Offset)
+		return fmt.Sprintf("%s:%d:%d", r.SourceFile.RelativePathFromProjectRoot(), line, column)
+	}
+
+	return "Missing location of reference. No source file info."
+}
+
 type ReferenceContext struct {
 	ScopeName string
 	ScopeType ReferenceScopeType
@@ -149,6 +238,7 @@ const (
 	CONTRACT
 	FUNCTION
 	CONSTRUCTOR
+	MODIFIER
 )
 
 func (s ReferenceScopeType) String() string {
@@ -161,6 +251,8 @@ func (s ReferenceScopeType) String() string {
 		return "FUNCTION"
 	case CONSTRUCTOR:
 		return "CONSTRUCTOR"
+	case MODIFIER:
+		return "MODIFIER"
 	default:
 		return "UNKNOWN"
 	}
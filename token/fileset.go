@@ -0,0 +1,138 @@
+package token
+
+import (
+	"errors"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// FileSet is a set of source files analyzed together e.g. the files of a
+// project. Every added file is assigned a base, so that the files occupy
+// disjoint ranges of positions: [base, base+size]. A Pos alone is then
+// enough to tell both the file and the offset within it.
+//
+// The set can have a project root. The paths of the added files are then
+// reported relative to it, see SourceFile.RelativePathFromProjectRoot.
+//
+// The methods of the FileSet can be used concurrently.
+type FileSet struct {
+	mu    sync.RWMutex
+	root  string        // absolute path to the project root; empty if not set
+	base  int           // base of the next file
+	files []*SourceFile // sorted by base
+}
+
+// NewFileSet returns an empty file set.
+func NewFileSet() *FileSet {
+	return &FileSet{}
+}
+
+// SetRoot sets the project root of the set. A relative root is resolved
+// against the current working directory. It must be called before any files
+// are added, since their relative paths are computed only once, in AddFile.
+func (s *FileSet) SetRoot(root string) error {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.files) > 0 {
+		return errors.New("the project root must be set before any files are added")
+	}
+	s.root = abs
+
+	return nil
+}
+
+// Root returns the absolute path to the project root or an empty string if
+// the root is not set.
+func (s *FileSet) Root() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.root
+}
+
+// Base returns the base that the next added file will get.
+func (s *FileSet) Base() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.base
+}
+
+// AddFile assigns the next base to the file and adds it to the set. The file
+// must be added before any positions are computed for it, i.e. before it is
+// lexed. AddFile panics if the file already belongs to a set.
+func (s *FileSet) AddFile(file *SourceFile) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if file.inSet {
+		panic("token.AddFile: " + file.Name() + " already belongs to a file set")
+	}
+
+	file.base = s.base
+	file.inSet = true
+	if s.root != "" {
+		file.relativePathFromProjectRoot = relativePath(s.root, file.path)
+	}
+	s.files = append(s.files, file)
+
+	// +1 so that the EOF position of the file is not the first position of
+	// the next file.
+	s.base += len(file.Content()) + 1
+}
+
+// File returns the file containing the pos or nil if there is none.
+func (s *FileSet) File(pos Pos) *SourceFile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// The index of the first file that starts after the pos is the one past
+	// the file that might contain the pos.
+	i := sort.Search(len(s.files), func(i int) bool { return s.files[i].base > int(pos) }) - 1
+	if i < 0 || !s.files[i].Contains(pos) {
+		return nil
+	}
+
+	return s.files[i]
+}
+
+// Position converts the pos into a Position in the file containing it. If no
+// file in the set contains the pos, the returned position is not valid.
+func (s *FileSet) Position(pos Pos) Position {
+	if file := s.File(pos); file != nil {
+		return file.Position(pos)
+	}
+
+	return Position{Offset: pos}
+}
+
+// Files returns the files of the set in the order they were added.
+func (s *FileSet) Files() []*SourceFile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]*SourceFile(nil), s.files...)
+}
+
+// relativePath returns the path relative to the root. The path is returned as
+// is if it can't be expressed relative to the root.
+func relativePath(root, path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return path
+	}
+
+	return filepath.ToSlash(rel)
+}
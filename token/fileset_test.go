@@ -0,0 +1,128 @@
+package token
+
+import "testing"
+
+func Test_FileSet_DisjointBases(t *testing.T) {
+	a, err := NewSourceFile("A.sol", "contract A {}\n")
+	if err != nil {
+		t.Fatalf("Failed to create A.sol: %v", err)
+	}
+	b, err := NewSourceFile("B.sol", "contract B {\n}")
+	if err != nil {
+		t.Fatalf("Failed to create B.sol: %v", err)
+	}
+
+	fset := NewFileSet()
+	fset.AddFile(a)
+	fset.AddFile(b)
+
+	if a.Base() != 0 {
+		t.Errorf("Expected the base of A.sol to be 0, got %d", a.Base())
+	}
+	// The EOF position of A.sol must not be the first position of B.sol.
+	if b.Base() != len(a.Content())+1 {
+		t.Errorf("Expected the base of B.sol to be %d, got %d", len(a.Content())+1, b.Base())
+	}
+	if fset.Base() != b.Base()+len(b.Content())+1 {
+		t.Errorf("Expected the next base to be %d, got %d", b.Base()+len(b.Content())+1, fset.Base())
+	}
+
+	tests := []struct {
+		name     string
+		pos      Pos
+		file     *SourceFile
+		expected string
+	}{
+		{"Start of A.sol", a.Pos(0), a, "A.sol:1:1"},
+		{"Name of A", a.Pos(9), a, "A.sol:1:10"},
+		{"End of A.sol", a.Pos(len(a.Content())), a, "A.sol:2:1"},
+		{"Start of B.sol", b.Pos(0), b, "B.sol:1:1"},
+		{"Closing brace of B", b.Pos(13), b, "B.sol:2:1"},
+		{"End of B.sol", b.Pos(len(b.Content())), b, "B.sol:2:2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if file := fset.File(tt.pos); file != tt.file {
+				t.Errorf("Expected the pos %d to be in %s, got %v", tt.pos, tt.file.Name(), file)
+			}
+
+			position := fset.Position(tt.pos)
+			if position.String() != tt.expected {
+				t.Errorf("Expected the position %s, got %s", tt.expected, position)
+			}
+			if position.Offset != tt.pos {
+				t.Errorf("Expected the offset %d, got %d", tt.pos, position.Offset)
+			}
+
+			if offset := tt.file.Offset(tt.pos); tt.file.Pos(offset) != tt.pos {
+				t.Errorf("Expected the offset %d to convert back to %d, got %d", offset, tt.pos, tt.file.Pos(offset))
+			}
+		})
+	}
+
+	if file := fset.File(Pos(fset.Base())); file != nil {
+		t.Errorf("Expected no file for the pos past the last file, got %s", file.Name())
+	}
+	if fset.Position(Pos(fset.Base())).IsValid() {
+		t.Errorf("Expected an invalid position for the pos past the last file")
+	}
+}
+
+func Test_FileSet_AddFileTwice(t *testing.T) {
+	file, err := NewSourceFile("A.sol", "contract A {}")
+	if err != nil {
+		t.Fatalf("Failed to create A.sol: %v", err)
+	}
+
+	NewFileSet().AddFile(file)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected AddFile to panic for a file from another set")
+		}
+	}()
+
+	NewFileSet().AddFile(file)
+}
+
+func Test_FileSet_RelativePaths(t *testing.T) {
+	fset := NewFileSet()
+	if err := fset.SetRoot("/repo/packages/contracts"); err != nil {
+		t.Fatalf("SetRoot failed: %v", err)
+	}
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/repo/packages/contracts/src/Vault.sol", "src/Vault.sol"},
+		{"/repo/packages/lib/Math.sol", "../lib/Math.sol"},
+	}
+
+	for _, tt := range tests {
+		file, err := NewSourceFile(tt.path, "contract A {}")
+		if err != nil {
+			t.Fatalf("Failed to create %s: %v", tt.path, err)
+		}
+		fset.AddFile(file)
+
+		if file.RelativePathFromProjectRoot() != tt.expected {
+			t.Errorf("Expected the relative path %s, got %s", tt.expected, file.RelativePathFromProjectRoot())
+		}
+	}
+
+	if err := fset.SetRoot("/repo"); err == nil {
+		t.Errorf("Expected an error when setting the root of a set with files")
+	}
+
+	// Without a root the path is reported as given.
+	file, err := NewSourceFile("src/Vault.sol", "contract A {}")
+	if err != nil {
+		t.Fatalf("Failed to create src/Vault.sol: %v", err)
+	}
+	NewFileSet().AddFile(file)
+	if file.RelativePathFromProjectRoot() != "src/Vault.sol" {
+		t.Errorf("Expected the path src/Vault.sol, got %s", file.RelativePathFromProjectRoot())
+	}
+}
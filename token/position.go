@@ -1,16 +1,24 @@
 package token
 
 import (
-	"bufio"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
+	"unicode/utf16"
+	"unicode/utf8"
 )
 
-// Pos is the file offset to the beginning of a token, starting from 0
+// Pos is the position of a token, starting from 0. For a standalone
+// SourceFile it is the byte offset in the file. The files added to a FileSet
+// occupy disjoint ranges of positions, so the Pos is unique across the set.
 type Pos int
 
+// Position is the human readable location in a source file. Line and
+// Column are 1-based; the Column is a byte count, see RuneColumn and
+// UTF16Column for the other ways of counting columns. Offset is the Pos
+// the position was computed from.
 type Position struct {
 	Filename string
 	Offset   Pos
@@ -18,55 +26,35 @@ type Position struct {
 	Column   int
 }
 
-// TODO: offset to position could be the method of pos which takes a ref to a
-// file and returns a proper position struct.
+// IsValid reports whether the position has a line and column.
+func (p Position) IsValid() bool { return p.Line > 0 }
 
-func OffsetToPosition(reader io.Reader, pos *Position) {
-	// Wrap into a buffered reader to use utility functions e.g. ReadString()
-	bufReader := bufio.NewReader(reader)
-	offset := pos.Offset
-	line := 1
-	column := 1
-	cumulativeOffset := 0
-
-	for {
-		l, err := bufReader.ReadString('\n')
-		lineLength := len(l)
-		// err is most likely io.EOF or error where no data was read,
-		// so we want to break.
-		// If there is an other error, e.g. \n delimeter is not found, we
-		// might have some remaining data in l left to be processed. In
-		// this scenario we don't want to break.
-		if err != nil && lineLength == 0 {
-			break
-		}
-
-		// The offset that we are looking for is in the current line.
-		if cumulativeOffset+lineLength > int(offset) {
-			// By subtracting the bytes that we accumulated so far from the
-			// offset that we are looking for, we get the bytes from the
-			// start of the current line up to the thing that we are looking for.
-			// This is the column number. Since the human readable representation
-			// of the column in the text editor starts from 1, we add 1 to get
-			// the 1 based indexing.
-			column = int(offset) - cumulativeOffset + 1
-			// Target reached.
-			break
+// String returns the position in the form of "file:line:column". The parts
+// that are not known are omitted, e.g. "file" or "line:column".
+func (p Position) String() string {
+	s := p.Filename
+	if p.IsValid() {
+		if s != "" {
+			s += ":"
 		}
-
-		cumulativeOffset += lineLength
-		line++
+		s += fmt.Sprintf("%d:%d", p.Line, p.Column)
 	}
-
-	pos.Line = line
-	pos.Column = column
+	if s == "" {
+		s = "-"
+	}
+	return s
 }
 
 type SourceFile struct {
 	name                        string // file name e.g. "foo.sol"
-	relativePathFromProjectRoot string // path to the file from project root e.g. where foundry.toml is defined
+	path                        string // path the file was created with e.g. "src/foo.sol"
+	relativePathFromProjectRoot string // path to the file from the project root; set by FileSet.AddFile
 	content                     string // file content; source code passed to the parser
 	lines                       []int  // offsets of the first character of each line
+	base                        int    // Pos of the first character; set by FileSet.AddFile
+	inSet                       bool   // Whether the file was added to a FileSet
+
+	linesOnce sync.Once // guards the lazy computation of the lines
 }
 
 func (sf *SourceFile) Name() string {
@@ -77,12 +65,19 @@ func (sf *SourceFile) Content() string {
 	return sf.content
 }
 
+// RelativePathFromProjectRoot returns the path of the file relative to the
+// root of the FileSet it belongs to. If the file is not in a set, or the set
+// has no root, the path the file was created with is returned.
 func (sf *SourceFile) RelativePathFromProjectRoot() string {
+	if sf.relativePathFromProjectRoot == "" {
+		return sf.path
+	}
 	return sf.relativePathFromProjectRoot
 }
 
+// LineOffsets returns the offsets of the first character of each line.
 func (sf *SourceFile) LineOffsets() []int {
-	return sf.lines
+	return sf.lineTable()
 }
 
 func (sf *SourceFile) ComputeLineOffsets() {
@@ -90,8 +85,8 @@ func (sf *SourceFile) ComputeLineOffsets() {
 	// Since we append pointing to next line, on first iter, 0th one would
 	// be skipped.
 	lines := []int{0}
-	for offset, char := range sf.content {
-		if char == '\n' {
+	for offset := 0; offset < len(sf.content); offset++ {
+		if sf.content[offset] == '\n' {
 			// +1 to skip \n and point to start of next line
 			lines = append(lines, offset+1)
 		}
@@ -99,89 +94,155 @@ func (sf *SourceFile) ComputeLineOffsets() {
 	sf.lines = lines
 }
 
+// lineTable returns the line offsets, computing them on the first use. The
+// content of a SourceFile never changes, so the table is computed only once
+// and can be shared by concurrent readers e.g. the LSP handlers.
+func (sf *SourceFile) lineTable() []int {
+	sf.linesOnce.Do(func() {
+		if len(sf.lines) == 0 {
+			sf.ComputeLineOffsets()
+		}
+	})
+	return sf.lines
+}
+
+// Base returns the Pos of the first character of the file. It is 0, unless
+// the file was added to a FileSet.
+func (sf *SourceFile) Base() int {
+	return sf.base
+}
+
+// Pos returns the Pos of the byte offset in the file.
+func (sf *SourceFile) Pos(offset int) Pos {
+	return Pos(sf.base + offset)
+}
+
+// Offset returns the byte offset in the file of the Pos.
+func (sf *SourceFile) Offset(pos Pos) int {
+	return int(pos) - sf.base
+}
+
+// Contains reports whether the pos is within the file. The position right
+// after the last character is part of the file as well; it is the position
+// of EOF.
+func (sf *SourceFile) Contains(pos Pos) bool {
+	offset := sf.Offset(pos)
+	return offset >= 0 && offset <= len(sf.content)
+}
+
+// line returns the 0-based index of the line containing the offset. The
+// offset must be valid.
+func (sf *SourceFile) line(offset int) int {
+	lines := sf.lineTable()
+	// The index of the first line that starts after the offset is the one
+	// past the line containing the offset.
+	return sort.Search(len(lines), func(i int) bool { return lines[i] > offset }) - 1
+}
+
+// Position converts the pos into a Position. The Filename is the path of the
+// file relative to the project root (see RelativePathFromProjectRoot). If the
+// pos is not within the file, the returned position is not valid (see
+// Position.IsValid).
+func (sf *SourceFile) Position(pos Pos) Position {
+	position := Position{Filename: sf.RelativePathFromProjectRoot(), Offset: pos}
+	position.Line, position.Column = sf.GetLineAndColumn(pos)
+	if !position.IsValid() {
+		position.Line, position.Column = 0, 0
+	}
+	return position
+}
+
 // GetLineAndColumn returns the (line, column) position in a source file based on the
-// provided offset. If the provided offset is invalid, the function returns (-1, -1).
-// If the line offsets were not computed yet for this SourceFile, GetLineAndColumn
-// will call ComputeLineOffsets function.
-func (sf *SourceFile) GetLineAndColumn(offset Pos) (int, int) {
-	// Check if the offset is valid
-	if offset < 0 || int(offset) >= len(sf.content) {
+// provided pos. The column is counted in bytes. If the provided pos is not
+// within the file, the function returns (-1, -1).
+func (sf *SourceFile) GetLineAndColumn(pos Pos) (int, int) {
+	if !sf.Contains(pos) {
 		return -1, -1
 	}
 
-	if len(sf.lines) == 0 {
-		sf.ComputeLineOffsets()
-	}
-
-	// Initialize the search range for binary search.
-	// `low` is the start of the range, and `high` is the end of the range.
-	// The `-1` for `high` ensures we stay within the valid index range of the array,
-	// since arrays are zero-indexed.
-	low, high := 0, len(sf.lines)-1
-
-	// Perform binary search to find the line that contains the offset.
-	for low <= high {
-		// Calculate the middle index of the current search range.
-		// This is where we will check if the offset falls before, at, or after this line.
-		mid := (low + high) / 2
-
-		// If the starting position of the current line (`sf.lines[mid]`)
-		// is less than or equal to the target `offset`, then the offset
-		// might be within this line or one of the lines after it.
-		// In this case, we adjust `low` to narrow the search range to the right half.
-		if sf.lines[mid] <= int(offset) {
-			low = mid + 1
-		} else {
-			// If the starting position of the current line (`sf.lines[mid]`)
-			// is greater than the target `offset`, then the offset must be in one
-			// of the lines before this line. We adjust `high` to narrow the search
-			// range to the left half.
-			high = mid - 1
-		}
+	offset := sf.Offset(pos)
+	line := sf.line(offset)
+
+	// +1 because line and column numbers in text editors start at 1 instead of 0.
+	return line + 1, offset - sf.lineTable()[line] + 1
+}
+
+// RuneColumn returns the 1-based column of the pos counted in Unicode code
+// points, or -1 if the pos is not within the file.
+func (sf *SourceFile) RuneColumn(pos Pos) int {
+	if !sf.Contains(pos) {
+		return -1
 	}
 
-	// After the loop ends:
-	// - `high` will point to the largest index where `sf.lines[high]` is still less than
-	//   or equal to the `offset`. This means it identifies the start of the line that
-	//   contains the `offset`.
-	// - `low` will be the index of the next line, which is strictly greater than the `offset`.
-	// We stop the loop because we've narrowed down the line where the `offset` resides.
+	offset := sf.Offset(pos)
+	lineStart := sf.lineTable()[sf.line(offset)]
+	return utf8.RuneCountInString(sf.content[lineStart:offset]) + 1
+}
 
-	// +1 because line numbers in text editors start at 1 instead of 0.
-	line := high + 1
-	// The same +1 based indexing applies to columns.
-	column := int(offset) - sf.lines[high] + 1
+// UTF16Column returns the 1-based column of the pos counted in UTF-16 code
+// units, or -1 if the pos is not within the file. This is how the LSP
+// clients count the characters by default.
+func (sf *SourceFile) UTF16Column(pos Pos) int {
+	if !sf.Contains(pos) {
+		return -1
+	}
 
-	return line, column
+	offset := sf.Offset(pos)
+	lineStart := sf.lineTable()[sf.line(offset)]
+	column := 1
+	for _, r := range sf.content[lineStart:offset] {
+		column += utf16.RuneLen(r)
+	}
+	return column
 }
 
-// GetOffset returns the offset in the source file based on the provided line and column.
-// If the provided line or column is invalid, it returns -1.
-// If the line offsets were not computed yet for this SourceFile, GetOffset
-// will call ComputeLineOffsets function.
+// GetOffset returns the pos in the source file based on the provided line and column.
+// The column is counted in bytes. If the provided line or column is invalid, it returns -1.
 func (sf *SourceFile) GetOffset(line, column int) Pos {
+	lines := sf.lineTable()
+
 	// Validate the line number.
-	if line <= 0 || line > len(sf.lines) {
+	if line <= 0 || line > len(lines) {
 		return -1 // Invalid line number.
 	}
 
-	// Ensure the line offsets are computed.
-	if len(sf.lines) == 0 {
-		sf.ComputeLineOffsets()
-	}
-
 	// Get the starting offset for the specified line.
-	lineStart := sf.lines[line-1] // Line numbers are 1-based, so subtract 1 for the index.
+	lineStart := lines[line-1] // Line numbers are 1-based, so subtract 1 for the index.
 
 	// Compute the offset by adding the column offset.
 	offset := lineStart + column - 1 // Columns are also 1-based, so subtract 1.
 
 	// Validate the computed offset against the file length.
-	if offset < 0 || offset >= len(sf.content) {
+	if column <= 0 || offset >= len(sf.content) {
 		return -1 // Invalid column for the given line.
 	}
 
-	return Pos(offset)
+	return sf.Pos(offset)
+}
+
+// OffsetFromUTF16 returns the pos of the 1-based line and column, where the
+// column is counted in UTF-16 code units. A column past the end of the line
+// is clamped to the end of the line, as required by the LSP specification.
+// If the line is invalid, it returns -1.
+func (sf *SourceFile) OffsetFromUTF16(line, column int) Pos {
+	lines := sf.lineTable()
+	if line <= 0 || line > len(lines) || column <= 0 {
+		return -1
+	}
+
+	lineEnd := len(sf.content)
+	if line < len(lines) {
+		lineEnd = lines[line] - 1 // Stop at the '\n'.
+	}
+
+	offset := lines[line-1]
+	for units := 1; offset < lineEnd && units < column; {
+		r, width := utf8.DecodeRuneInString(sf.content[offset:lineEnd])
+		units += utf16.RuneLen(r)
+		offset += width
+	}
+
+	return sf.Pos(offset)
 }
 
 func NewSourceFile(fileNameOrPath, src string) (*SourceFile, error) {
@@ -189,6 +250,7 @@ func NewSourceFile(fileNameOrPath, src string) (*SourceFile, error) {
 	if src != "" {
 		return &SourceFile{
 			name:    fileNameOrPath,
+			path:    fileNameOrPath,
 			content: src,
 		}, nil
 	}
@@ -199,57 +261,9 @@ func NewSourceFile(fileNameOrPath, src string) (*SourceFile, error) {
 		return nil, fmt.Errorf("failed to read file from path %s: %w", fileNameOrPath, err)
 	}
 
-	// Compute relative path from project root
-	relativePath, err := getRelativePath(fileNameOrPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to determine relative path for %s: %w", fileNameOrPath, err)
-	}
-
 	return &SourceFile{
-		name:                        filepath.Base(fileNameOrPath),
-		relativePathFromProjectRoot: relativePath,
-		content:                     string(content),
+		name:    filepath.Base(fileNameOrPath),
+		path:    fileNameOrPath,
+		content: string(content),
 	}, nil
 }
-
-// projectMarkers defines files or directories that indicate the project root.
-var projectMarkers = []string{
-	"hardhat.config.js",
-	"hardhat.config.ts",
-	"foundry.toml",
-	"remappings.txt",
-	"truffle.js",
-	"truffle-config.js",
-	"ape-config.yaml",
-	".git",
-	"package.json",
-	"node_modules",
-}
-
-// findProjectRoot traverses up the directory tree to locate the project root.
-func findProjectRoot(startPath string) (string, error) {
-	// startPath is the dir where .sol file is.
-	currPath := startPath
-	for {
-		for _, marker := range projectMarkers {
-			// Look for markers at each dir.
-			if _, err := os.Stat(filepath.Join(currPath, marker)); err == nil {
-				return currPath, nil
-			}
-		}
-		parentDir := filepath.Dir(currPath)
-		if parentDir == currPath {
-			return "", fmt.Errorf("Couldn't find foundry/hardhat/ape project root. You can init a git repo (or add foundry.toml etc.) to create one.")
-		}
-		currPath = parentDir
-	}
-}
-
-// getRelativePath computes the relative path to the project root.
-func getRelativePath(filePath string) (string, error) {
-	projectRoot, err := findProjectRoot(filepath.Dir(filePath))
-	if err != nil {
-		return "", err
-	}
-	return filepath.Rel(projectRoot, filePath)
-}
@@ -114,3 +114,65 @@ func Fuzz_LineAndColumnOffsetConsistency(f *testing.F) {
 
 	})
 }
+
+func Test_Position_EndOfFile(t *testing.T) {
+	sf := &SourceFile{name: "test.sol", path: "test.sol", content: "contract A {}\n"}
+
+	// The offset right after the last character is where the EOF token is.
+	pos := sf.Position(Pos(len(sf.content)))
+	if pos.String() != "test.sol:2:1" {
+		t.Errorf("Expected test.sol:2:1, got %s", pos)
+	}
+
+	pos = sf.Position(Pos(len(sf.content) + 1))
+	if pos.IsValid() || pos.String() != "test.sol" {
+		t.Errorf("Expected an invalid position, got %s", pos)
+	}
+}
+
+func Test_UnicodeColumns(t *testing.T) {
+	// "é" is 2 bytes and 1 UTF-16 code unit; "🚀" is 4 bytes and 2 UTF-16
+	// code units (a surrogate pair).
+	content := "// é🚀\nx"
+	sf := &SourceFile{name: "test.sol", content: content}
+
+	tests := []struct {
+		name        string
+		offset      int
+		byteColumn  int
+		runeColumn  int
+		utf16Column int
+	}{
+		{"Before the multi-byte characters", 3, 4, 4, 4},
+		{"After é", 5, 6, 5, 5},
+		{"After the rocket", 9, 10, 6, 7},
+		{"Next line", 10, 1, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, column := sf.GetLineAndColumn(Pos(tt.offset))
+			if column != tt.byteColumn {
+				t.Errorf("Expected byte column %d, got %d", tt.byteColumn, column)
+			}
+
+			if column := sf.RuneColumn(Pos(tt.offset)); column != tt.runeColumn {
+				t.Errorf("Expected rune column %d, got %d", tt.runeColumn, column)
+			}
+
+			if column := sf.UTF16Column(Pos(tt.offset)); column != tt.utf16Column {
+				t.Errorf("Expected UTF-16 column %d, got %d", tt.utf16Column, column)
+			}
+
+			line, _ := sf.GetLineAndColumn(Pos(tt.offset))
+			if offset := sf.OffsetFromUTF16(line, tt.utf16Column); offset != Pos(tt.offset) {
+				t.Errorf("Expected offset %d from the UTF-16 column, got %d", tt.offset, offset)
+			}
+		})
+	}
+
+	// Columns past the end of the line are clamped to the end of the line.
+	if offset := sf.OffsetFromUTF16(1, 100); offset != 9 {
+		t.Errorf("Expected the offset to be clamped to 9, got %d", offset)
+	}
+}
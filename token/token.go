@@ -734,6 +734,20 @@ func IsVarMutability(tt TokenType) bool {
 	return tt == CONSTANT || tt == IMMUTABLE || tt == TRANSIENT
 }
 
+// IsAssignment reports whether the token is the assignment operator or one of
+// the compound assignment operators e.g. `+=`.
+func IsAssignment(tt TokenType) bool {
+	return tt >= ASSIGN && tt <= ASSIGN_MOD
+}
+
+func IsFunctionVisibility(tt TokenType) bool {
+	return tt == PUBLIC || tt == PRIVATE || tt == INTERNAL || tt == EXTERNAL
+}
+
+func IsFunctionMutability(tt TokenType) bool {
+	return tt == PURE || tt == VIEW || tt == PAYABLE
+}
+
 func IsDataLocation(tt TokenType) bool {
 	return tt == MEMORY || tt == STORAGE || tt == CALLDATA
 }
@@ -0,0 +1,716 @@
+package types
+
+import (
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+// Info holds the results of the type checking.
+type Info struct {
+	// Types maps the expressions and the type names (ast.Type) to their
+	// types. Every checked expression is in the map; the expressions with
+	// errors have the Invalid type.
+	Types map[ast.Node]Type
+
+	// Defs maps the names in the declarations e.g. the name of a state
+	// variable, a function or a param, to the type of the declared entity.
+	Defs map[*ast.Identifier]Type
+
+	// Uses maps the identifiers to the declarations they refer to. The
+	// identifiers referring to the builtins e.g. `msg` are not in the map.
+	Uses map[*ast.Identifier]ast.Node
+}
+
+// NewInfo returns an Info with all the maps initialized.
+func NewInfo() *Info {
+	return &Info{
+		Types: make(map[ast.Node]Type),
+		Defs:  make(map[*ast.Identifier]Type),
+		Uses:  make(map[*ast.Identifier]ast.Node),
+	}
+}
+
+// TypeOf returns the type of the expression or type name; or nil if the
+// node was not checked.
+func (info *Info) TypeOf(node ast.Node) Type {
+	return info.Types[node]
+}
+
+// Check type checks the file and records the results in the info, which
+// can be nil. It returns an ErrorList if there are type errors. The checking
+// does not stop on errors, so the info is complete anyway.
+func Check(file *ast.File, info *Info) error {
+	if info == nil {
+		info = NewInfo()
+	}
+
+	c := &checker{
+		file:         file.SourceFile,
+		info:         info,
+		contracts:    make(map[*Contract]*scope),
+		unknownBases: make(map[*Contract]bool),
+	}
+	c.checkFile(file)
+
+	if len(c.errors) > 0 {
+		return c.errors
+	}
+
+	return nil
+}
+
+type checker struct {
+	file   *token.SourceFile
+	info   *Info
+	errors ErrorList
+
+	// The members of each contract. They are needed to check the member
+	// access on contract instances e.g. `token.transfer`.
+	contracts map[*Contract]*scope
+
+	// The context of the currently checked declaration.
+	contract *Contract // or nil outside of contracts
+	function *Function // or nil outside of functions and modifiers
+	modifier bool      // whether the function is a modifier
+	// whether the function is a constructor; it can call the constructors
+	// of the bases in its header
+	constructor bool
+
+	// whether the file has a plain import e.g. `import "./A.sol";`; the
+	// names it imports are not known, so the undeclared identifiers are not
+	// reported
+	plainImport bool
+	// the contracts with a base that is not known e.g. an imported one; the
+	// names they inherit are not known either
+	unknownBases map[*Contract]bool
+}
+
+/*~*~*~*~*~*~*~*~*~*~*~*~*~*~*~ Scopes ~*~*~*~*~*~*~*~*~*~*~*~*~*~*~*/
+
+// object is a named entity e.g. a contract, a function or a variable.
+type object struct {
+	name     string
+	typ      Type
+	decl     ast.Node // the declaration of the object
+	variable bool     // whether the object can be assigned to
+	constant bool     // whether the variable is constant
+}
+
+type scope struct {
+	parent  *scope
+	bases   []*scope // the scopes of the linearized bases; only for contract scopes
+	objects map[string][]*object
+}
+
+func newScope(parent *scope) *scope {
+	return &scope{parent: parent, objects: make(map[string][]*object)}
+}
+
+// lookup returns the objects with the name from the innermost scope that
+// declares it. A name can be declared many times in one scope e.g. the
+// overloaded functions.
+func (s *scope) lookup(name string) []*object {
+	for ; s != nil; s = s.parent {
+		if objs := s.lookupMember(name); len(objs) > 0 {
+			return objs
+		}
+	}
+	return nil
+}
+
+// lookupMember returns the objects with the name declared in the scope or
+// in the scopes of the inherited contracts. The bases are searched in the
+// order of the linearization, so the most derived declaration is found.
+func (s *scope) lookupMember(name string) []*object {
+	if objs := s.objects[name]; len(objs) > 0 {
+		return objs
+	}
+	for _, base := range s.bases {
+		if objs := base.objects[name]; len(objs) > 0 {
+			return objs
+		}
+	}
+	return nil
+}
+
+// overloads returns the functions or events with the name visible from the
+// scope. Unlike lookup, the declarations inherited from the bases are
+// included, since a contract can overload the functions of its bases. If the
+// name does not refer to functions or events, the result of lookup is
+// returned.
+func (s *scope) overloads(name string) []*object {
+	for ; s != nil; s = s.parent {
+		objs := s.lookupMember(name)
+		if len(objs) == 0 {
+			continue
+		}
+		if _, ok := signature(objs[0].typ); !ok {
+			return objs
+		}
+		return s.memberOverloads(name, nil)
+	}
+	return nil
+}
+
+// memberOverloads appends the functions or events with the name declared in
+// the scope and in the scopes of its bases to the objs. The declarations
+// with the same params as the already collected ones are overridden, so they
+// are skipped.
+func (s *scope) memberOverloads(name string, objs []*object) []*object {
+	objs = s.declaredOverloads(name, objs)
+	for _, base := range s.bases {
+		objs = base.declaredOverloads(name, objs)
+	}
+	return objs
+}
+
+// declaredOverloads is like memberOverloads, but it skips the bases.
+func (s *scope) declaredOverloads(name string, objs []*object) []*object {
+	for _, obj := range s.objects[name] {
+		params, ok := signature(obj.typ)
+		if !ok {
+			continue
+		}
+		overridden := false
+		for _, prev := range objs {
+			if prevParams, _ := signature(prev.typ); identicalList(params, prevParams) {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			objs = append(objs, obj)
+		}
+	}
+	return objs
+}
+
+// signature returns the params of the function or event type. It reports
+// false for the other types, which can't be overloaded.
+func signature(t Type) ([]Type, bool) {
+	switch t := t.(type) {
+	case *Function:
+		return t.Params, true
+	case *Event:
+		return t.Params, true
+	}
+	return nil, false
+}
+
+func (c *checker) declare(s *scope, ident *ast.Identifier, obj *object) {
+	if ident == nil {
+		return
+	}
+
+	obj.name = ident.Value
+	c.info.Defs[ident] = obj.typ
+
+	// Only functions and events can be overloaded, and only with the
+	// declarations of the same kind.
+	if prev := s.objects[obj.name]; len(prev) > 0 && !overloadable(prev[0].typ, obj.typ) {
+		c.errorf(ident.Pos, "identifier already declared: %s", obj.name)
+		return
+	}
+
+	params, _ := signature(obj.typ)
+	for _, prev := range s.objects[obj.name] {
+		if prevParams, _ := signature(prev.typ); identicalList(params, prevParams) {
+			c.errorf(ident.Pos, "%s with the same params already declared", obj.name)
+			return
+		}
+	}
+
+	s.objects[obj.name] = append(s.objects[obj.name], obj)
+}
+
+func overloadable(x, y Type) bool {
+	switch x.(type) {
+	case *Function:
+		_, ok := y.(*Function)
+		return ok
+	case *Event:
+		_, ok := y.(*Event)
+		return ok
+	}
+	return false
+}
+
+/*~*~*~*~*~*~*~*~*~*~*~*~*~*~ Declarations ~*~*~*~*~*~*~*~*~*~*~*~*~*/
+
+func (c *checker) checkFile(file *ast.File) {
+	fileScope := newScope(nil)
+
+	// The contracts, the imports and the user-defined types are declared
+	// first, since they can be referred to before their declaration e.g. in
+	// the types of the params.
+	var contracts []*Contract
+	contractOf := make(map[ast.Declaration]*Contract)
+	for _, decl := range file.Declarations {
+		if imp, ok := decl.(*ast.ImportDirective); ok {
+			c.collectImport(fileScope, imp)
+			continue
+		}
+		if c.collectType(fileScope, decl) {
+			continue
+		}
+
+		base, kind, ok := contractBase(decl)
+		if !ok || base.Name == nil {
+			continue
+		}
+		contract := &Contract{Name: base.Name.Value, Kind: kind, Decl: decl}
+		c.declare(fileScope, base.Name, &object{typ: &TypeType{Type: contract}, decl: decl})
+
+		contracts = append(contracts, contract)
+		contractOf[decl] = contract
+		c.contracts[contract] = newScope(fileScope)
+	}
+
+	for _, decl := range file.Declarations {
+		c.collectDeclaration(fileScope, decl)
+	}
+
+	// The bases are linked after all the contracts are known, and the
+	// members are collected after all the bases are linked, since the
+	// overloads of the inherited functions depend on them.
+	parents := make(map[*Contract][]*Contract)
+	for _, contract := range contracts {
+		parents[contract] = c.parents(fileScope, contract)
+	}
+	for _, contract := range contracts {
+		c.linearize(contract, parents)
+	}
+	for _, contract := range contracts {
+		if base, _, _ := contractBase(contract.Decl); base.Body != nil {
+			for _, member := range base.Body.Declarations {
+				c.collectType(c.contracts[contract], member)
+			}
+		}
+	}
+	for _, contract := range contracts {
+		c.collectContract(contract)
+	}
+
+	for _, decl := range file.Declarations {
+		if contract, ok := contractOf[decl]; ok {
+			c.checkContract(contract)
+		} else {
+			c.checkDeclaration(fileScope, decl)
+		}
+	}
+}
+
+// collectImport declares the names imported by the directive. The imported
+// files are not checked, so the types of the imported names are invalid and
+// their uses are not checked either.
+func (c *checker) collectImport(s *scope, imp *ast.ImportDirective) {
+	switch {
+	case imp.UnitAlias != nil:
+		c.declare(s, imp.UnitAlias, &object{typ: Typ[Invalid], decl: imp})
+	case len(imp.Symbols) > 0:
+		for _, sym := range imp.Symbols {
+			if sym.Name == nil {
+				continue
+			}
+			c.declare(s, sym.LocalName(), &object{typ: Typ[Invalid], decl: sym})
+		}
+	default:
+		c.plainImport = true
+	}
+}
+
+// undeclared reports the use of an undeclared identifier, unless the
+// identifier can come from a plain import or from an unknown base of the
+// current contract.
+func (c *checker) undeclared(ident *ast.Identifier) {
+	if c.plainImport || (c.contract != nil && c.hasUnknownBases(c.contract)) {
+		return
+	}
+	c.errorf(ident.Pos, "undeclared identifier: %s", ident.Value)
+}
+
+// hasUnknownBases reports whether the contract or any of its bases inherits
+// from a contract that is not known.
+func (c *checker) hasUnknownBases(contract *Contract) bool {
+	if c.unknownBases[contract] {
+		return true
+	}
+	for _, base := range contract.Bases {
+		if c.unknownBases[base] {
+			return true
+		}
+	}
+	return false
+}
+
+// contractBase returns the common part of the contract-like declarations.
+// TODO: Interfaces and libraries are not declarations in the AST yet.
+func contractBase(decl ast.Declaration) (*ast.ContractBase, ContractKind, bool) {
+	switch d := decl.(type) {
+	case *ast.ContractDeclaration:
+		return &d.ContractBase, KindContract, true
+	}
+	return nil, 0, false
+}
+
+// parents returns the direct bases of the contract in the order of the `is`
+// list. The unknown bases are reported and skipped.
+func (c *checker) parents(s *scope, contract *Contract) []*Contract {
+	var idents []*ast.Identifier
+	switch d := contract.Decl.(type) {
+	case *ast.ContractDeclaration:
+		idents = d.Parents
+	}
+
+	var parents []*Contract
+	for _, ident := range idents {
+		if ident == nil {
+			continue
+		}
+		switch t := c.typeNamed(s, ident).(type) {
+		case *Contract:
+			parents = append(parents, t)
+		case *Basic:
+			// The error was reported or the base is imported.
+			c.unknownBases[contract] = true
+		default:
+			c.errorf(ident.Pos, "%s is not a contract", ident.Value)
+		}
+	}
+	return parents
+}
+
+// linearize sets the bases of the contract to its C3 linearization. If the
+// linearization is impossible, the contract has no bases.
+func (c *checker) linearize(contract *Contract, parents map[*Contract][]*Contract) {
+	mro, err := Linearize(contract, func(t *Contract) []*Contract { return parents[t] })
+	if err != nil {
+		base, _, _ := contractBase(contract.Decl)
+		c.errorf(base.Name.Pos, "cannot linearize the bases of %s: %s", contract.Name, err)
+		return
+	}
+
+	contract.Bases = mro[1:]
+
+	s := c.contracts[contract]
+	for _, base := range contract.Bases {
+		s.bases = append(s.bases, c.contracts[base])
+	}
+}
+
+func (c *checker) collectContract(contract *Contract) {
+	contractBase, _, _ := contractBase(contract.Decl)
+	if contractBase.Body == nil {
+		return
+	}
+
+	s := c.contracts[contract]
+	for _, member := range contractBase.Body.Declarations {
+		c.collectDeclaration(s, member)
+	}
+}
+
+// collectType declares the struct or the enum in the scope and reports
+// whether the declaration is one of them. The fields of the structs are
+// resolved by collectDeclaration, since they can refer to the types
+// declared later.
+func (c *checker) collectType(s *scope, decl ast.Declaration) bool {
+	switch d := decl.(type) {
+	case *ast.StructDeclaration:
+		if d.Name != nil {
+			c.declare(s, d.Name, &object{typ: &TypeType{Type: &Struct{Name: d.Name.Value}}, decl: d})
+		}
+		return true
+
+	case *ast.EnumDeclaration:
+		if d.Name == nil {
+			return true
+		}
+		enum := &Enum{Name: d.Name.Value}
+		for _, member := range d.Members {
+			enum.Members = append(enum.Members, member.Value)
+			c.info.Defs[member] = enum
+		}
+		c.declare(s, d.Name, &object{typ: &TypeType{Type: enum}, decl: d})
+		return true
+	}
+	return false
+}
+
+// collectDeclaration declares the named entities of the declaration in the
+// scope. The bodies are checked later, when all the names are known.
+func (c *checker) collectDeclaration(s *scope, decl ast.Declaration) {
+	switch d := decl.(type) {
+	case *ast.FunctionDeclaration:
+		if d.Name == nil {
+			return
+		}
+		c.declare(s, d.Name, &object{typ: c.functionType(s, d), decl: d})
+
+	case *ast.ModifierDeclaration:
+		if d.Name == nil {
+			return
+		}
+		c.declare(s, d.Name, &object{typ: &Modifier{Name: d.Name.Value, Params: c.paramTypes(s, d.Params)}, decl: d})
+
+	case *ast.StateVariableDeclaration:
+		t := c.typeOf(s, d.Type)
+		c.declare(s, d.Name, &object{typ: t, decl: d, variable: true, constant: d.Mutability == ast.Constant})
+
+	case *ast.ConstantVariableDeclaration:
+		t := c.typeOf(s, d.Type)
+		c.declare(s, d.Name, &object{typ: t, decl: d, variable: true, constant: true})
+
+	case *ast.EventDeclaration:
+		if d.Name == nil {
+			return
+		}
+		event := &Event{Name: d.Name.Value}
+		if d.Params != nil {
+			for _, param := range d.Params.List {
+				event.Params = append(event.Params, c.typeOf(s, param.Type))
+				if param.Name != nil {
+					c.info.Defs[param.Name] = event.Params[len(event.Params)-1]
+				}
+			}
+		}
+		c.declare(s, d.Name, &object{typ: event, decl: d})
+
+	case *ast.ErrorDeclaration:
+		if d.Name == nil {
+			return
+		}
+		c.declare(s, d.Name, &object{typ: &CustomError{Name: d.Name.Value, Params: c.paramTypes(s, d.Params)}, decl: d})
+
+	case *ast.StructDeclaration:
+		tt, ok := c.info.Defs[d.Name].(*TypeType)
+		if !ok {
+			return
+		}
+		st := tt.Type.(*Struct)
+		for _, member := range d.Members {
+			t := c.typeOf(s, member.Type)
+			if t == Type(st) {
+				c.errorf(member.Start(), "recursive struct definition: %s", st.Name)
+			}
+			if member.Name != nil {
+				c.info.Defs[member.Name] = t
+				st.Fields = append(st.Fields, &Field{Name: member.Name.Value, Type: t})
+			}
+		}
+	}
+}
+
+func (c *checker) functionType(s *scope, d *ast.FunctionDeclaration) *Function {
+	fn := &Function{
+		Params:     c.paramTypes(s, d.Params),
+		Results:    c.paramTypes(s, d.Results),
+		Visibility: d.Visibility,
+		Mutability: d.Mutability,
+	}
+	if d.Name != nil {
+		fn.Name = d.Name.Value
+	}
+	return fn
+}
+
+func (c *checker) paramTypes(s *scope, params *ast.ParamList) []Type {
+	if params == nil {
+		return nil
+	}
+
+	types := make([]Type, 0, len(params.List))
+	for _, param := range params.List {
+		types = append(types, c.typeOf(s, param.Type))
+	}
+	return types
+}
+
+func (c *checker) checkContract(contract *Contract) {
+	c.contract = contract
+	defer func() { c.contract = nil }()
+
+	contractBase, _, _ := contractBase(contract.Decl)
+	if contractBase.Body == nil {
+		return
+	}
+
+	s := c.contracts[contract]
+	for _, member := range contractBase.Body.Declarations {
+		c.checkDeclaration(s, member)
+	}
+}
+
+func (c *checker) checkDeclaration(s *scope, decl ast.Declaration) {
+	switch d := decl.(type) {
+	case *ast.FunctionDeclaration:
+		c.checkFunction(s, d.Params, d.Results, d.Modifiers, d.Body, c.info.Defs[d.Name])
+
+	case *ast.ModifierDeclaration:
+		c.modifier = true
+		defer func() { c.modifier = false }()
+		c.checkFunction(s, d.Params, nil, nil, d.Body, &Function{})
+
+	case *ast.ConstructorDeclaration:
+		c.constructor = true
+		defer func() { c.constructor = false }()
+		c.checkFunction(s, d.Params, nil, d.Modifiers, d.Body, &Function{Mutability: d.Mutability})
+
+	case *ast.SpecialFunctionDeclaration:
+		// The receive and fallback functions can't be referred to by name,
+		// so their types are only needed to check the bodies.
+		fn := &Function{
+			Name:       d.Kind.Literal,
+			Params:     c.paramTypes(s, d.Params),
+			Results:    c.paramTypes(s, d.Results),
+			Visibility: d.Visibility,
+			Mutability: d.Mutability,
+		}
+		c.checkFunction(s, d.Params, d.Results, d.Modifiers, d.Body, fn)
+
+	case *ast.StateVariableDeclaration:
+		if d.Value == nil {
+			return
+		}
+		t := c.expr(s, d.Value)
+		c.assignment(d.Value, t, c.info.Types[d.Type], "variable declaration")
+
+	case *ast.ConstantVariableDeclaration:
+		if d.Value == nil {
+			return
+		}
+		t := c.expr(s, d.Value)
+		c.assignment(d.Value, t, c.info.Types[d.Type], "variable declaration")
+
+	case *ast.UsingForDirective:
+		if d.ForType != nil {
+			c.typeOf(s, d.ForType)
+		}
+	}
+}
+
+func (c *checker) checkFunction(s *scope, params, results *ast.ParamList,
+	modifiers []*ast.ModifierInvocation, body *ast.BlockStatement, t Type) {
+	fn, _ := t.(*Function)
+	c.function = fn
+	defer func() { c.function = nil }()
+
+	fnScope := newScope(s)
+	for _, list := range []*ast.ParamList{params, results} {
+		if list == nil {
+			continue
+		}
+		for _, param := range list.List {
+			if param.Name != nil {
+				c.declare(fnScope, param.Name, &object{typ: c.info.Types[param.Type], decl: param, variable: true})
+			}
+		}
+	}
+
+	// The arguments of the modifiers can refer to the params.
+	for _, modifier := range modifiers {
+		c.modifierInvocation(fnScope, modifier)
+	}
+
+	if body != nil {
+		// The params are in the same scope as the top level statements of
+		// the body, so they can't be redeclared there.
+		c.statements(fnScope, body.Statements)
+	}
+}
+
+// modifierInvocation checks the modifier invoked in the function header
+// against the modifier's params.
+func (c *checker) modifierInvocation(s *scope, n *ast.ModifierInvocation) {
+	args := c.args(s, n.Args)
+	if n.Name == nil {
+		return
+	}
+
+	objs := s.lookup(n.Name.Value)
+	if len(objs) == 0 {
+		c.undeclared(n.Name)
+		return
+	}
+	c.info.Uses[n.Name] = objs[0].decl
+	if IsInvalid(objs[0].typ) {
+		// e.g. an imported modifier
+		return
+	}
+
+	// The arguments of the base constructors are not checked, since the
+	// params of the constructors are not known.
+	if tt, ok := objs[0].typ.(*TypeType); ok && c.constructor {
+		if _, ok := tt.Type.(*Contract); ok {
+			c.record(n.Name, tt)
+			return
+		}
+	}
+
+	modifier, ok := objs[0].typ.(*Modifier)
+	if !ok {
+		c.errorf(n.Name.Pos, "%s is not a modifier", n.Name.Value)
+		return
+	}
+	c.record(n.Name, modifier)
+
+	if len(args) != len(modifier.Params) {
+		c.errorf(n.Start(), "wrong number of arguments in the invocation of %s: expected %d, got %d",
+			n.Name.Value, len(modifier.Params), len(args))
+		return
+	}
+	for i, param := range modifier.Params {
+		if !AssignableTo(args[i], param) {
+			c.errorf(n.Args[i].Start(), "cannot use %s as %s in argument %d", args[i], param, i+1)
+		}
+	}
+}
+
+/*~*~*~*~*~*~*~*~*~*~*~*~*~*~*~ Types *~*~*~*~*~*~*~*~*~*~*~*~*~*~*~*/
+
+// typeOf returns the type denoted by the type name and records it.
+func (c *checker) typeOf(s *scope, typ ast.Type) Type {
+	if typ == nil {
+		return Typ[Invalid]
+	}
+
+	var t Type = Typ[Invalid]
+	switch n := typ.(type) {
+	case *ast.ElementaryType:
+		if n.Payable != 0 {
+			t = Typ[AddressPayable]
+		} else if et := ElementaryType(n.Kind.Type); et != nil {
+			t = et
+		} else {
+			c.errorf(n.Pos, "unsupported type: %s", n.Kind.Literal)
+		}
+	case *ast.UserDefinedType:
+		t = c.typeNamed(s, n.Name)
+	}
+
+	c.info.Types[typ] = t
+	return t
+}
+
+// typeNamed returns the type named by the identifier e.g. a contract.
+func (c *checker) typeNamed(s *scope, ident *ast.Identifier) Type {
+	objs := s.lookup(ident.Value)
+	if len(objs) == 0 {
+		c.undeclared(ident)
+		return Typ[Invalid]
+	}
+
+	c.info.Uses[ident] = objs[0].decl
+	if IsInvalid(objs[0].typ) {
+		// e.g. an imported type
+		return Typ[Invalid]
+	}
+
+	tt, ok := objs[0].typ.(*TypeType)
+	if !ok {
+		c.errorf(ident.Pos, "%s is not a type", ident.Value)
+		return Typ[Invalid]
+	}
+
+	return tt.Type
+}
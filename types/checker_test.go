@@ -0,0 +1,632 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/parser"
+	"github.com/ChmielewskiKamil/solbot/types"
+)
+
+func Test_Check_ExpressionTypes(t *testing.T) {
+	src := `contract C {
+    uint8 small;
+    address payable owner;
+    event Paid(address indexed from, uint256 amount);
+
+    function f() {
+        uint256 value = msg.value;
+        address sender = msg.sender;
+        address payable recipient = payable(sender);
+        bool ok = value > 1 && recipient == owner;
+        uint256 sum = small + value;
+        bytes32 hash = keccak256(msg.data);
+        emit Paid(sender, value);
+    }
+}`
+
+	_, info := test_helper_check(t, src, "")
+
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{"(msg.value)", "uint256"},
+		{"(msg.sender)", "address"},
+		{"payable(sender)", "address payable"},
+		{"(value > 1)", "bool"},
+		{"((value > 1) && (recipient == owner))", "bool"},
+		{"(small + value)", "uint256"},
+		{"keccak256((msg.data))", "bytes32"},
+		{"1", "int_const 1"},
+	}
+
+	exprTypes := test_helper_exprTypes(info)
+	for _, tt := range tests {
+		got, ok := exprTypes[tt.expr]
+		if !ok {
+			t.Errorf("The type of %s was not recorded.", tt.expr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("Wrong type of %s. Expected: %s, got: %s", tt.expr, tt.expected, got)
+		}
+	}
+}
+
+func Test_Check_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{
+			"transfer on address",
+			`contract C { function f() { address a = msg.sender; a.transfer(1); } }`,
+			"transfer is only available on address payable, not on address",
+		},
+		{
+			"implicit address to address payable",
+			`contract C { function f() { address payable a = msg.sender; } }`,
+			"cannot use address as address payable in variable declaration",
+		},
+		{
+			"literal out of range",
+			`contract C { uint8 x = 256; }`,
+			"cannot use int_const 256 as uint8 in variable declaration",
+		},
+		{
+			"narrowing conversion",
+			`contract C { uint256 a; function f() { uint8 b = a; } }`,
+			"cannot use uint256 as uint8 in variable declaration",
+		},
+		{
+			"undeclared identifier",
+			`contract C { function f() { x = 1; } }`,
+			"undeclared identifier: x",
+		},
+		{
+			"wrong number of emit args",
+			`contract C { event E(uint256 a); function f() { emit E(1, 2); } }`,
+			"wrong number of arguments in the call to E: expected 1, got 2",
+		},
+		{
+			"wrong type of emit arg",
+			`contract C { event E(uint256 a); function f() { emit E(true); } }`,
+			"cannot use bool as uint256 in argument 1",
+		},
+		{
+			"non bool condition",
+			`contract C { function f() { if (1) { } } }`,
+			"expected a bool condition, got: int_const 1",
+		},
+		{
+			"assignment to constant",
+			`contract C { uint256 constant X = 1; function f() { X = 2; } }`,
+			"cannot assign to a constant variable: X",
+		},
+		{
+			"duplicate declaration",
+			`contract C { uint256 a; uint256 a; }`,
+			"identifier already declared: a",
+		},
+		{
+			"wrong type of modifier arg",
+			`contract C { modifier m(uint256 a) { _; } function f() m(true) { } }`,
+			"cannot use bool as uint256 in argument 1",
+		},
+		{
+			"invoking a non-modifier",
+			`contract C { uint256 a; function f() a { } }`,
+			"a is not a modifier",
+		},
+		{
+			"wrong tuple component type",
+			`contract C { function g() returns (uint256, bool) { } function f() { uint256 a; bool b; (b, a) = g(); } }`,
+			"cannot use tuple(uint256, bool) as tuple(bool, uint256) in assignment",
+		},
+		{
+			"assignment to a tuple of literals",
+			`contract C { function g() returns (uint256, bool) { } function f() { (1, true) = g(); } }`,
+			"expression is not assignable: 1",
+		},
+		{
+			"wrong fallback result type",
+			`contract C { fallback(bytes calldata input) external returns (bytes memory) { return true; } }`,
+			"cannot use bool as bytes in return",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test_helper_check(t, tt.src, tt.expected)
+		})
+	}
+}
+
+func Test_Check_Overloads(t *testing.T) {
+	src := `contract Base {
+    function pay(address to) public { }
+}
+
+contract C is Base {
+    event Paid(address to);
+    event Paid(address to, uint256 amount);
+
+    function pay(address to, uint256 amount) public { }
+
+    function f() {
+        pay(msg.sender);
+        pay(msg.sender, 1);
+        emit Paid(msg.sender, msg.value);
+    }
+}`
+
+	file, info := test_helper_check(t, src, "")
+
+	base := file.Declarations[0].(*ast.ContractDeclaration)
+	contract := file.Declarations[1].(*ast.ContractDeclaration)
+	fn := contract.Body.Declarations[3].(*ast.FunctionDeclaration)
+
+	tests := []struct {
+		stmt     int
+		expected ast.Node
+	}{
+		{0, base.Body.Declarations[0]},
+		{1, contract.Body.Declarations[2]},
+		{2, contract.Body.Declarations[1]},
+	}
+
+	for _, tt := range tests {
+		var call *ast.CallExpression
+		switch stmt := fn.Body.Statements[tt.stmt].(type) {
+		case *ast.ExpressionStatement:
+			call = stmt.Expression.(*ast.CallExpression)
+		case *ast.EmitStatement:
+			call = stmt.Expression.(*ast.CallExpression)
+		}
+
+		ident := call.Ident.(*ast.Identifier)
+		if got := info.Uses[ident]; got != tt.expected {
+			t.Errorf("Statement %d: %s resolved to the wrong declaration: %v", tt.stmt, call, got)
+		}
+	}
+}
+
+func Test_Check_OverloadErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{
+			"ambiguous",
+			`contract C { function f(uint8 a) { } function f(uint16 a) { } function g() { f(1); } }`,
+			"ambiguous call to overloaded f with the arguments (int_const 1)",
+		},
+		{
+			"no match",
+			`contract C { function f(uint8 a) { } function f(address a) { } function g() { f(true); } }`,
+			"no overload of f matches the arguments (bool)",
+		},
+		{
+			"same params",
+			`contract C { event E(uint256 a); event E(uint256 b); }`,
+			"E with the same params already declared",
+		},
+		{
+			"function and event",
+			`contract C { event f(uint256 a); function f(address a) { } }`,
+			"identifier already declared: f",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test_helper_check(t, tt.src, tt.expected)
+		})
+	}
+}
+
+func Test_Check_Inheritance(t *testing.T) {
+	src := `contract Ownable {
+    address owner;
+    function check() { }
+}
+
+contract Pausable {
+    bool paused;
+    function check() { }
+}
+
+contract Vault is Ownable, Pausable {
+    function check() {
+        super.check();
+        paused = owner == msg.sender;
+    }
+}`
+
+	file, info := test_helper_check(t, src, "")
+
+	ownable := file.Declarations[0].(*ast.ContractDeclaration)
+	pausable := file.Declarations[1].(*ast.ContractDeclaration)
+	vault := file.Declarations[2].(*ast.ContractDeclaration)
+
+	vaultType := info.Defs[vault.Name].(*types.TypeType).Type.(*types.Contract)
+	var bases []string
+	for _, base := range vaultType.Bases {
+		bases = append(bases, base.Name)
+	}
+	if strings.Join(bases, " ") != "Pausable Ownable" {
+		t.Errorf("Expected the bases Pausable Ownable, got %v", bases)
+	}
+
+	body := vault.Body.Declarations[0].(*ast.FunctionDeclaration).Body
+
+	// super.check() refers to the next contract in the linearization.
+	superCall := body.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.CallExpression)
+	member := superCall.Ident.(*ast.MemberAccessExpression).Member
+	if got := info.Uses[member]; got != pausable.Body.Declarations[1] {
+		t.Errorf("Expected super.check to refer to Pausable.check, got %v", got)
+	}
+
+	assignment := body.Statements[1].(*ast.ExpressionStatement).Expression.(*ast.InfixExpression)
+	if got := info.Uses[assignment.Left.(*ast.Identifier)]; got != pausable.Body.Declarations[0] {
+		t.Errorf("Expected paused to refer to Pausable.paused, got %v", got)
+	}
+	owner := assignment.Right.(*ast.InfixExpression).Left.(*ast.Identifier)
+	if got := info.Uses[owner]; got != ownable.Body.Declarations[0] {
+		t.Errorf("Expected owner to refer to Ownable.owner, got %v", got)
+	}
+}
+
+func Test_Check_InheritanceErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{
+			"cycle",
+			`contract A is B { } contract B is A { }`,
+			"cannot linearize the bases of A: cyclic inheritance",
+		},
+		{
+			"inconsistent order",
+			`contract X { } contract A is X { } contract C is A, X { }`,
+			"cannot linearize the bases of C: inconsistent order of the bases",
+		},
+		{
+			"unknown base",
+			`contract C { uint256 x; } contract D is x { }`,
+			"undeclared identifier: x",
+		},
+		{
+			"super outside of the bases",
+			`contract C { function f() { super.f(); } }`,
+			"member f not found in type(contract super C)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test_helper_check(t, tt.src, tt.expected)
+		})
+	}
+}
+
+func Test_Check_Modifier(t *testing.T) {
+	src := `contract C {
+    address owner;
+
+    modifier onlyOwner() {
+        require(msg.sender == owner, "not owner");
+        _;
+    }
+
+    modifier atLeast(uint256 amount) {
+        require(msg.value >= amount, "not enough");
+        _;
+    }
+
+    function deposit(uint256 minimum) public payable onlyOwner atLeast(minimum) { }
+}`
+
+	file, info := test_helper_check(t, src, "")
+
+	contract := file.Declarations[0].(*ast.ContractDeclaration)
+	fn := contract.Body.Declarations[3].(*ast.FunctionDeclaration)
+	for i, modifier := range fn.Modifiers {
+		if got := info.Uses[modifier.Name]; got != contract.Body.Declarations[i+1] {
+			t.Errorf("Modifier %s resolved to the wrong declaration: %v", modifier.Name, got)
+		}
+	}
+}
+
+func Test_Check_ConstructorAndAssembly(t *testing.T) {
+	src := `contract Ownable {
+    address owner;
+}
+
+contract C is Ownable {
+    uint256 total;
+
+    modifier positive(uint256 amount) {
+        require(amount > 0, "zero");
+        _;
+    }
+
+    constructor(uint256 initial) Ownable() positive(initial) payable {
+        total = initial;
+        assembly {
+            let slot := total.slot
+            sstore(slot, initial)
+        }
+    }
+}`
+
+	file, info := test_helper_check(t, src, "")
+
+	base := file.Declarations[0].(*ast.ContractDeclaration)
+	contract := file.Declarations[1].(*ast.ContractDeclaration)
+	constructor := contract.Body.Declarations[2].(*ast.ConstructorDeclaration)
+
+	if got := info.Uses[constructor.Modifiers[0].Name]; got != base {
+		t.Errorf("Base constructor call resolved to the wrong declaration: %v", got)
+	}
+	if got := info.Uses[constructor.Modifiers[1].Name]; got != contract.Body.Declarations[1] {
+		t.Errorf("Modifier resolved to the wrong declaration: %v", got)
+	}
+
+	// The Yul variables and builtins are skipped.
+	assembly := constructor.Body.Statements[1].(*ast.AssemblyStatement)
+	var resolved []string
+	for _, ident := range assembly.Identifiers {
+		if info.Uses[ident] != nil {
+			resolved = append(resolved, ident.Value)
+		}
+	}
+	if strings.Join(resolved, ", ") != "total, initial" {
+		t.Errorf("Expected the assembly identifiers total, initial to be resolved, got: %v", resolved)
+	}
+}
+
+func Test_Check_SpecialFunctions(t *testing.T) {
+	src := `contract C {
+    uint256 total;
+
+    receive() external payable {
+        total += msg.value;
+    }
+
+    fallback(bytes calldata input) external returns (bytes memory) {
+        return input;
+    }
+}`
+
+	file, info := test_helper_check(t, src, "")
+
+	contract := file.Declarations[0].(*ast.ContractDeclaration)
+	receive := contract.Body.Declarations[1].(*ast.SpecialFunctionDeclaration)
+	fallback := contract.Body.Declarations[2].(*ast.SpecialFunctionDeclaration)
+
+	assignment := receive.Body.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.InfixExpression)
+	if got := info.Uses[assignment.Left.(*ast.Identifier)]; got != contract.Body.Declarations[0] {
+		t.Errorf("State variable resolved to the wrong declaration: %v", got)
+	}
+
+	ret := fallback.Body.Statements[0].(*ast.ReturnStatement)
+	if got := info.Uses[ret.Result.(*ast.Identifier)]; got != fallback.Params.List[0] {
+		t.Errorf("Param resolved to the wrong declaration: %v", got)
+	}
+}
+
+func Test_Check_CallOptions(t *testing.T) {
+	src := `contract C {
+    function f(address payable to, uint256 amount) public {
+        (bool ok, ) = to.call{value: amount}("");
+    }
+}`
+
+	file, info := test_helper_check(t, src, "")
+
+	fn := file.Declarations[0].(*ast.ContractDeclaration).Body.Declarations[0].(*ast.FunctionDeclaration)
+	decl := fn.Body.Statements[0].(*ast.VariableDeclarationTupleStatement)
+	options := decl.Value.(*ast.CallExpression).Ident.(*ast.CallOptionsExpression)
+
+	if got := info.Uses[options.Values[0].(*ast.Identifier)]; got != fn.Params.List[1] {
+		t.Errorf("Option value resolved to the wrong declaration: %v", got)
+	}
+	if got, expected := info.Types[options], info.Types[options.Expression]; got != expected {
+		t.Errorf("Expected the type of the options to be %s, got %s", expected, got)
+	}
+}
+
+func Test_Check_Imports(t *testing.T) {
+	src := `import {Ownable, Math as M} from "./Ownable.sol";
+import * as Lib from "./Lib.sol";
+
+contract C is Ownable {
+    function f(uint256 x) returns (uint256) {
+        _checkOwner();
+        return M.max(x, Lib.ONE);
+    }
+}`
+
+	file, info := test_helper_check(t, src, "")
+
+	imp := file.Declarations[0].(*ast.ImportDirective)
+	wildcard := file.Declarations[1].(*ast.ImportDirective)
+	contract := file.Declarations[2].(*ast.ContractDeclaration)
+
+	if got := info.Uses[contract.Parents[0]]; got != imp.Symbols[0] {
+		t.Errorf("Base resolved to the wrong declaration: %v", got)
+	}
+
+	var uses []ast.Node
+	for ident, decl := range info.Uses {
+		if ident.Value == "M" || ident.Value == "Lib" {
+			uses = append(uses, decl)
+		}
+	}
+	if len(uses) != 2 {
+		t.Fatalf("Expected 2 uses of the aliases, got: %d", len(uses))
+	}
+	for _, decl := range uses {
+		if decl != imp.Symbols[1] && decl != wildcard {
+			t.Errorf("Alias resolved to the wrong declaration: %v", decl)
+		}
+	}
+}
+
+func Test_Check_ImportErrors(t *testing.T) {
+	// The names of a plain import are not known, so the undeclared
+	// identifiers are not reported.
+	test_helper_check(t, `import "./A.sol"; contract C is A { function f() { g(); } }`, "")
+
+	// The members of the contracts with imported bases are not known either.
+	test_helper_check(t, `import {A} from "./A.sol";
+contract C is A { function f() { g(); this.h(); } }`, "")
+
+	test_helper_check(t, `import {A} from "./A.sol";
+contract C { function f() { g(); } }`, "undeclared identifier: g")
+}
+
+func Test_Check_UserDefinedTypes(t *testing.T) {
+	src := `struct Position { uint256 amount; Status status; }
+
+contract C {
+    error Unauthorized(address caller);
+
+    Position position;
+
+    function f(address caller) returns (uint256) {
+        Position storage current = position;
+        current.status = Status.Closed;
+        if (caller != msg.sender) revert Unauthorized(caller);
+        return current.amount;
+    }
+}
+
+enum Status { Open, Closed }`
+
+	_, info := test_helper_check(t, src, "")
+
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{"(current.status)", "enum Status"},
+		{"(Status.Closed)", "enum Status"},
+		{"(current.amount)", "uint256"},
+		{"Unauthorized(caller)", "tuple()"},
+		{"Unauthorized", "error Unauthorized(address)"},
+	}
+
+	exprTypes := test_helper_exprTypes(info)
+	for _, tt := range tests {
+		got, ok := exprTypes[tt.expr]
+		if !ok {
+			t.Errorf("The type of %s was not recorded.", tt.expr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("Wrong type of %s. Expected: %s, got: %s", tt.expr, tt.expected, got)
+		}
+	}
+}
+
+func Test_Check_UserDefinedTypeErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{
+			"wrong error argument",
+			`contract C { error E(uint256 x); function f() { revert E(true); } }`,
+			"cannot use bool as uint256 in argument 1",
+		},
+		{
+			"revert with an event",
+			`contract C { event E(); function f() { revert E(); } }`,
+			"expected an error, got: event E()",
+		},
+		{
+			"unknown enum member",
+			`contract C { enum E { A } function f() { E x = E.B; } }`,
+			"member B not found",
+		},
+		{
+			"recursive struct",
+			`struct S { S s; }`,
+			"recursive struct definition: S",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test_helper_check(t, tt.src, tt.expected)
+		})
+	}
+}
+
+func Test_Check_TupleAssignment(t *testing.T) {
+	src := `contract C {
+    uint256 total;
+
+    function values() returns (uint256, bool, address) { }
+
+    function f() {
+        bool ok;
+        address to;
+        (total, ok, ) = values();
+        (, , to) = values();
+    }
+}`
+
+	_, info := test_helper_check(t, src, "")
+
+	exprTypes := test_helper_exprTypes(info)
+	if got := exprTypes["(total, ok, )"]; got != "tuple(uint256, bool, )" {
+		t.Errorf("Wrong type of the tuple. Expected: tuple(uint256, bool, ), got: %s", got)
+	}
+}
+
+// test_helper_check parses and checks the source. If the expected error is
+// empty, the check must succeed; otherwise it must report the error.
+func test_helper_check(t *testing.T, src, expectedErr string) (*ast.File, *types.Info) {
+	t.Helper()
+
+	file, err := parser.ParseFile("test.sol", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parser errors: %s", err)
+	}
+
+	info := types.NewInfo()
+	err = types.Check(file, info)
+
+	switch {
+	case expectedErr == "" && err != nil:
+		t.Fatalf("Unexpected type errors: %s", err)
+	case expectedErr != "" && err == nil:
+		t.Fatalf("Expected the error %q, got none.", expectedErr)
+	case expectedErr != "" && !strings.Contains(err.Error(), expectedErr):
+		t.Fatalf("Expected the error %q, got: %s", expectedErr, err)
+	}
+
+	return file, info
+}
+
+// test_helper_exprTypes returns the types of the checked expressions keyed
+// by the string representation of the expressions.
+func test_helper_exprTypes(info *types.Info) map[string]string {
+	exprTypes := make(map[string]string)
+	for node, t := range info.Types {
+		if expr, ok := node.(ast.Expression); ok {
+			exprTypes[expr.String()] = t.String()
+		}
+	}
+	return exprTypes
+}
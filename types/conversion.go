@@ -0,0 +1,266 @@
+package types
+
+import (
+	"math/big"
+	"unicode/utf8"
+)
+
+// Identical reports whether x and y are the same type.
+func Identical(x, y Type) bool {
+	if x == y {
+		return true
+	}
+
+	switch x := x.(type) {
+	case *Basic:
+		y, ok := y.(*Basic)
+		return ok && x.kind == y.kind
+	case *Int:
+		y, ok := y.(*Int)
+		return ok && x.Bits == y.Bits && x.Signed == y.Signed
+	case *FixedBytes:
+		y, ok := y.(*FixedBytes)
+		return ok && x.Size == y.Size
+	case *IntLiteral:
+		y, ok := y.(*IntLiteral)
+		return ok && x.Value.Cmp(y.Value) == 0
+	case *StringLiteral:
+		y, ok := y.(*StringLiteral)
+		return ok && x.Value == y.Value
+	case *Array:
+		y, ok := y.(*Array)
+		return ok && x.Len == y.Len && Identical(x.Elem, y.Elem)
+	case *Mapping:
+		y, ok := y.(*Mapping)
+		return ok && Identical(x.Key, y.Key) && Identical(x.Value, y.Value)
+	case *Struct, *Enum, *Contract, *Super:
+		// User-defined types are identical only if they come from the same
+		// declaration; the checker creates one value per declaration.
+		return false
+	case *Function:
+		y, ok := y.(*Function)
+		return ok && identicalList(x.Params, y.Params) && identicalList(x.Results, y.Results) &&
+			x.Visibility == y.Visibility && x.Mutability == y.Mutability
+	case *Tuple:
+		y, ok := y.(*Tuple)
+		return ok && identicalList(x.Types, y.Types)
+	case *TypeType:
+		y, ok := y.(*TypeType)
+		return ok && Identical(x.Type, y.Type)
+	case *Magic:
+		y, ok := y.(*Magic)
+		return ok && x.Kind == y.Kind
+	}
+
+	return false
+}
+
+func identicalList(x, y []Type) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if !Identical(x[i], y[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsInvalid reports whether the type is nil or the Invalid type. The
+// checker gives the Invalid type to the expressions with errors, so that
+// the errors are not reported again for the enclosing expressions.
+func IsInvalid(t Type) bool {
+	b, ok := t.(*Basic)
+	return t == nil || ok && b.kind == Invalid
+}
+
+// IsInteger reports whether the type is an integer type or an int literal.
+func IsInteger(t Type) bool {
+	switch t.(type) {
+	case *Int, *IntLiteral:
+		return true
+	}
+	return false
+}
+
+// IsAddress reports whether the type is address or address payable.
+func IsAddress(t Type) bool {
+	b, ok := t.(*Basic)
+	return ok && (b.kind == Address || b.kind == AddressPayable)
+}
+
+// MobileType returns the type that a literal gets when it is used as a
+// value e.g. assigned to a `var`-like tuple component: the smallest integer
+// type that can hold the value. The other types are returned unchanged. It
+// returns nil if the value does not fit into any integer type.
+func MobileType(t Type) Type {
+	switch t := t.(type) {
+	case *IntLiteral:
+		return smallestInt(t.Value)
+	case *StringLiteral:
+		return Typ[String]
+	}
+	return t
+}
+
+func smallestInt(value *big.Int) Type {
+	signed := value.Sign() < 0
+	for bits := 8; bits <= 256; bits += 8 {
+		if t := (&Int{Bits: bits, Signed: signed}); t.Contains(value) {
+			return t
+		}
+	}
+	return nil
+}
+
+// AssignableTo reports whether a value of type v is implicitly convertible to
+// the type t e.g. when it is assigned to a variable or passed as an argument.
+func AssignableTo(v, t Type) bool {
+	if IsInvalid(v) || IsInvalid(t) {
+		// Don't report the errors caused by other errors.
+		return true
+	}
+
+	if Identical(v, t) {
+		return true
+	}
+
+	switch v := v.(type) {
+	case *Int:
+		t, ok := t.(*Int)
+		if !ok {
+			return false
+		}
+		// Integers convert to larger types of the same sign. An unsigned
+		// integer converts to a signed one that can hold all of its values.
+		if v.Signed == t.Signed {
+			return v.Bits <= t.Bits
+		}
+		return !v.Signed && v.Bits < t.Bits
+
+	case *IntLiteral:
+		switch t := t.(type) {
+		case *Int:
+			return t.Contains(v.Value)
+		case *FixedBytes:
+			// Only zero is implicitly convertible to the fixed bytes, the
+			// hex literals of the right size are not tracked yet.
+			return v.Value.Sign() == 0
+		}
+		return false
+
+	case *StringLiteral:
+		switch t := t.(type) {
+		case *Basic:
+			return t.kind == Bytes || t.kind == String && utf8.ValidString(v.Value)
+		case *FixedBytes:
+			return len(v.Value) <= t.Size
+		}
+		return false
+
+	case *FixedBytes:
+		t, ok := t.(*FixedBytes)
+		return ok && v.Size <= t.Size
+
+	case *Basic:
+		return v.kind == AddressPayable && IsAddress(t)
+
+	case *Contract:
+		t, ok := t.(*Contract)
+		return ok && v.IsBase(t)
+
+	case *Tuple:
+		t, ok := t.(*Tuple)
+		if !ok || len(v.Types) != len(t.Types) {
+			return false
+		}
+		for i := range v.Types {
+			if !AssignableTo(v.Types[i], t.Types[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+// ConvertibleTo reports whether a value of type v can be explicitly converted
+// to the type t e.g. `uint160(x)`.
+func ConvertibleTo(v, t Type) bool {
+	if AssignableTo(v, t) {
+		return true
+	}
+
+	switch v := v.(type) {
+	case *Int:
+		switch t := t.(type) {
+		case *Int:
+			// The sign and the size can't be changed at once.
+			return v.Signed == t.Signed || v.Bits == t.Bits
+		case *FixedBytes:
+			return v.Bits == t.Size*8
+		case *Basic:
+			return IsAddress(t) && !v.Signed && v.Bits == 160
+		case *Enum:
+			return true
+		}
+
+	case *IntLiteral:
+		switch t := t.(type) {
+		case *Int:
+			return t.Contains(v.Value)
+		case *Basic:
+			// e.g. address(0)
+			return IsAddress(t) && (&Int{Bits: 160}).Contains(v.Value)
+		case *Enum:
+			return v.Value.Sign() >= 0 && v.Value.Cmp(big.NewInt(int64(len(t.Members)))) < 0
+		}
+
+	case *FixedBytes:
+		switch t := t.(type) {
+		case *FixedBytes:
+			return true
+		case *Int:
+			return !t.Signed && t.Bits == v.Size*8
+		case *Basic:
+			return IsAddress(t) && v.Size == 20
+		}
+
+	case *Basic:
+		switch v.kind {
+		case Address, AddressPayable:
+			switch t := t.(type) {
+			case *Basic:
+				// payable(x)
+				return IsAddress(t)
+			case *Int:
+				return !t.Signed && t.Bits == 160
+			case *FixedBytes:
+				return t.Size == 20
+			case *Contract:
+				return t.Kind != KindLibrary
+			}
+		case Bytes:
+			switch t := t.(type) {
+			case *Basic:
+				return t.kind == String
+			case *FixedBytes:
+				return true
+			}
+		case String:
+			t, ok := t.(*Basic)
+			return ok && t.kind == Bytes
+		}
+
+	case *Contract:
+		return IsAddress(t)
+
+	case *Enum:
+		_, ok := t.(*Int)
+		return ok
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+package types_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/types"
+)
+
+func Test_AssignableTo(t *testing.T) {
+	uint8Type := &types.Int{Bits: 8}
+	uint256Type := &types.Int{Bits: 256}
+	int16Type := &types.Int{Bits: 16, Signed: true}
+
+	tests := []struct {
+		v, t     types.Type
+		expected bool
+	}{
+		{uint8Type, uint256Type, true},
+		{uint256Type, uint8Type, false},
+		{uint8Type, int16Type, true},
+		{int16Type, uint256Type, false},
+		{&types.IntLiteral{Value: big.NewInt(255)}, uint8Type, true},
+		{&types.IntLiteral{Value: big.NewInt(256)}, uint8Type, false},
+		{&types.IntLiteral{Value: big.NewInt(-1)}, uint256Type, false},
+		{types.Typ[types.AddressPayable], types.Typ[types.Address], true},
+		{types.Typ[types.Address], types.Typ[types.AddressPayable], false},
+		{&types.FixedBytes{Size: 4}, &types.FixedBytes{Size: 32}, true},
+		{&types.FixedBytes{Size: 32}, &types.FixedBytes{Size: 4}, false},
+		{&types.StringLiteral{Value: "abc"}, types.Typ[types.String], true},
+		{&types.StringLiteral{Value: "abc"}, &types.FixedBytes{Size: 2}, false},
+		{types.Typ[types.Bool], uint256Type, false},
+		{types.Typ[types.Invalid], uint256Type, true},
+	}
+
+	for _, tt := range tests {
+		if got := types.AssignableTo(tt.v, tt.t); got != tt.expected {
+			t.Errorf("AssignableTo(%s, %s): expected %t, got %t", tt.v, tt.t, tt.expected, got)
+		}
+	}
+}
+
+func Test_AssignableTo_Contracts(t *testing.T) {
+	base := &types.Contract{Name: "Base"}
+	derived := &types.Contract{Name: "Derived", Bases: []*types.Contract{base}}
+	other := &types.Contract{Name: "Other"}
+
+	tests := []struct {
+		v, t     types.Type
+		expected bool
+	}{
+		{derived, derived, true},
+		{derived, base, true},
+		{base, derived, false},
+		{other, base, false},
+	}
+
+	for _, tt := range tests {
+		if got := types.AssignableTo(tt.v, tt.t); got != tt.expected {
+			t.Errorf("AssignableTo(%s, %s): expected %t, got %t", tt.v, tt.t, tt.expected, got)
+		}
+	}
+}
+
+func Test_ConvertibleTo(t *testing.T) {
+	tests := []struct {
+		v, t     types.Type
+		expected bool
+	}{
+		{&types.Int{Bits: 256}, &types.Int{Bits: 8}, true},
+		{&types.Int{Bits: 8}, &types.Int{Bits: 16, Signed: true}, true},
+		{&types.Int{Bits: 16}, &types.Int{Bits: 8, Signed: true}, false},
+		{&types.Int{Bits: 160}, types.Typ[types.Address], true},
+		{&types.Int{Bits: 256}, types.Typ[types.Address], false},
+		{&types.IntLiteral{Value: big.NewInt(0)}, types.Typ[types.Address], true},
+		{types.Typ[types.Address], types.Typ[types.AddressPayable], true},
+		{types.Typ[types.Bytes], types.Typ[types.String], true},
+		{types.Typ[types.Bool], &types.Int{Bits: 8}, false},
+	}
+
+	for _, tt := range tests {
+		if got := types.ConvertibleTo(tt.v, tt.t); got != tt.expected {
+			t.Errorf("ConvertibleTo(%s, %s): expected %t, got %t", tt.v, tt.t, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+type Error struct {
+	Pos      token.Pos // The position of the error; used to resolve it in a FileSet.
+	Filename string
+	Line     int
+	Column   int
+	Msg      string
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.Filename, e.Line, e.Column, e.Msg)
+}
+
+type ErrorList []Error
+
+func (e ErrorList) Error() string {
+	if len(e) == 0 {
+		return "no errors"
+	}
+
+	var b strings.Builder
+	for i, err := range e {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+func (c *checker) errorf(pos token.Pos, format string, args ...any) {
+	line, col := c.file.GetLineAndColumn(pos)
+	c.errors = append(c.errors, Error{
+		Pos:      pos,
+		Filename: c.file.Name(),
+		Line:     line,
+		Column:   col,
+		Msg:      fmt.Sprintf(format, args...),
+	})
+}
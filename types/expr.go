@@ -0,0 +1,881 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+// record stores the type of the expression and returns it.
+func (c *checker) record(expr ast.Expression, t Type) Type {
+	if t == nil {
+		t = Typ[Invalid]
+	}
+	c.info.Types[expr] = t
+	return t
+}
+
+// expr checks the expression and returns its type. The type is Invalid if
+// the expression has errors.
+func (c *checker) expr(s *scope, expr ast.Expression) Type {
+	switch n := expr.(type) {
+	case *ast.Identifier:
+		return c.record(n, c.ident(s, n))
+	case *ast.NumberLiteral:
+		return c.record(n, &IntLiteral{Value: new(big.Int).Set(&n.Value)})
+	case *ast.BooleanLiteral:
+		return c.record(n, Typ[Bool])
+	case *ast.StringLiteral:
+		return c.record(n, &StringLiteral{Value: n.Value})
+	case *ast.PrefixExpression:
+		return c.record(n, c.prefix(s, n))
+	case *ast.InfixExpression:
+		return c.record(n, c.infix(s, n))
+	case *ast.PostfixExpression:
+		return c.record(n, c.postfix(s, n))
+	case *ast.CallExpression:
+		return c.record(n, c.call(s, n))
+	case *ast.CallOptionsExpression:
+		return c.record(n, c.callOptions(s, n))
+	case *ast.MemberAccessExpression:
+		return c.record(n, c.memberAccess(s, n))
+	case *ast.TupleExpression:
+		return c.record(n, c.tuple(s, n))
+	case *ast.ElementaryTypeExpression:
+		return c.record(n, c.elementaryTypeExpression(s, n))
+	}
+
+	return Typ[Invalid]
+}
+
+func (c *checker) ident(s *scope, n *ast.Identifier) Type {
+	switch n.Value {
+	case "this":
+		if c.contract == nil {
+			c.errorf(n.Pos, "this is only available in contracts")
+			return Typ[Invalid]
+		}
+		return c.contract
+	case "super":
+		if c.contract == nil {
+			c.errorf(n.Pos, "super is only available in contracts")
+			return Typ[Invalid]
+		}
+		return &Super{Contract: c.contract}
+	case "_":
+		// The placeholder statement of the modifiers.
+		if c.modifier {
+			return &Tuple{}
+		}
+	}
+
+	objs := s.lookup(n.Value)
+	if len(objs) == 0 {
+		if t, ok := universe[n.Value]; ok {
+			return t
+		}
+		c.undeclared(n)
+		return Typ[Invalid]
+	}
+
+	// The overloaded functions are resolved by the callee, since it knows
+	// the arguments. Outside of calls the first declaration is used.
+	// TODO: Resolve the overloads used as values e.g. `f.selector`.
+	c.info.Uses[n] = objs[0].decl
+	return objs[0].typ
+}
+
+func (c *checker) prefix(s *scope, n *ast.PrefixExpression) Type {
+	if n.Right == nil {
+		return Typ[Invalid]
+	}
+
+	t := c.expr(s, n.Right)
+	if IsInvalid(t) {
+		return Typ[Invalid]
+	}
+
+	switch n.Operator.Type {
+	case token.NOT:
+		if !AssignableTo(t, Typ[Bool]) {
+			break
+		}
+		return Typ[Bool]
+
+	case token.SUB:
+		switch t := t.(type) {
+		case *IntLiteral:
+			return &IntLiteral{Value: new(big.Int).Neg(t.Value)}
+		case *Int:
+			if t.Signed {
+				return t
+			}
+		}
+
+	case token.BIT_NOT:
+		switch t := t.(type) {
+		case *IntLiteral:
+			return &IntLiteral{Value: new(big.Int).Not(t.Value)}
+		case *Int, *FixedBytes:
+			return t
+		}
+
+	case token.INC, token.DEC:
+		if _, ok := t.(*Int); ok {
+			c.checkAssignable(s, n.Right)
+			return t
+		}
+
+	case token.DELETE:
+		c.checkAssignable(s, n.Right)
+		return &Tuple{}
+	}
+
+	c.errorf(n.Pos, "operator %s not compatible with type %s", n.Operator.Literal, t)
+	return Typ[Invalid]
+}
+
+func (c *checker) postfix(s *scope, n *ast.PostfixExpression) Type {
+	if n.Left == nil {
+		return Typ[Invalid]
+	}
+
+	t := c.expr(s, n.Left)
+	if IsInvalid(t) {
+		return Typ[Invalid]
+	}
+
+	if _, ok := t.(*Int); !ok {
+		c.errorf(n.Operator.Pos, "operator %s not compatible with type %s", n.Operator.Literal, t)
+		return Typ[Invalid]
+	}
+
+	c.checkAssignable(s, n.Left)
+	return t
+}
+
+// assignOperators are the compound assignment operators and the binary
+// operators they apply.
+var assignOperators = map[token.TokenType]token.TokenType{
+	token.ASSIGN_BIT_OR:  token.BIT_OR,
+	token.ASSIGN_BIT_XOR: token.BIT_XOR,
+	token.ASSIGN_BIT_AND: token.BIT_AND,
+	token.ASSIGN_SHL:     token.SHL,
+	token.ASSIGN_SAR:     token.SAR,
+	token.ASSIGN_SHR:     token.SHR,
+	token.ASSIGN_ADD:     token.ADD,
+	token.ASSIGN_SUB:     token.SUB,
+	token.ASSIGN_MUL:     token.MUL,
+	token.ASSIGN_DIV:     token.DIV,
+	token.ASSIGN_MOD:     token.MOD,
+}
+
+func (c *checker) infix(s *scope, n *ast.InfixExpression) Type {
+	if n.Left == nil || n.Right == nil {
+		return Typ[Invalid]
+	}
+
+	x := c.expr(s, n.Left)
+	y := c.expr(s, n.Right)
+	if IsInvalid(x) || IsInvalid(y) {
+		return Typ[Invalid]
+	}
+
+	op := n.Operator.Type
+	switch {
+	case op == token.ASSIGN:
+		c.checkAssignable(s, n.Left)
+		c.assignment(n.Right, y, x, "assignment")
+		return x
+
+	case assignOperators[op] != 0:
+		c.checkAssignable(s, n.Left)
+		t := c.binary(n, assignOperators[op], x, y)
+		if !IsInvalid(t) {
+			c.assignment(n.Right, t, x, "assignment")
+		}
+		return x
+
+	case op == token.CONDITIONAL:
+		// TODO: The parser does not handle the ternary operator yet.
+		return Typ[Invalid]
+	}
+
+	return c.binary(n, op, x, y)
+}
+
+// binary checks the binary operation and returns the type of the result.
+func (c *checker) binary(n *ast.InfixExpression, op token.TokenType, x, y Type) Type {
+	switch op {
+	case token.AND, token.OR:
+		if AssignableTo(x, Typ[Bool]) && AssignableTo(y, Typ[Bool]) {
+			return Typ[Bool]
+		}
+
+	case token.EQUAL, token.NOT_EQUAL:
+		if c.comparable(x, y) {
+			return Typ[Bool]
+		}
+
+	case token.LESS_THAN, token.GREATER_THAN, token.LESS_THAN_OR_EQUAL, token.GREATER_THAN_OR_EQUAL:
+		if t := commonType(x, y); t != nil && ordered(t) {
+			return Typ[Bool]
+		}
+
+	case token.SHL, token.SAR, token.SHR:
+		if !isUnsigned(y) {
+			break
+		}
+		if xl, ok := x.(*IntLiteral); ok {
+			if yl, ok := y.(*IntLiteral); ok {
+				return foldShift(op, xl.Value, yl.Value)
+			}
+			x = MobileType(x)
+		}
+		switch x.(type) {
+		case *Int, *FixedBytes:
+			return x
+		}
+
+	case token.EXP:
+		if !isUnsigned(y) {
+			break
+		}
+		if xl, ok := x.(*IntLiteral); ok {
+			if yl, ok := y.(*IntLiteral); ok {
+				return foldExp(xl.Value, yl.Value)
+			}
+			x = MobileType(x)
+		}
+		if _, ok := x.(*Int); ok {
+			return x
+		}
+
+	case token.ADD, token.SUB, token.MUL, token.DIV, token.MOD,
+		token.BIT_AND, token.BIT_OR, token.BIT_XOR:
+		if xl, ok := x.(*IntLiteral); ok {
+			if yl, ok := y.(*IntLiteral); ok {
+				return fold(op, xl.Value, yl.Value)
+			}
+		}
+		switch t := commonType(x, y).(type) {
+		case *Int:
+			return t
+		case *FixedBytes:
+			if op == token.BIT_AND || op == token.BIT_OR || op == token.BIT_XOR {
+				return t
+			}
+		}
+	}
+
+	c.errorf(n.Operator.Pos, "operator %s not compatible with types %s and %s", n.Operator.Literal, x, y)
+	return Typ[Invalid]
+}
+
+// commonType returns the type both x and y are implicitly convertible to or
+// nil if there is none.
+func commonType(x, y Type) Type {
+	if _, ok := x.(*IntLiteral); ok {
+		x, y = y, x
+	}
+	if _, ok := x.(*IntLiteral); ok {
+		// Both are literals.
+		return MobileType(x)
+	}
+
+	switch {
+	case AssignableTo(y, x):
+		return x
+	case AssignableTo(x, y):
+		return y
+	}
+	return nil
+}
+
+func (c *checker) comparable(x, y Type) bool {
+	t := commonType(x, y)
+	if t == nil {
+		// e.g. comparing an address with a contract is not allowed, but
+		// comparing two instances of the same contract is.
+		return Identical(x, y)
+	}
+
+	switch t := t.(type) {
+	case *Int, *FixedBytes, *Enum, *Contract:
+		return true
+	case *Basic:
+		return t.kind == Bool || IsAddress(t)
+	case *Function:
+		return t.Visibility == ast.External
+	}
+	return false
+}
+
+func ordered(t Type) bool {
+	switch t := t.(type) {
+	case *Int, *FixedBytes, *Enum:
+		return true
+	case *Basic:
+		return IsAddress(t)
+	}
+	return false
+}
+
+func isUnsigned(t Type) bool {
+	switch t := t.(type) {
+	case *Int:
+		return !t.Signed
+	case *IntLiteral:
+		return t.Value.Sign() >= 0
+	}
+	return false
+}
+
+// The literals larger than this are not computed, to protect the checker
+// from expressions like `2 ** 2 ** 100`. solc has a similar limit.
+const maxLiteralBits = 4096
+
+func fold(op token.TokenType, x, y *big.Int) Type {
+	z := new(big.Int)
+	switch op {
+	case token.ADD:
+		z.Add(x, y)
+	case token.SUB:
+		z.Sub(x, y)
+	case token.MUL:
+		z.Mul(x, y)
+	case token.DIV, token.MOD:
+		if y.Sign() == 0 {
+			return Typ[Invalid]
+		}
+		var m big.Int
+		z.QuoRem(x, y, &m)
+		if op == token.MOD {
+			z = &m
+		} else if m.Sign() != 0 {
+			// TODO: The result is a rational number, which is not modelled.
+			return Typ[Invalid]
+		}
+	case token.BIT_AND:
+		z.And(x, y)
+	case token.BIT_OR:
+		z.Or(x, y)
+	case token.BIT_XOR:
+		z.Xor(x, y)
+	}
+
+	if z.BitLen() > maxLiteralBits {
+		return Typ[Invalid]
+	}
+	return &IntLiteral{Value: z}
+}
+
+func foldShift(op token.TokenType, x, y *big.Int) Type {
+	if !y.IsInt64() || y.Int64() > maxLiteralBits {
+		return Typ[Invalid]
+	}
+	if op == token.SHL {
+		return &IntLiteral{Value: new(big.Int).Lsh(x, uint(y.Int64()))}
+	}
+	return &IntLiteral{Value: new(big.Int).Rsh(x, uint(y.Int64()))}
+}
+
+func foldExp(x, y *big.Int) Type {
+	if x.CmpAbs(big.NewInt(1)) > 0 && (!y.IsInt64() || int64(x.BitLen()-1)*y.Int64() > maxLiteralBits) {
+		return Typ[Invalid]
+	}
+	return &IntLiteral{Value: new(big.Int).Exp(x, y, nil)}
+}
+
+// checkAssignable reports an error if the expression can't be assigned to.
+func (c *checker) checkAssignable(s *scope, expr ast.Expression) {
+	switch n := expr.(type) {
+	case *ast.Identifier:
+		objs := s.lookup(n.Value)
+		if len(objs) == 0 {
+			break
+		}
+		if objs[0].constant {
+			c.errorf(n.Pos, "cannot assign to a constant variable: %s", n.Value)
+		}
+		if objs[0].variable {
+			return
+		}
+	case *ast.TupleExpression:
+		// The omitted components are skipped by the assignment.
+		for _, component := range n.Components {
+			if component != nil {
+				c.checkAssignable(s, component)
+			}
+		}
+		return
+	case *ast.MemberAccessExpression:
+		// Only the fields of structs can be assigned to.
+		if _, ok := c.info.Types[n.Expression].(*Struct); ok {
+			return
+		}
+	}
+
+	if !IsInvalid(c.info.Types[expr]) {
+		c.errorf(expr.Start(), "expression is not assignable: %s", expr)
+	}
+}
+
+// tuple returns the type of the tuple expression. The omitted components
+// have no type, so any value can be assigned to them.
+func (c *checker) tuple(s *scope, n *ast.TupleExpression) Type {
+	t := &Tuple{Types: make([]Type, len(n.Components))}
+	for i, component := range n.Components {
+		if component != nil {
+			t.Types[i] = c.expr(s, component)
+		}
+	}
+	return t
+}
+
+/*~*~*~*~*~*~*~*~*~*~*~*~*~*~*~ Calls *~*~*~*~*~*~*~*~*~*~*~*~*~*~*~*/
+
+func (c *checker) args(s *scope, args []ast.Expression) []Type {
+	types := make([]Type, len(args))
+	for i, arg := range args {
+		if arg == nil {
+			types[i] = Typ[Invalid]
+			continue
+		}
+		types[i] = c.expr(s, arg)
+	}
+	return types
+}
+
+// checkArgs checks the arguments of the call against the params.
+func (c *checker) checkArgs(call *ast.CallExpression, args, params []Type, variadic bool) bool {
+	if len(args) != len(params) && (!variadic || len(args) < len(params)) {
+		c.errorf(call.Start(), "wrong number of arguments in the call to %s: expected %d, got %d",
+			call.Ident, len(params), len(args))
+		return false
+	}
+
+	ok := true
+	for i, param := range params {
+		if !AssignableTo(args[i], param) {
+			c.errorf(call.Args[i].Start(), "cannot use %s as %s in argument %d", args[i], param, i+1)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// callee checks the callee and the arguments of the call. If the callee
+// refers to overloaded functions or events, the one matching the arguments
+// is selected.
+func (c *checker) callee(s *scope, n *ast.CallExpression) (Type, []Type) {
+	switch fn := n.Ident.(type) {
+	case *ast.Identifier:
+		if objs := s.overloads(fn.Value); len(objs) > 1 {
+			args := c.args(s, n.Args)
+			types := make([]Type, len(objs))
+			for i, obj := range objs {
+				types[i] = obj.typ
+			}
+			return c.record(fn, c.resolveOverload(n, fn, objs, types, args)), args
+		}
+
+	case *ast.MemberAccessExpression:
+		if fn.Expression == nil || fn.Member == nil {
+			break
+		}
+		// The expression is checked here, so that it is not checked again
+		// when the member is not overloaded.
+		x := c.expr(s, fn.Expression)
+		if objs, types := c.memberOverloads(x, fn.Member.Value); len(objs) > 1 {
+			args := c.args(s, n.Args)
+			return c.record(fn, c.resolveOverload(n, fn.Member, objs, types, args)), args
+		}
+		return c.record(fn, c.selectMember(fn, x)), c.args(s, n.Args)
+	}
+
+	return c.expr(s, n.Ident), c.args(s, n.Args)
+}
+
+// resolveOverload selects the declaration matching the arguments among the
+// overloaded ones. The types are the types of the declarations as seen by
+// the caller. Like in solc, the call is ambiguous if the arguments are
+// implicitly convertible to the params of more than one declaration.
+func (c *checker) resolveOverload(n *ast.CallExpression, ident *ast.Identifier, objs []*object, types, args []Type) Type {
+	var matches []int
+	for i, t := range types {
+		if params, _ := signature(t); matchArgs(t, params, args) {
+			matches = append(matches, i)
+		}
+	}
+
+	switch {
+	case len(matches) == 1:
+		c.info.Uses[ident] = objs[matches[0]].decl
+		return types[matches[0]]
+	case len(matches) > 1 && containsInvalid(args):
+		// The invalid arguments match any params; the error was reported.
+		return Typ[Invalid]
+	case len(matches) > 1:
+		c.errorf(ident.Pos, "ambiguous call to overloaded %s with the arguments %s", ident.Value, typeList(args))
+	default:
+		c.errorf(ident.Pos, "no overload of %s matches the arguments %s", ident.Value, typeList(args))
+	}
+
+	return Typ[Invalid]
+}
+
+func matchArgs(t Type, params, args []Type) bool {
+	variadic := false
+	if fn, ok := t.(*Function); ok {
+		variadic = fn.Variadic
+	}
+	if len(args) != len(params) && (!variadic || len(args) < len(params)) {
+		return false
+	}
+	for i, param := range params {
+		if !AssignableTo(args[i], param) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsInvalid(types []Type) bool {
+	for _, t := range types {
+		if IsInvalid(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *checker) call(s *scope, n *ast.CallExpression) Type {
+	if n.Ident == nil {
+		return Typ[Invalid]
+	}
+
+	callee, args := c.callee(s, n)
+	if IsInvalid(callee) {
+		return Typ[Invalid]
+	}
+
+	switch t := callee.(type) {
+	case *Function:
+		c.checkArgs(n, args, t.Params, t.Variadic)
+		if len(t.Results) == 1 {
+			return t.Results[0]
+		}
+		return &Tuple{Types: t.Results}
+
+	case *TypeType:
+		return c.conversion(n, t.Type, args)
+
+	case *Builtin:
+		return c.builtinCall(n, t, args)
+
+	case *Event:
+		c.errorf(n.Start(), "events can only be emitted: emit %s", n)
+		return Typ[Invalid]
+
+	case *CustomError:
+		// The errors are used in the reverts and, since 0.8.26, in the
+		// requires e.g. `require(ok, Failed())`.
+		c.checkArgs(n, args, t.Params, false)
+		return &Tuple{}
+	}
+
+	c.errorf(n.Start(), "%s is not callable: %s", n.Ident, callee)
+	return Typ[Invalid]
+}
+
+// conversion checks the explicit type conversion e.g. `uint8(x)` and the
+// struct construction.
+func (c *checker) conversion(n *ast.CallExpression, t Type, args []Type) Type {
+	if st, ok := t.(*Struct); ok {
+		params := make([]Type, len(st.Fields))
+		for i, f := range st.Fields {
+			params[i] = f.Type
+		}
+		c.checkArgs(n, args, params, false)
+		return st
+	}
+
+	if len(args) != 1 {
+		c.errorf(n.Start(), "expected exactly one argument in the type conversion, got %d", len(args))
+		return t
+	}
+
+	if !ConvertibleTo(args[0], t) {
+		c.errorf(n.Start(), "explicit conversion from %s to %s is not allowed", args[0], t)
+	}
+	return t
+}
+
+func (c *checker) builtinCall(n *ast.CallExpression, b *Builtin, args []Type) Type {
+	switch b.Name {
+	case "require":
+		if len(args) < 1 || len(args) > 2 {
+			c.errorf(n.Start(), "wrong number of arguments in the call to require: expected 1 or 2, got %d", len(args))
+			break
+		}
+		if !AssignableTo(args[0], Typ[Bool]) {
+			c.errorf(n.Args[0].Start(), "cannot use %s as bool in argument 1", args[0])
+		}
+	case "revert":
+		if len(args) > 1 {
+			c.errorf(n.Start(), "wrong number of arguments in the call to revert: expected 0 or 1, got %d", len(args))
+		} else if len(args) == 1 && !AssignableTo(args[0], Typ[String]) {
+			c.errorf(n.Args[0].Start(), "cannot use %s as string in argument 1", args[0])
+		}
+	case "abi.decode":
+		// The types of the results are not known, see magicMembers.
+		return Typ[Invalid]
+	}
+
+	return &Tuple{}
+}
+
+func (c *checker) elementaryTypeExpression(s *scope, n *ast.ElementaryTypeExpression) Type {
+	t := ElementaryType(n.Kind.Type)
+	if t == nil {
+		c.errorf(n.Pos, "unsupported type: %s", n.Kind.Literal)
+		return Typ[Invalid]
+	}
+
+	if n.Value == nil {
+		return &TypeType{Type: t}
+	}
+
+	// The parser represents the conversions e.g. `address(0)` by the
+	// ElementaryTypeExpression with the converted value.
+	v := c.expr(s, n.Value)
+	if IsInvalid(v) {
+		return t
+	}
+	if !ConvertibleTo(v, t) {
+		c.errorf(n.Pos, "explicit conversion from %s to %s is not allowed", v, t)
+	}
+	return t
+}
+
+/*~*~*~*~*~*~*~*~*~*~*~*~*~*~ Member access *~*~*~*~*~*~*~*~*~*~*~*~*~*/
+
+// callOptions checks the values of the call options e.g. `{value: 1}`. The
+// options don't change the type of the called expression.
+func (c *checker) callOptions(s *scope, n *ast.CallOptionsExpression) Type {
+	for _, value := range n.Values {
+		c.expr(s, value)
+	}
+	return c.expr(s, n.Expression)
+}
+
+func (c *checker) memberAccess(s *scope, n *ast.MemberAccessExpression) Type {
+	if n.Expression == nil || n.Member == nil {
+		return Typ[Invalid]
+	}
+
+	return c.selectMember(n, c.expr(s, n.Expression))
+}
+
+// selectMember returns the type of the member of the checked expression of
+// type x.
+func (c *checker) selectMember(n *ast.MemberAccessExpression, x Type) Type {
+	if IsInvalid(x) {
+		return Typ[Invalid]
+	}
+
+	name := n.Member.Value
+	if t := c.member(x, n.Member); t != nil {
+		return t
+	}
+
+	switch t := x.(type) {
+	case *Contract:
+		if c.hasUnknownBases(t) {
+			// The member can be inherited from the unknown base.
+			return Typ[Invalid]
+		}
+	case *Super:
+		if c.hasUnknownBases(t.Contract) {
+			return Typ[Invalid]
+		}
+	}
+
+	if b, ok := x.(*Basic); ok && b.kind == Address && (name == "transfer" || name == "send") {
+		c.errorf(n.Member.Pos, "%s is only available on address payable, not on address", name)
+		return Typ[Invalid]
+	}
+
+	c.errorf(n.Member.Pos, "member %s not found in %s", name, x)
+	return Typ[Invalid]
+}
+
+// member returns the type of the member of the type x or nil if there is
+// no such member.
+func (c *checker) member(x Type, ident *ast.Identifier) Type {
+	name := ident.Value
+
+	switch x := x.(type) {
+	case *Magic:
+		return magicMembers[x.Kind][name]
+
+	case *Basic:
+		switch x.kind {
+		case Address, AddressPayable:
+			return addressMember(x, name)
+		case Bytes:
+			return arrayMember(x, Typ[Bytes], name)
+		}
+
+	case *FixedBytes:
+		if name == "length" {
+			return uint8Type
+		}
+
+	case *Array:
+		if x.Len < 0 {
+			return arrayMember(x, x.Elem, name)
+		}
+		if name == "length" {
+			return uint256Type
+		}
+
+	case *Struct:
+		if f := x.Field(name); f != nil {
+			return f.Type
+		}
+
+	case *Contract:
+		// Only the external functions and the public state variables are
+		// accessible on the contract instances.
+		if obj := c.contractMember(x, ident); obj != nil {
+			return externalType(obj)
+		}
+
+	case *Super:
+		if obj := c.scopeMember(c.superScope(x.Contract), ident); obj != nil {
+			return obj.typ
+		}
+
+	case *TypeType:
+		switch t := x.Type.(type) {
+		case *Contract:
+			// e.g. `Library.function` or `Base.function`.
+			if obj := c.contractMember(t, ident); obj != nil {
+				return obj.typ
+			}
+		case *Enum:
+			for _, member := range t.Members {
+				if member == name {
+					return t
+				}
+			}
+		}
+
+	case *Function:
+		if name == "selector" && x.Visibility == ast.External {
+			return bytes4Type
+		}
+	}
+
+	return nil
+}
+
+// externalType returns the type of the contract member accessed on a
+// contract instance or nil if the member is not accessible externally.
+func externalType(obj *object) Type {
+	switch t := obj.typ.(type) {
+	case *Function:
+		if t.Visibility == ast.External || t.Visibility == ast.Public {
+			return &Function{Name: t.Name, Params: t.Params, Results: t.Results,
+				Visibility: ast.External, Mutability: t.Mutability}
+		}
+	default:
+		if decl, ok := obj.decl.(*ast.StateVariableDeclaration); ok && decl.Visibility == ast.Public {
+			// The getter of the public state variable.
+			return &Function{Name: obj.name, Results: []Type{t}, Visibility: ast.External, Mutability: ast.View}
+		}
+	}
+	return nil
+}
+
+// memberOverloads returns the overloaded functions accessible as the member
+// of the type x together with their types as seen by the caller.
+func (c *checker) memberOverloads(x Type, name string) ([]*object, []Type) {
+	var objs []*object
+	external := false
+	switch x := x.(type) {
+	case *Contract:
+		if s := c.contracts[x]; s != nil {
+			objs = s.memberOverloads(name, nil)
+		}
+		external = true
+	case *Super:
+		objs = c.superScope(x.Contract).memberOverloads(name, nil)
+	case *TypeType:
+		if contract, ok := x.Type.(*Contract); ok && c.contracts[contract] != nil {
+			objs = c.contracts[contract].memberOverloads(name, nil)
+		}
+	}
+
+	var (
+		accessible []*object
+		types      []Type
+	)
+	for _, obj := range objs {
+		t := obj.typ
+		if external {
+			if t = externalType(obj); t == nil {
+				continue
+			}
+		}
+		accessible = append(accessible, obj)
+		types = append(types, t)
+	}
+	return accessible, types
+}
+
+func (c *checker) contractMember(t *Contract, ident *ast.Identifier) *object {
+	return c.scopeMember(c.contracts[t], ident)
+}
+
+// superScope returns the scope of the members accessible through `super` in
+// the contract: the members of its bases, but not its own.
+func (c *checker) superScope(contract *Contract) *scope {
+	s := newScope(nil)
+	for _, base := range contract.Bases {
+		s.bases = append(s.bases, c.contracts[base])
+	}
+	return s
+}
+
+func (c *checker) scopeMember(s *scope, ident *ast.Identifier) *object {
+	if s == nil {
+		return nil
+	}
+
+	objs := s.lookupMember(ident.Value)
+	if len(objs) == 0 {
+		return nil
+	}
+
+	c.info.Uses[ident] = objs[0].decl
+	return objs[0]
+}
+
+func arrayMember(array, elem Type, name string) Type {
+	switch name {
+	case "length":
+		return uint256Type
+	case "push":
+		if b, ok := array.(*Basic); ok && b.kind == Bytes {
+			elem = &FixedBytes{Size: 1}
+		}
+		return &Function{Name: name, Params: []Type{elem}}
+	case "pop":
+		return &Function{Name: name}
+	}
+	return nil
+}
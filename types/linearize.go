@@ -0,0 +1,107 @@
+package types
+
+import (
+	"errors"
+	"slices"
+)
+
+var (
+	ErrCyclicInheritance   = errors.New("cyclic inheritance")
+	ErrLinearizationFailed = errors.New("inconsistent order of the bases")
+)
+
+// Linearize returns the C3 linearization of the inheritance graph of the
+// contract: the contract followed by its bases from the most derived to the
+// most base one. The members are looked up in this order and `super` refers
+// to the next contract in it.
+//
+// The parents returns the direct bases of a contract in the order of the
+// `is` list. Like in solc, the bases listed later are considered more
+// derived, so in `contract C is A, B` the members of B take precedence.
+//
+// It returns ErrCyclicInheritance if a contract inherits from itself and
+// ErrLinearizationFailed if the order of the bases is inconsistent e.g.
+// in `contract C is B, A` where B is A.
+func Linearize[T comparable](contract T, parents func(T) []T) ([]T, error) {
+	l := &linearizer[T]{
+		parents:  parents,
+		done:     make(map[T][]T),
+		visiting: make(map[T]bool),
+	}
+	return l.linearize(contract)
+}
+
+type linearizer[T comparable] struct {
+	parents  func(T) []T
+	done     map[T][]T
+	visiting map[T]bool
+}
+
+func (l *linearizer[T]) linearize(contract T) ([]T, error) {
+	if result, ok := l.done[contract]; ok {
+		return result, nil
+	}
+	if l.visiting[contract] {
+		return nil, ErrCyclicInheritance
+	}
+	l.visiting[contract] = true
+	defer delete(l.visiting, contract)
+
+	// L(C) = C + merge(L(Pn), ..., L(P1), [Pn, ..., P1])
+	parents := slices.Clone(l.parents(contract))
+	slices.Reverse(parents)
+
+	var seqs [][]T
+	for _, parent := range parents {
+		seq, err := l.linearize(parent)
+		if err != nil {
+			return nil, err
+		}
+		seqs = append(seqs, slices.Clone(seq))
+	}
+	seqs = append(seqs, parents)
+
+	result := []T{contract}
+	for {
+		seqs = slices.DeleteFunc(seqs, func(seq []T) bool { return len(seq) == 0 })
+		if len(seqs) == 0 {
+			break
+		}
+
+		head, ok := mergeHead(seqs)
+		if !ok {
+			return nil, ErrLinearizationFailed
+		}
+		result = append(result, head)
+
+		for i, seq := range seqs {
+			if seq[0] == head {
+				seqs[i] = seq[1:]
+			}
+		}
+	}
+
+	l.done[contract] = result
+	return result, nil
+}
+
+// mergeHead returns the first head of the sequences that is not in the tail
+// of any sequence.
+func mergeHead[T comparable](seqs [][]T) (T, bool) {
+	for _, seq := range seqs {
+		head := seq[0]
+		inTail := false
+		for _, other := range seqs {
+			if slices.Contains(other[1:], head) {
+				inTail = true
+				break
+			}
+		}
+		if !inTail {
+			return head, true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
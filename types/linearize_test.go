@@ -0,0 +1,78 @@
+package types_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ChmielewskiKamil/solbot/types"
+)
+
+func Test_Linearize(t *testing.T) {
+	tests := []struct {
+		name     string
+		graph    map[string][]string // contract -> parents in the order of the `is` list
+		contract string
+		expected string
+		err      error
+	}{
+		{
+			name:     "no bases",
+			graph:    map[string][]string{"A": nil},
+			contract: "A",
+			expected: "A",
+		},
+		{
+			name:     "chain",
+			graph:    map[string][]string{"A": nil, "B": {"A"}, "C": {"B"}},
+			contract: "C",
+			expected: "C B A",
+		},
+		{
+			// The bases listed later in the `is` list are more derived.
+			name:     "diamond",
+			graph:    map[string][]string{"A": nil, "B": {"A"}, "C": {"A"}, "D": {"B", "C"}},
+			contract: "D",
+			expected: "D C B A",
+		},
+		{
+			name: "multiple levels",
+			graph: map[string][]string{
+				"X": nil, "A": {"X"}, "B": {"X"}, "C": {"A", "B"}, "D": {"B", "C"},
+			},
+			contract: "D",
+			expected: "D C B A X",
+		},
+		{
+			name:     "inconsistent order",
+			graph:    map[string][]string{"X": nil, "A": {"X"}, "C": {"A", "X"}},
+			contract: "C",
+			err:      types.ErrLinearizationFailed,
+		},
+		{
+			name:     "cycle",
+			graph:    map[string][]string{"A": {"B"}, "B": {"A"}},
+			contract: "A",
+			err:      types.ErrCyclicInheritance,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := types.Linearize(tt.contract, func(c string) []string { return tt.graph[c] })
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("Expected the error %q, got: %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !slices.Equal(got, strings.Fields(tt.expected)) {
+				t.Errorf("Expected %s, got %s", tt.expected, strings.Join(got, " "))
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package types
+
+import (
+	"github.com/ChmielewskiKamil/solbot/ast"
+)
+
+func (c *checker) statements(s *scope, stmts []ast.Statement) {
+	for _, stmt := range stmts {
+		c.statement(s, stmt)
+	}
+}
+
+func (c *checker) statement(s *scope, stmt ast.Statement) {
+	switch n := stmt.(type) {
+	case *ast.BlockStatement:
+		c.statements(newScope(s), n.Statements)
+
+	case *ast.UncheckedBlockStatement:
+		c.statements(newScope(s), n.Statements)
+
+	case *ast.VariableDeclarationStatement:
+		c.variableDeclaration(s, n, nil)
+
+	case *ast.VariableDeclarationTupleStatement:
+		c.variableDeclarationTuple(s, n)
+
+	case *ast.ExpressionStatement:
+		if n.Expression != nil {
+			c.expr(s, n.Expression)
+		}
+
+	case *ast.IfStatement:
+		if n.Condition != nil {
+			c.condition(s, n.Condition)
+		}
+		if n.Consequence != nil {
+			c.statement(newScope(s), n.Consequence)
+		}
+		if n.Alternative != nil {
+			c.statement(newScope(s), n.Alternative)
+		}
+
+	case *ast.ReturnStatement:
+		c.returnStatement(s, n)
+
+	case *ast.EmitStatement:
+		c.emit(s, n)
+
+	case *ast.RevertStatement:
+		c.revert(s, n)
+
+	case *ast.AssemblyStatement:
+		c.assembly(s, n)
+	}
+}
+
+// assembly records the declarations the identifiers of the inline assembly
+// refer to. The Yul code is not checked, so the identifiers that are not
+// declared e.g. the Yul builtins and variables, are skipped silently.
+func (c *checker) assembly(s *scope, n *ast.AssemblyStatement) {
+	for _, ident := range n.Identifiers {
+		if objs := s.lookup(ident.Value); len(objs) > 0 {
+			c.info.Uses[ident] = objs[0].decl
+			c.record(ident, objs[0].typ)
+		}
+	}
+}
+
+// condition checks that the expression is a bool.
+func (c *checker) condition(s *scope, expr ast.Expression) {
+	t := c.expr(s, expr)
+	if !AssignableTo(t, Typ[Bool]) {
+		c.errorf(expr.Start(), "expected a bool condition, got: %s", t)
+	}
+}
+
+// variableDeclaration declares the local variable. The value is either the
+// initial value of the declaration or, for the tuple components, the type of
+// the corresponding component of the tuple.
+func (c *checker) variableDeclaration(s *scope, n *ast.VariableDeclarationStatement, value Type) {
+	t := c.typeOf(s, n.Type)
+
+	if n.Value != nil {
+		value = c.expr(s, n.Value)
+		c.assignment(n.Value, value, t, "variable declaration")
+	} else if value != nil {
+		c.assignment(n.Name, value, t, "variable declaration")
+	}
+
+	// The variable is declared after the value is checked, since the value
+	// can't refer to the variable.
+	c.declare(s, n.Name, &object{typ: t, decl: n, variable: true})
+}
+
+func (c *checker) variableDeclarationTuple(s *scope, n *ast.VariableDeclarationTupleStatement) {
+	var components []Type
+	if n.Value != nil {
+		switch t := c.expr(s, n.Value).(type) {
+		case *Tuple:
+			components = t.Types
+		default:
+			if !IsInvalid(t) {
+				components = []Type{t}
+			}
+		}
+	}
+
+	if components != nil && len(components) != len(n.Declarations) {
+		c.errorf(n.Opening, "expected a tuple of %d components, got %d", len(n.Declarations), len(components))
+		components = nil
+	}
+
+	for i, decl := range n.Declarations {
+		if decl == nil {
+			continue
+		}
+		var value Type = Typ[Invalid]
+		if components != nil {
+			value = components[i]
+		}
+		c.variableDeclaration(s, decl, value)
+	}
+}
+
+func (c *checker) returnStatement(s *scope, n *ast.ReturnStatement) {
+	var t Type
+	if n.Result != nil {
+		t = c.expr(s, n.Result)
+	}
+
+	// The results are nil if the function does not declare them, or if
+	// they were not parsed, so there is nothing to check against.
+	if c.function == nil || c.function.Results == nil {
+		return
+	}
+
+	results := c.function.Results
+	switch {
+	case t == nil:
+		// `return;` is allowed in the functions with named results.
+	case len(results) == 0:
+		c.errorf(n.Pos, "function does not return any values")
+	case len(results) == 1:
+		c.assignment(n.Result, t, results[0], "return")
+	default:
+		c.assignment(n.Result, t, &Tuple{Types: results}, "return")
+	}
+}
+
+func (c *checker) emit(s *scope, n *ast.EmitStatement) {
+	call, ok := n.Expression.(*ast.CallExpression)
+	if !ok {
+		if n.Expression != nil {
+			c.expr(s, n.Expression)
+		}
+		c.errorf(n.Pos, "expected an event call in the emit statement")
+		return
+	}
+
+	callee, args := c.callee(s, call)
+	event, ok := callee.(*Event)
+	if !ok {
+		if !IsInvalid(callee) {
+			c.errorf(call.Ident.Start(), "expected an event, got: %s", callee)
+		}
+		c.record(call, Typ[Invalid])
+		return
+	}
+
+	c.checkArgs(call, args, event.Params, false)
+	c.record(call, &Tuple{})
+}
+
+func (c *checker) revert(s *scope, n *ast.RevertStatement) {
+	call, ok := n.Expression.(*ast.CallExpression)
+	if !ok {
+		if n.Expression != nil {
+			c.expr(s, n.Expression)
+		}
+		c.errorf(n.Pos, "expected an error call in the revert statement")
+		return
+	}
+
+	callee, args := c.callee(s, call)
+	customErr, ok := callee.(*CustomError)
+	if !ok {
+		if !IsInvalid(callee) {
+			c.errorf(call.Ident.Start(), "expected an error, got: %s", callee)
+		}
+		c.record(call, Typ[Invalid])
+		return
+	}
+
+	c.checkArgs(call, args, customErr.Params, false)
+	c.record(call, &Tuple{})
+}
+
+// assignment checks that the value is implicitly convertible to the type.
+func (c *checker) assignment(node ast.Node, value, t Type, context string) {
+	if !AssignableTo(value, t) {
+		c.errorf(node.Start(), "cannot use %s as %s in %s", value, t, context)
+	}
+}
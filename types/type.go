@@ -0,0 +1,321 @@
+// Package types declares the data types of Solidity and implements the type
+// checker, which computes the type of every expression in a file.
+//
+// The package is modelled after go/types. The types are represented by the
+// implementations of the Type interface. Types are compared with Identical,
+// not with ==, since two occurrences of e.g. `uint256[]` are different values.
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ChmielewskiKamil/solbot/ast"
+)
+
+// Type is implemented by all the types.
+type Type interface {
+	String() string // e.g. "uint256", "mapping(address => uint256)"
+}
+
+/*~*~*~*~*~*~*~*~*~*~*~*~*~ Elementary ~*~*~*~*~*~*~*~*~*~*~*~*~*/
+
+type BasicKind int
+
+const (
+	Invalid BasicKind = iota // the type of expressions that could not be checked
+	Bool
+	Address
+	AddressPayable
+	String
+	Bytes // dynamically sized byte array; fixed size ones are FixedBytes
+)
+
+// Basic is an elementary type without a size.
+type Basic struct {
+	kind BasicKind
+	name string
+}
+
+func (t *Basic) Kind() BasicKind { return t.kind }
+func (t *Basic) String() string  { return t.name }
+
+// The Basic types are singletons, so they can be compared with ==.
+var (
+	Typ = [...]*Basic{
+		Invalid:        {Invalid, "invalid type"},
+		Bool:           {Bool, "bool"},
+		Address:        {Address, "address"},
+		AddressPayable: {AddressPayable, "address payable"},
+		String:         {String, "string"},
+		Bytes:          {Bytes, "bytes"},
+	}
+)
+
+// Int is an integer type e.g. uint8 or int256.
+type Int struct {
+	Bits   int // 8, 16, ..., 256
+	Signed bool
+}
+
+func (t *Int) String() string {
+	if t.Signed {
+		return fmt.Sprintf("int%d", t.Bits)
+	}
+	return fmt.Sprintf("uint%d", t.Bits)
+}
+
+// Min returns the smallest value of the type.
+func (t *Int) Min() *big.Int {
+	if !t.Signed {
+		return new(big.Int)
+	}
+	return new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), uint(t.Bits-1)))
+}
+
+// Max returns the largest value of the type.
+func (t *Int) Max() *big.Int {
+	bits := t.Bits
+	if t.Signed {
+		bits--
+	}
+	max := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	return max.Sub(max, big.NewInt(1))
+}
+
+// Contains reports whether the value is in the range of the type.
+func (t *Int) Contains(value *big.Int) bool {
+	return value.Cmp(t.Min()) >= 0 && value.Cmp(t.Max()) <= 0
+}
+
+// FixedBytes is a fixed size byte array e.g. bytes4 or bytes32.
+type FixedBytes struct {
+	Size int // 1, 2, ..., 32
+}
+
+func (t *FixedBytes) String() string { return fmt.Sprintf("bytes%d", t.Size) }
+
+/*~*~*~*~*~*~*~*~*~*~*~*~*~*~ Literals ~*~*~*~*~*~*~*~*~*~*~*~*~*~*/
+
+// IntLiteral is the type of an integer literal and of the constant
+// expressions computed from literals e.g. `1 + 2`. Such expressions are
+// computed with arbitrary precision and converted to a real type only when
+// they are used together with one, see MobileType.
+type IntLiteral struct {
+	Value *big.Int
+}
+
+func (t *IntLiteral) String() string { return "int_const " + t.Value.String() }
+
+// StringLiteral is the type of a string literal. It is implicitly
+// convertible to string, bytes and to large enough FixedBytes.
+type StringLiteral struct {
+	Value string
+}
+
+func (t *StringLiteral) String() string { return fmt.Sprintf("literal_string %q", t.Value) }
+
+/*~*~*~*~*~*~*~*~*~*~*~*~*~*~ Composite *~*~*~*~*~*~*~*~*~*~*~*~*~*/
+
+// Array is a fixed size or a dynamic array.
+type Array struct {
+	Elem Type
+	Len  int // -1 for dynamic arrays
+}
+
+func (t *Array) String() string {
+	if t.Len < 0 {
+		return t.Elem.String() + "[]"
+	}
+	return fmt.Sprintf("%s[%d]", t.Elem, t.Len)
+}
+
+type Mapping struct {
+	Key   Type
+	Value Type
+}
+
+func (t *Mapping) String() string { return fmt.Sprintf("mapping(%s => %s)", t.Key, t.Value) }
+
+type Field struct {
+	Name string
+	Type Type
+}
+
+type Struct struct {
+	Name   string
+	Fields []*Field
+}
+
+func (t *Struct) String() string { return "struct " + t.Name }
+
+// Field returns the field with the name or nil if there is none.
+func (t *Struct) Field(name string) *Field {
+	for _, f := range t.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+type Enum struct {
+	Name    string
+	Members []string
+}
+
+func (t *Enum) String() string { return "enum " + t.Name }
+
+type ContractKind int
+
+const (
+	KindContract ContractKind = iota
+	KindInterface
+	KindLibrary
+)
+
+// Contract is the type of a contract, interface or library instance.
+type Contract struct {
+	Name  string
+	Kind  ContractKind
+	Decl  ast.Declaration // the declaration of the contract; or nil
+	Bases []*Contract     // the linearized bases from the most derived; see Linearize
+}
+
+// IsBase reports whether the contract inherits from the base.
+func (t *Contract) IsBase(base *Contract) bool {
+	for _, b := range t.Bases {
+		if b == base {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Contract) String() string {
+	switch t.Kind {
+	case KindInterface:
+		return "interface " + t.Name
+	case KindLibrary:
+		return "library " + t.Name
+	default:
+		return "contract " + t.Name
+	}
+}
+
+// Function is the type of functions, including the builtin ones.
+type Function struct {
+	Name       string // the name of the declared function; or empty for function types
+	Params     []Type
+	Results    []Type
+	Visibility ast.Visibility
+	Mutability ast.Mutability
+	Variadic   bool // the last param can be repeated; only builtins e.g. abi.encode
+}
+
+func (t *Function) String() string {
+	var out strings.Builder
+	out.WriteString("function ")
+	out.WriteString(typeList(t.Params))
+	if t.Visibility != 0 {
+		out.WriteString(" " + t.Visibility.String())
+	}
+	if t.Mutability != 0 {
+		out.WriteString(" " + t.Mutability.String())
+	}
+	if len(t.Results) > 0 {
+		out.WriteString(" returns " + typeList(t.Results))
+	}
+	return out.String()
+}
+
+// Event is the type of an event name, which can only be emitted.
+type Event struct {
+	Name   string
+	Params []Type
+}
+
+func (t *Event) String() string { return "event " + t.Name + typeList(t.Params) }
+
+// CustomError is the type of an error name e.g. `Unauthorized` of
+// `error Unauthorized(address caller);`. It can be used to revert.
+type CustomError struct {
+	Name   string
+	Params []Type
+}
+
+func (t *CustomError) String() string { return "error " + t.Name + typeList(t.Params) }
+
+// Modifier is the type of a modifier name.
+type Modifier struct {
+	Name   string
+	Params []Type
+}
+
+func (t *Modifier) String() string { return "modifier " + t.Name + typeList(t.Params) }
+
+// Tuple is the type of a function call with zero or more than one result
+// and of the tuple expressions.
+type Tuple struct {
+	Types []Type // the omitted components of the tuple expressions are nil
+}
+
+func (t *Tuple) String() string { return "tuple" + typeList(t.Types) }
+
+// TypeType is the type of an expression that names a type e.g. the
+// `uint256` and the `Vault` in `uint256(x)` and `Vault(addr)`.
+type TypeType struct {
+	Type Type
+}
+
+func (t *TypeType) String() string { return "type(" + t.Type.String() + ")" }
+
+// Super is the type of `super` in the contract. Its members are looked up
+// in the bases of the contract, skipping the contract itself.
+type Super struct {
+	Contract *Contract
+}
+
+func (t *Super) String() string { return "type(contract super " + t.Contract.Name + ")" }
+
+type MagicKind int
+
+const (
+	MagicMessage     MagicKind = iota // msg
+	MagicBlock                        // block
+	MagicTransaction                  // tx
+	MagicABI                          // abi
+)
+
+// Magic is the type of the global variables, which members are builtin.
+type Magic struct {
+	Kind MagicKind
+}
+
+func (t *Magic) String() string {
+	return [...]string{"msg", "block", "tx", "abi"}[t.Kind]
+}
+
+// Builtin is the type of the global functions e.g. require. They don't have a
+// single signature, the checker knows how to check each of them.
+type Builtin struct {
+	Name string
+}
+
+func (t *Builtin) String() string { return "builtin " + t.Name }
+
+func typeList(types []Type) string {
+	var out strings.Builder
+	out.WriteString("(")
+	for i, t := range types {
+		if i > 0 {
+			out.WriteString(", ")
+		}
+		if t != nil {
+			out.WriteString(t.String())
+		}
+	}
+	out.WriteString(")")
+	return out.String()
+}
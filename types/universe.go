@@ -0,0 +1,140 @@
+package types
+
+import (
+	"github.com/ChmielewskiKamil/solbot/ast"
+	"github.com/ChmielewskiKamil/solbot/token"
+)
+
+// The frequently used types.
+var (
+	uint8Type   = &Int{Bits: 8}
+	uint256Type = &Int{Bits: 256}
+	bytes4Type  = &FixedBytes{Size: 4}
+	bytes32Type = &FixedBytes{Size: 32}
+)
+
+// ElementaryType returns the type denoted by the elementary type keyword
+// e.g. token.UINT_256. It returns nil for the fixed point types, which are
+// not supported.
+func ElementaryType(tt token.TokenType) Type {
+	switch {
+	case tt == token.INT:
+		return &Int{Bits: 256, Signed: true}
+	case token.INT_8 <= tt && tt <= token.INT_256:
+		return &Int{Bits: int(tt-token.INT_8+1) * 8, Signed: true}
+	case tt == token.UINT:
+		return &Int{Bits: 256}
+	case token.UINT_8 <= tt && tt <= token.UINT_256:
+		return &Int{Bits: int(tt-token.UINT_8+1) * 8}
+	case token.BYTES_1 <= tt && tt <= token.BYTES_32:
+		return &FixedBytes{Size: int(tt-token.BYTES_1) + 1}
+	case tt == token.BYTES:
+		return Typ[Bytes]
+	case tt == token.STRING:
+		return Typ[String]
+	case tt == token.ADDRESS:
+		return Typ[Address]
+	case tt == token.PAYABLE:
+		// The `payable` in `payable(x)`.
+		return Typ[AddressPayable]
+	case tt == token.BOOL:
+		return Typ[Bool]
+	}
+	return nil
+}
+
+// universe holds the globally available names. The `this` and `super` are
+// handled by the checker, since their types depend on the contract. The
+// builtin functions with overloads are of the Builtin type, the checker knows
+// how to check their calls.
+var universe = map[string]Type{
+	"msg":   &Magic{Kind: MagicMessage},
+	"block": &Magic{Kind: MagicBlock},
+	"tx":    &Magic{Kind: MagicTransaction},
+	"abi":   &Magic{Kind: MagicABI},
+
+	"require": &Builtin{Name: "require"},
+	"revert":  &Builtin{Name: "revert"},
+
+	"assert":       &Function{Name: "assert", Params: []Type{Typ[Bool]}, Mutability: ast.Pure},
+	"keccak256":    &Function{Name: "keccak256", Params: []Type{Typ[Bytes]}, Results: []Type{bytes32Type}, Mutability: ast.Pure},
+	"sha256":       &Function{Name: "sha256", Params: []Type{Typ[Bytes]}, Results: []Type{bytes32Type}, Mutability: ast.Pure},
+	"ripemd160":    &Function{Name: "ripemd160", Params: []Type{Typ[Bytes]}, Results: []Type{&FixedBytes{Size: 20}}, Mutability: ast.Pure},
+	"ecrecover":    &Function{Name: "ecrecover", Params: []Type{bytes32Type, uint8Type, bytes32Type, bytes32Type}, Results: []Type{Typ[Address]}, Mutability: ast.Pure},
+	"addmod":       &Function{Name: "addmod", Params: []Type{uint256Type, uint256Type, uint256Type}, Results: []Type{uint256Type}, Mutability: ast.Pure},
+	"mulmod":       &Function{Name: "mulmod", Params: []Type{uint256Type, uint256Type, uint256Type}, Results: []Type{uint256Type}, Mutability: ast.Pure},
+	"gasleft":      &Function{Name: "gasleft", Results: []Type{uint256Type}, Mutability: ast.View},
+	"blockhash":    &Function{Name: "blockhash", Params: []Type{uint256Type}, Results: []Type{bytes32Type}, Mutability: ast.View},
+	"blobhash":     &Function{Name: "blobhash", Params: []Type{uint256Type}, Results: []Type{bytes32Type}, Mutability: ast.View},
+	"selfdestruct": &Function{Name: "selfdestruct", Params: []Type{Typ[AddressPayable]}},
+}
+
+// magicMembers are the members of the global variables.
+var magicMembers = map[MagicKind]map[string]Type{
+	MagicMessage: {
+		"sender": Typ[Address],
+		"value":  uint256Type,
+		"data":   Typ[Bytes],
+		"sig":    bytes4Type,
+	},
+	MagicBlock: {
+		"basefee":     uint256Type,
+		"blobbasefee": uint256Type,
+		"chainid":     uint256Type,
+		"coinbase":    Typ[AddressPayable],
+		"difficulty":  uint256Type,
+		"gaslimit":    uint256Type,
+		"number":      uint256Type,
+		"prevrandao":  uint256Type,
+		"timestamp":   uint256Type,
+	},
+	MagicTransaction: {
+		"gasprice": uint256Type,
+		"origin":   Typ[Address],
+	},
+	MagicABI: {
+		"encode":             &Function{Name: "encode", Results: []Type{Typ[Bytes]}, Mutability: ast.Pure, Variadic: true},
+		"encodePacked":       &Function{Name: "encodePacked", Results: []Type{Typ[Bytes]}, Mutability: ast.Pure, Variadic: true},
+		"encodeWithSelector": &Function{Name: "encodeWithSelector", Params: []Type{bytes4Type}, Results: []Type{Typ[Bytes]}, Mutability: ast.Pure, Variadic: true},
+		"encodeWithSignature": &Function{Name: "encodeWithSignature", Params: []Type{Typ[String]}, Results: []Type{Typ[Bytes]},
+			Mutability: ast.Pure, Variadic: true},
+		// The results of abi.decode depend on the types passed as the
+		// second argument, which can't be expressed in the AST yet.
+		"decode": &Builtin{Name: "abi.decode"},
+	},
+}
+
+// addressMember returns the type of the member of an address or nil if
+// there is no such member. The transfer and send are only available on
+// address payable.
+func addressMember(t *Basic, name string) Type {
+	callResults := []Type{Typ[Bool], Typ[Bytes]}
+
+	switch name {
+	case "balance":
+		return uint256Type
+	case "code":
+		return Typ[Bytes]
+	case "codehash":
+		return bytes32Type
+	case "call":
+		return &Function{Name: name, Params: []Type{Typ[Bytes]}, Results: callResults, Mutability: ast.Payable}
+	case "delegatecall":
+		return &Function{Name: name, Params: []Type{Typ[Bytes]}, Results: callResults}
+	case "staticcall":
+		return &Function{Name: name, Params: []Type{Typ[Bytes]}, Results: callResults, Mutability: ast.View}
+	}
+
+	if t.kind != AddressPayable {
+		return nil
+	}
+
+	switch name {
+	case "transfer":
+		return &Function{Name: name, Params: []Type{uint256Type}}
+	case "send":
+		return &Function{Name: name, Params: []Type{uint256Type}, Results: []Type{Typ[Bool]}}
+	}
+
+	return nil
+}